@@ -0,0 +1,74 @@
+// Command x12schemagen generates Go source declaring an implementation
+// guide schema from a pyx12 XML transaction map.
+//
+// Usage:
+//
+//	x12schemagen [flags] map.xml
+//
+// The flags are:
+//
+//	-pkg name
+//		package name of the generated file (default "hipaa")
+//	-var name
+//		name of the generated variable (required)
+//	-version ref
+//		implementation convention reference to record, e.g. 005010X222A1
+//	-o file
+//		output file (default standard output)
+//
+// It is intended for use with go:generate:
+//
+//	//go:generate x12schemagen -var X223A3 -version 005010X223A3 -o x223a3_gen.go maps/837.5010.X223.A3.xml
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tmc/x12/schema/importer"
+)
+
+func main() {
+	pkg := flag.String("pkg", "hipaa", "package name of the generated file")
+	name := flag.String("var", "", "name of the generated variable")
+	version := flag.String("version", "", "implementation convention reference, e.g. 005010X222A1")
+	out := flag.String("o", "", "output file (default standard output)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: x12schemagen [flags] map.xml\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || *name == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *pkg, *name, *version, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "x12schemagen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, pkg, name, version, out string) error {
+	f, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	ts, err := importer.ReadXML(f)
+	if err != nil {
+		return err
+	}
+	ts.Version = version
+	var b bytes.Buffer
+	if err := importer.WriteGo(&b, pkg, name, ts); err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = io.Copy(os.Stdout, &b)
+		return err
+	}
+	return os.WriteFile(out, b.Bytes(), 0o666)
+}
@@ -88,8 +88,9 @@ func WithMaxSegmentSize(n int) DecodeOption {
 
 // A Decoder reads an X12 document from an input stream.
 type Decoder struct {
-	r    io.Reader
-	opts []DecodeOption
+	r      io.Reader
+	opts   []DecodeOption
+	header *ISA
 }
 
 // NewDecoder returns a new Decoder that reads from r.
@@ -109,6 +110,7 @@ func NewDecoder(r io.Reader, opts ...DecodeOption) *Decoder {
 func (dec *Decoder) Decode() (*Document, error) {
 	state := initializeDecodeState(dec.opts)
 	segmentParsers := state.getSegmentParsers()
+	defer func() { dec.header = state.doc.Interchange.Header }()
 
 	r := bufio.NewReader(dec.r)
 	term := DefaultSegmentTerminator[0]
@@ -125,7 +127,7 @@ func (dec *Decoder) Decode() (*Document, error) {
 
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(nil, state.maxSegmentSize)
-	scanner.Split(scanSegments(term))
+	scanner.Split(scanSegments(term, state.elementSeparator[0]))
 	for scanner.Scan() {
 		if err := state.processLine(scanner.Text(), segmentParsers); err != nil {
 			return nil, err
@@ -141,6 +143,14 @@ func (dec *Decoder) Decode() (*Document, error) {
 	return state.doc, nil
 }
 
+// Header returns the interchange header (ISA) read by the last call to
+// Decode, or nil if it read none. It is available when Decode fails
+// after reading the ISA, so that the interchange can still be answered
+// with a TA1.
+func (dec *Decoder) Header() *ISA {
+	return dec.header
+}
+
 // Decode decodes an X12 document from an io.Reader.
 //
 // Like Decoder.Decode, it returns io.EOF if the input contains no
@@ -300,12 +310,22 @@ func isAlnum(b byte) bool {
 }
 
 // scanSegments returns a bufio.SplitFunc that splits an EDI document
-// into segments on the given segment terminator.
-func scanSegments(term byte) bufio.SplitFunc {
+// into segments on the given segment terminator. A binary data segment
+// (BIN) is split after the number of bytes its BIN01 declares instead,
+// since its data may contain the terminator; sep is the element
+// separator.
+func scanSegments(term, sep byte) bufio.SplitFunc {
 	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		if atEOF && len(data) == 0 {
 			return 0, nil, nil
 		}
+		end, ok, more := binarySegment(data, sep, term)
+		if more && !atEOF {
+			return 0, nil, nil
+		}
+		if ok {
+			return end + 1, data[:end], nil
+		}
 		if i := bytes.IndexByte(data, term); i >= 0 {
 			// We have a full segment.
 			return i + 1, data[0:i], nil
@@ -319,8 +339,56 @@ func scanSegments(term byte) bufio.SplitFunc {
 	}
 }
 
+// binarySegment reports whether data begins, after any line breaks, with
+// a complete binary data segment: "BIN", its length (BIN01), and that
+// many bytes of data (BIN02), followed by the segment terminator term.
+// It returns the offset of the terminator. more reports that data ends
+// before it can tell.
+func binarySegment(data []byte, sep, term byte) (end int, ok, more bool) {
+	i := 0
+	for i < len(data) && (data[i] == '\r' || data[i] == '\n') {
+		i++
+	}
+	prefix := []byte{'B', 'I', 'N', sep}
+	p := data[i:]
+	if len(p) < len(prefix) {
+		return 0, false, bytes.HasPrefix(prefix, p)
+	}
+	if !bytes.HasPrefix(p, prefix) {
+		return 0, false, false
+	}
+	j := len(prefix)
+	for j < len(p) && '0' <= p[j] && p[j] <= '9' {
+		j++
+	}
+	if j == len(p) {
+		return 0, false, true
+	}
+	if j == len(prefix) || j-len(prefix) > 15 || p[j] != sep {
+		return 0, false, false
+	}
+	n, err := strconv.Atoi(string(p[len(prefix):j]))
+	if err != nil {
+		return 0, false, false
+	}
+	end = i + j + 1 + n
+	if end >= len(data) {
+		return 0, false, true
+	}
+	if data[end] != term {
+		return 0, false, false
+	}
+	return end, true, false
+}
+
 func (s *decodeState) processLine(line string, parsers map[string]segmentParser) error {
-	segment := strings.Trim(line, "\r\n")
+	segment := strings.TrimLeft(line, "\r\n")
+	// The data of a binary segment is kept whole: it may end in line
+	// breaks and contain the element separator.
+	binary := strings.HasPrefix(segment, "BIN"+s.elementSeparator)
+	if !binary {
+		segment = strings.TrimRight(segment, "\r\n")
+	}
 	if segment == "" {
 		// Stray terminators and blank lines are not segments; they do
 		// not advance the segment ordinal used by ParseError and the
@@ -329,7 +397,12 @@ func (s *decodeState) processLine(line string, parsers map[string]segmentParser)
 	}
 	s.lineIndex++
 
-	elements := strings.Split(segment, s.elementSeparator)
+	var elements []string
+	if binary {
+		elements = strings.SplitN(segment, s.elementSeparator, 3)
+	} else {
+		elements = strings.Split(segment, s.elementSeparator)
+	}
 	segmentID, _ := s.extractSegmentID(elements)
 
 	parseFunc, exists := parsers[segmentID]
@@ -343,8 +416,13 @@ func (s *decodeState) processLine(line string, parsers map[string]segmentParser)
 // Validate checks that the document's envelope is structurally sound:
 // header and trailer segments are present, their control numbers match,
 // and the trailer counts (IEA01, GE01, SE01) match the document's
-// contents. It does not validate segments against a transaction-set
-// implementation guide.
+// contents. Unless the envelope was added automatically, it also checks
+// the ISA and GS values: field widths, qualifier and indicator codes,
+// dates, times, control numbers, and that ISA11 and ISA16 are distinct
+// delimiters, and that each group's GS01 and GS08 agree with the
+// transaction sets it carries. It does not validate segments against a
+// transaction-set implementation guide. Envelope problems are reported
+// as *EnvelopeError values naming the segment and element at fault.
 func (doc *Document) Validate() error {
 	if doc == nil {
 		return fmt.Errorf("%w: doc nil", ErrInvalidArgument)
@@ -354,17 +432,22 @@ func (doc *Document) Validate() error {
 	}
 	// check that the ISA and IEA segments are present and match
 	if doc.Interchange.Header == nil {
-		return fmt.Errorf("%w: ISA segment missing", ErrInvalidFormat)
+		return envelopeErrorf("ISA", 0, "%w: ISA segment missing", ErrInvalidFormat)
 	}
 	if doc.Interchange.Trailer == nil {
-		return fmt.Errorf("%w: IEA segment missing", ErrInvalidFormat)
+		return envelopeErrorf("IEA", 0, "%w: IEA segment missing", ErrInvalidFormat)
 	}
 	if !controlNumbersMatch(doc.Interchange.Header.ControlNumber, doc.Interchange.Trailer.ControlNumber) {
-		return fmt.Errorf("%w: ISA and IEA control numbers do not match (%v != %v)", ErrInvalidFormat, doc.Interchange.Header.ControlNumber, doc.Interchange.Trailer.ControlNumber)
+		return envelopeErrorf("IEA", 2, "%w: ISA and IEA control numbers do not match (%v != %v)", ErrInvalidFormat, doc.Interchange.Header.ControlNumber, doc.Interchange.Trailer.ControlNumber)
 	}
-	if err := checkCount("IEA01 functional group count", doc.Interchange.Trailer.FunctionalGroupCount, len(doc.Interchange.FunctionGroups)); err != nil {
+	if err := checkCount("IEA", 1, "IEA01 functional group count", doc.Interchange.Trailer.FunctionalGroupCount, len(doc.Interchange.FunctionGroups)); err != nil {
 		return err
 	}
+	if !doc.EnvelopeAutomaticallyAdded {
+		if err := doc.checkISA(); err != nil {
+			return err
+		}
+	}
 
 	// check that the GS and GE segments are present and match
 	for _, functionGroup := range doc.Interchange.FunctionGroups {
@@ -372,17 +455,22 @@ func (doc *Document) Validate() error {
 			return fmt.Errorf("%w: nil function group", ErrInvalidFormat)
 		}
 		if functionGroup.Header == nil {
-			return fmt.Errorf("%w: GS segment missing", ErrInvalidFormat)
+			return envelopeErrorf("GS", 0, "%w: GS segment missing", ErrInvalidFormat)
 		}
 		if functionGroup.Trailer == nil {
-			return fmt.Errorf("%w: GE segment missing", ErrInvalidFormat)
+			return envelopeErrorf("GE", 0, "%w: GE segment missing", ErrInvalidFormat)
 		}
 		if !controlNumbersMatch(functionGroup.Header.ControlNumber, functionGroup.Trailer.ControlNumber) {
-			return fmt.Errorf("%w: GS and GE control numbers do not match (%v != %v)", ErrInvalidFormat, functionGroup.Header.ControlNumber, functionGroup.Trailer.ControlNumber)
+			return envelopeErrorf("GE", 2, "%w: GS and GE control numbers do not match (%v != %v)", ErrInvalidFormat, functionGroup.Header.ControlNumber, functionGroup.Trailer.ControlNumber)
 		}
-		if err := checkCount("GE01 transaction set count", functionGroup.Trailer.TransactionSetCount, len(functionGroup.Transactions)); err != nil {
+		if err := checkCount("GE", 1, "GE01 transaction set count", functionGroup.Trailer.TransactionSetCount, len(functionGroup.Transactions)); err != nil {
 			return err
 		}
+		if !doc.EnvelopeAutomaticallyAdded {
+			if err := checkGS(functionGroup.Header); err != nil {
+				return err
+			}
+		}
 	}
 
 	// check that the ST and SE segments are present and match
@@ -392,17 +480,22 @@ func (doc *Document) Validate() error {
 				return fmt.Errorf("%w: nil transaction", ErrInvalidFormat)
 			}
 			if transaction.Header == nil {
-				return fmt.Errorf("%w: ST segment missing", ErrInvalidFormat)
+				return envelopeErrorf("ST", 0, "%w: ST segment missing", ErrInvalidFormat)
 			}
 			if transaction.Trailer == nil {
-				return fmt.Errorf("%w: SE segment missing", ErrInvalidFormat)
+				return envelopeErrorf("SE", 0, "%w: SE segment missing", ErrInvalidFormat)
 			}
 			if !controlNumbersMatch(transaction.Header.ControlNumber, transaction.Trailer.ControlNumber) {
-				return fmt.Errorf("%w: ST and SE control numbers do not match (%v != %v)", ErrInvalidFormat, transaction.Header.ControlNumber, transaction.Trailer.ControlNumber)
+				return envelopeErrorf("SE", 2, "%w: ST and SE control numbers do not match (%v != %v)", ErrInvalidFormat, transaction.Header.ControlNumber, transaction.Trailer.ControlNumber)
 			}
 			// SE01 counts every segment in the transaction set,
 			// including the ST and SE segments themselves.
-			if err := checkCount("SE01 segment count", transaction.Trailer.SegmentCount, len(transaction.Segments)+2); err != nil {
+			if err := checkCount("SE", 1, "SE01 segment count", transaction.Trailer.SegmentCount, len(transaction.Segments)+2); err != nil {
+				return err
+			}
+		}
+		if !doc.EnvelopeAutomaticallyAdded {
+			if err := checkGroupTransactions(functionGroup); err != nil {
 				return err
 			}
 		}
@@ -417,15 +510,15 @@ func controlNumbersMatch(a, b string) bool {
 	return strings.TrimSpace(a) == strings.TrimSpace(b)
 }
 
-// checkCount verifies that a trailer count element matches the number of
-// units actually present in the document.
-func checkCount(what, value string, actual int) error {
+// checkCount verifies that a trailer count element, element of segment
+// id, matches the number of units actually present in the document.
+func checkCount(id string, element int, what, value string, actual int) error {
 	n, err := strconv.Atoi(strings.TrimSpace(value))
 	if err != nil {
-		return fmt.Errorf("%w: %s %q is not a number", ErrInvalidFormat, what, value)
+		return envelopeErrorf(id, element, "%w: %s %q is not a number", ErrInvalidFormat, what, value)
 	}
 	if n != actual {
-		return fmt.Errorf("%w: %s is %d, document contains %d", ErrInvalidFormat, what, n, actual)
+		return envelopeErrorf(id, element, "%w: %s is %d, document contains %d", ErrInvalidFormat, what, n, actual)
 	}
 	return nil
 }
@@ -437,6 +530,7 @@ func (s *decodeState) getSegmentParsers() map[string]segmentParser {
 	return map[string]segmentParser{
 		"ISA":     (*decodeState).parseISA,
 		"IEA":     (*decodeState).parseIEA,
+		"TA1":     (*decodeState).parseTA1,
 		"GS":      (*decodeState).parseGS,
 		"GE":      (*decodeState).parseGE,
 		"ST":      (*decodeState).parseST,
@@ -489,6 +583,26 @@ func (s *decodeState) parseIEA(elements []string) error {
 	return nil
 }
 
+func (s *decodeState) parseTA1(elements []string) error {
+	if s.doc.Interchange.Header == nil {
+		return s.parseErrorf("TA1", 0, "%w: TA1 segment without ISA segment", ErrInvalidFormat)
+	}
+	if len(s.doc.Interchange.FunctionGroups) > 0 {
+		return s.parseErrorf("TA1", 0, "%w: TA1 segment after GS segment", ErrInvalidFormat)
+	}
+	if len(elements) < 6 {
+		return s.parseErrorf("TA1", len(elements), "%w", ErrMissingElement)
+	}
+	s.doc.Interchange.Acknowledgments = append(s.doc.Interchange.Acknowledgments, &TA1{
+		ControlNumber:      elements[1],
+		Date:               elements[2],
+		Time:               elements[3],
+		AcknowledgmentCode: elements[4],
+		NoteCode:           elements[5],
+	})
+	return nil
+}
+
 func (s *decodeState) parseGS(elements []string) error {
 	if s.doc.Interchange.Header == nil {
 		return s.parseErrorf("GS", 0, "%w: GS segment without ISA segment", ErrInvalidFormat)
@@ -554,11 +554,31 @@ func Test_scanSegments(t *testing.T) {
 			input: "",
 			want:  nil,
 		},
+		{
+			name:  "Binary Segment",
+			input: "BIN*7*a~b*c~d~SEG2*val2~",
+			want:  []string{"BIN*7*a~b*c~d", "SEG2*val2"},
+		},
+		{
+			name:  "Binary Segment Ending In Newline",
+			input: "SEG1*val1~\nBIN*2*a\n~SEG2*val2~",
+			want:  []string{"SEG1*val1", "\nBIN*2*a\n", "SEG2*val2"},
+		},
+		{
+			name:  "Binary Segment With Wrong Length",
+			input: "BIN*2*abc~SEG2*val2~",
+			want:  []string{"BIN*2*abc", "SEG2*val2"},
+		},
+		{
+			name:  "Truncated Binary Segment",
+			input: "BIN*20*abc~",
+			want:  []string{"BIN*20*abc"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			scanner := bufio.NewScanner(strings.NewReader(tt.input))
-			scanner.Split(scanSegments('~'))
+			scanner.Split(scanSegments('~', '*'))
 
 			var segments []string
 			for scanner.Scan() {
@@ -0,0 +1,252 @@
+// Package dict is a data dictionary of X12 segments and data elements:
+// segment names, the data element at each position, and each element's
+// name, reference number, data type, length bounds, and the meanings of
+// its codes.
+//
+// Dictionaries are per X12 version. Lookup returns the dictionary for a
+// version given in any of the forms an interchange carries it: ISA12
+// ("00501"), GS08 ("005010" or "005010X222A1"), or ST03. Default is the
+// 005010 dictionary, which the x12 package consults for
+// Segment.ElementName and related methods.
+//
+// The 005010 dictionary covers the control segments and the segments
+// of the HIPAA transaction sets and acknowledgments; code lists are
+// limited to the qualifiers and status codes most often looked up.
+//
+// The package has no dependency on package x12, so x12 and packages
+// built on it may all use it.
+package dict
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A Dictionary describes the segments and data elements of one X12
+// version.
+type Dictionary struct {
+	Version  string              // e.g. "005010"
+	Segments map[string]*Segment // by segment ID
+	Elements map[string]*Element // by reference number, including composites
+}
+
+// A Segment describes a segment: its name, the data element at each
+// position, and its syntax notes.
+type Segment struct {
+	ID       string
+	Name     string
+	Elements []Use    // indexed by element position - 1
+	Syntax   []string // in published form, e.g. "P0809"
+}
+
+// A Use is a reference to a data element from a position in a segment
+// or composite.
+type Use struct {
+	Ref         string // data element reference number, e.g. "1028" or "C003"
+	Requirement byte   // 'M' mandatory, 'O' optional, or 'X' relational
+}
+
+// An Element describes a simple data element or a composite.
+type Element struct {
+	Ref  string // reference number, e.g. "98"
+	Name string
+
+	// Type is the data type: "AN", "ID", "DT", "TM", "R", "B" (binary),
+	// or "N0" to "N9". It is empty for composites.
+	Type      string
+	MinLength int
+	MaxLength int
+
+	// Components are the component positions of a composite.
+	Components []Use
+
+	// Codes maps code values of an ID element to their meanings. It
+	// holds only the codes the dictionary lists.
+	Codes map[string]string
+}
+
+// IsComposite reports whether e is a composite data element.
+func (e *Element) IsComposite() bool {
+	return strings.HasPrefix(e.Ref, "C")
+}
+
+// Segment returns the description of segment id, or nil.
+func (d *Dictionary) Segment(id string) *Segment {
+	return d.Segments[id]
+}
+
+// Element returns the description of the data element with reference
+// number ref, or nil.
+func (d *Dictionary) Element(ref string) *Element {
+	return d.Elements[ref]
+}
+
+// ElementAt returns the description of the data element at the 1-based
+// position pos of segment id, or nil if either is unknown.
+func (d *Dictionary) ElementAt(id string, pos int) *Element {
+	sg := d.Segments[id]
+	if sg == nil || pos < 1 || pos > len(sg.Elements) {
+		return nil
+	}
+	return d.Elements[sg.Elements[pos-1].Ref]
+}
+
+// ElementName returns the name of the data element at position pos of
+// segment id, such as "Claim Submitter's Identifier" for CLM at 1, or
+// "" if it is unknown.
+func (d *Dictionary) ElementName(id string, pos int) string {
+	if e := d.ElementAt(id, pos); e != nil {
+		return e.Name
+	}
+	return ""
+}
+
+// CodeName returns the meaning of code in the data element at position
+// pos of segment id, such as "Centers for Medicare and Medicaid
+// Services National Provider Identifier" for NM1 at 8 with code "XX",
+// or "" if it is unknown.
+func (d *Dictionary) CodeName(id string, pos int, code string) string {
+	if e := d.ElementAt(id, pos); e != nil {
+		return e.Codes[code]
+	}
+	return ""
+}
+
+// Default is the 005010 dictionary.
+var Default = Lookup("005010")
+
+// loaders holds the tables of each known version, parsed on first use.
+var loaders = map[string]*loader{
+	"005010": {tables: x005010},
+}
+
+type loader struct {
+	once   sync.Once
+	tables tables
+	dict   *Dictionary
+}
+
+// Lookup returns the dictionary for version, given as an ISA12 value
+// ("00501"), a GS08 or ST03 value ("005010" or "005010X222A1"), or nil
+// if there is none.
+func Lookup(version string) *Dictionary {
+	v := strings.TrimSpace(version)
+	if len(v) == 5 {
+		v += "0" // ISA12 omits the release's trailing digit
+	}
+	if len(v) > 6 {
+		v = v[:6]
+	}
+	l := loaders[v]
+	if l == nil {
+		return nil
+	}
+	l.once.Do(func() { l.dict = l.tables.parse(v) })
+	return l.dict
+}
+
+// Versions returns the versions for which Lookup has a dictionary.
+func Versions() []string {
+	var vs []string
+	for v := range loaders {
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// tables holds a dictionary in its source form. Each is a list of lines
+// of "|"-separated fields:
+//
+//	segments:   ID|Name|Ref Req,Ref Req,...|Note Note ...
+//	elements:   Ref|Name|Type|MinLength|MaxLength
+//	composites: Ref|Name|Ref Req,Ref Req,...
+//	codes:      Ref|Code|Meaning
+type tables struct {
+	segments, elements, composites, codes string
+}
+
+// parse builds a Dictionary from t. The tables are part of the package,
+// so malformed lines panic.
+func (t tables) parse(version string) *Dictionary {
+	d := &Dictionary{
+		Version:  version,
+		Segments: make(map[string]*Segment),
+		Elements: make(map[string]*Element),
+	}
+	for _, f := range lines(t.elements, 5) {
+		d.Elements[f[0]] = &Element{Ref: f[0], Name: f[1], Type: f[2], MinLength: atoi(f[3]), MaxLength: atoi(f[4])}
+	}
+	for _, f := range lines(t.composites, 3) {
+		d.Elements[f[0]] = &Element{Ref: f[0], Name: f[1], Components: uses(f[2])}
+	}
+	for _, f := range lines(t.segments, 4) {
+		d.Segments[f[0]] = &Segment{ID: f[0], Name: f[1], Elements: uses(f[2]), Syntax: strings.Fields(f[3])}
+	}
+	for _, f := range lines(t.codes, 3) {
+		e := d.Elements[f[0]]
+		if e == nil {
+			panic(fmt.Sprintf("dict: codes for unknown element %s", f[0]))
+		}
+		if e.Codes == nil {
+			e.Codes = make(map[string]string)
+		}
+		e.Codes[f[1]] = f[2]
+	}
+	for _, sg := range d.Segments {
+		for _, u := range sg.Elements {
+			if d.Elements[u.Ref] == nil {
+				panic(fmt.Sprintf("dict: segment %s uses unknown element %s", sg.ID, u.Ref))
+			}
+		}
+	}
+	for _, e := range d.Elements {
+		for _, u := range e.Components {
+			if d.Elements[u.Ref] == nil {
+				panic(fmt.Sprintf("dict: composite %s uses unknown element %s", e.Ref, u.Ref))
+			}
+		}
+	}
+	return d
+}
+
+// lines splits a table into its non-blank lines' n fields.
+func lines(table string, n int) [][]string {
+	var out [][]string
+	for _, line := range strings.Split(table, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		f := strings.Split(line, "|")
+		for len(f) < n {
+			f = append(f, "")
+		}
+		if len(f) != n {
+			panic(fmt.Sprintf("dict: malformed line %q", line))
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// uses parses a list of element uses such as "98 M,1065 M,1035 X".
+func uses(s string) []Use {
+	var us []Use
+	for _, u := range strings.Split(s, ",") {
+		f := strings.Fields(u)
+		if len(f) != 2 || len(f[1]) != 1 {
+			panic(fmt.Sprintf("dict: malformed element use %q", u))
+		}
+		us = append(us, Use{Ref: f[0], Requirement: f[1][0]})
+	}
+	return us
+}
+
+func atoi(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		panic(fmt.Sprintf("dict: malformed length %q", s))
+	}
+	return n
+}
@@ -0,0 +1,143 @@
+package dict_test
+
+import (
+	"testing"
+
+	"github.com/tmc/x12/dict"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+)
+
+func TestLookup(t *testing.T) {
+	for _, v := range []string{"005010", "00501", "005010X222A1", " 005010X279A1 "} {
+		if d := dict.Lookup(v); d == nil || d.Version != "005010" {
+			t.Errorf("Lookup(%q) = %v, want the 005010 dictionary", v, d)
+		}
+	}
+	for _, v := range []string{"", "004010", "00401", "X"} {
+		if d := dict.Lookup(v); d != nil {
+			t.Errorf("Lookup(%q) = %s, want nil", v, d.Version)
+		}
+	}
+	if dict.Default != dict.Lookup("005010") {
+		t.Error("Default is not the 005010 dictionary")
+	}
+}
+
+func TestDictionary(t *testing.T) {
+	d := dict.Default
+	tests := []struct {
+		id   string
+		pos  int
+		want string
+	}{
+		{"CLM", 1, "Claim Submitter's Identifier"},
+		{"CLM", 2, "Monetary Amount"},
+		{"CLM", 5, "Health Care Service Location Information"},
+		{"NM1", 8, "Identification Code Qualifier"},
+		{"ISA", 13, "Interchange Control Number"},
+		{"IK4", 3, "Implementation Data Element Syntax Error Code"},
+		{"CLM", 0, ""},
+		{"CLM", 21, ""},
+		{"ZZZ", 1, ""},
+	}
+	for _, tt := range tests {
+		if got := d.ElementName(tt.id, tt.pos); got != tt.want {
+			t.Errorf("ElementName(%s, %d) = %q, want %q", tt.id, tt.pos, got, tt.want)
+		}
+	}
+
+	if got, want := d.CodeName("NM1", 8, "XX"), "Centers for Medicare and Medicaid Services National Provider Identifier"; got != want {
+		t.Errorf("CodeName(NM1, 8, XX) = %q, want %q", got, want)
+	}
+	if got := d.CodeName("NM1", 8, "Q9"); got != "" {
+		t.Errorf("CodeName(NM1, 8, Q9) = %q, want empty", got)
+	}
+
+	e := d.Element("1028")
+	if e == nil || e.Type != "AN" || e.MinLength != 1 || e.MaxLength != 38 || e.IsComposite() {
+		t.Errorf("Element(1028) = %+v", e)
+	}
+	c := d.ElementAt("SV1", 1)
+	if c == nil || c.Ref != "C003" || !c.IsComposite() || len(c.Components) != 8 {
+		t.Errorf("ElementAt(SV1, 1) = %+v", c)
+	}
+	if sg := d.Segment("PER"); sg == nil || sg.Name != "Administrative Communications Contact" || len(sg.Syntax) != 3 {
+		t.Errorf("Segment(PER) = %+v", sg)
+	}
+}
+
+// TestTables checks properties of every entry that the table format
+// does not enforce.
+func TestTables(t *testing.T) {
+	d := dict.Default
+	types := map[string]bool{"AN": true, "ID": true, "DT": true, "TM": true, "R": true, "B": true}
+	for ref, e := range d.Elements {
+		if e.IsComposite() {
+			if len(e.Components) == 0 {
+				t.Errorf("composite %s has no components", ref)
+			}
+			continue
+		}
+		if !types[e.Type] && !(len(e.Type) == 2 && e.Type[0] == 'N') {
+			t.Errorf("element %s has type %q", ref, e.Type)
+		}
+		if e.MinLength < 1 || e.MaxLength < e.MinLength {
+			t.Errorf("element %s has lengths %d/%d", ref, e.MinLength, e.MaxLength)
+		}
+		if len(e.Codes) > 0 && e.Type != "ID" {
+			t.Errorf("element %s of type %s has codes", ref, e.Type)
+		}
+		for code := range e.Codes {
+			if len(code) < e.MinLength || len(code) > e.MaxLength {
+				t.Errorf("element %s code %q does not fit lengths %d/%d", ref, code, e.MinLength, e.MaxLength)
+			}
+		}
+	}
+	for id, sg := range d.Segments {
+		for i, u := range sg.Elements {
+			switch u.Requirement {
+			case 'M', 'O', 'X':
+			default:
+				t.Errorf("%s%02d has requirement %q", id, i+1, u.Requirement)
+			}
+		}
+		for _, note := range sg.Syntax {
+			for i := 1; i+2 <= len(note); i += 2 {
+				var pos int
+				for _, c := range note[i : i+2] {
+					pos = pos*10 + int(c-'0')
+				}
+				if pos < 1 || pos > len(sg.Elements) {
+					t.Errorf("%s syntax note %s refers to position %d", id, note, pos)
+				} else if sg.Elements[pos-1].Requirement == 'M' {
+					t.Errorf("%s syntax note %s relates mandatory %s%02d", id, note, id, pos)
+				}
+			}
+		}
+	}
+}
+
+// TestGuideSegments checks that the dictionary describes every segment
+// the HIPAA guides use.
+func TestGuideSegments(t *testing.T) {
+	var walk func(l *schema.Loop)
+	walk = func(l *schema.Loop) {
+		for _, n := range l.Children {
+			switch n := n.(type) {
+			case *schema.Loop:
+				walk(n)
+			case *schema.Segment:
+				if dict.Default.Segment(n.ID) == nil {
+					t.Errorf("no entry for segment %s", n.ID)
+				}
+			}
+		}
+	}
+	for _, ts := range hipaa.Schemas {
+		walk(ts.Loop)
+	}
+	if got, want := dict.Default.ElementName("UM", 1), "Request Category Code"; got != want {
+		t.Errorf("ElementName(UM, 1) = %q, want %q", got, want)
+	}
+}
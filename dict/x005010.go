@@ -0,0 +1,887 @@
+package dict
+
+// x005010 is the 005010 dictionary.
+var x005010 = tables{
+	segments: `
+AAA|Request Validation|1073 M,559 O,901 O,889 O|
+ACT|Account Identification|508 M,93 O,66 X,67 X,569 O,C040 O,107 O|P0304
+ADX|Adjustment|782 M,426 M,128 X,127 X|P0304
+AK1|Functional Group Response Header|479 M,28 M,480 O|
+AK2|Transaction Set Response Header|143 M,329 M,1705 O|
+AK3|Data Segment Note|721 M,719 M,447 O,720 O|
+AK4|Data Element Note|C030 M,725 O,723 M,724 O|
+AK5|Transaction Set Response Trailer|717 M,718 O,718 O,718 O,718 O,718 O|
+AK9|Functional Group Response Trailer|715 M,97 M,123 M,2 M,716 O,716 O,716 O,716 O,716 O|
+AMT|Monetary Amount Information|522 M,782 M,478 O|
+BGN|Beginning Segment|353 M,127 M,373 M,337 X,623 O,127 O,640 O,306 O,786 O|C0504
+BHT|Beginning of Hierarchical Transaction|1005 M,353 M,127 O,373 O,337 O,640 O|
+BIN|Binary Data|784 M,785 M|
+BPR|Financial Information|305 M,782 M,478 M,591 M,812 O,506 X,507 X,569 O,508 X,509 O,510 O,506 X,507 X,569 O,508 X,373 O,1048 O,506 X,507 X,569 O,508 X|P0607 C0809 P1213 C1415 P1819 C2021
+CAS|Claims Adjustment|1033 M,1034 M,782 M,380 O,1034 X,782 X,380 X,1034 X,782 X,380 X,1034 X,782 X,380 X,1034 X,782 X,380 X,1034 X,782 X,380 X|L050607 C0605 C0705 L080910 C0908 C1008 L111213 C1211 C1311 L141516 C1514 C1614 L171819 C1817 C1917
+CAT|Category of Patient Information Service|1136 M,1270 M,1271 M|
+CL1|Claim Codes|1315 O,1314 O,1352 O,1345 O|
+CLM|Health Claim|1028 M,782 O,1032 O,1343 O,C023 O,1073 O,1359 O,1073 O,1363 O,1351 O,C024 O,1366 O,1073 O,1338 O,1073 O,1360 O,1029 O,1073 O,1383 O,1514 O|
+CLP|Claim Level Data|1028 M,1029 M,782 M,782 M,782 O,1032 O,127 O,1331 O,1325 O,1352 O,1354 O,380 O,954 O,1073 O|
+CN1|Contract Information|1166 M,782 O,332 O,127 O,338 O,799 O|
+COB|Coordination of Benefits|1138 M,127 O,1143 O,1365 O|
+CR1|Ambulance Certification|355 X,81 X,1316 O,1317 O,355 X,380 X,166 O,166 O,352 O,352 O|P0102 P0506
+CR2|Chiropractic Certification|609 O,380 O,1367 X,1367 X,355 X,380 X,380 O,1342 O,1073 O,352 O,352 O,1073 O|C0403 P0506
+CR3|Durable Medical Equipment Certification|1322 O,355 X,380 X,1335 O,352 O|P0203
+CR5|Home Oxygen Therapy Information|1322 O,380 O,1348 O,1348 O,352 O,380 O,380 O,380 O,352 O,380 O,380 O,1349 O,1350 O,1350 O,1350 O,1382 O,1348 O|
+CR6|Home Health Care Information|923 M,373 M,1250 X,1251 X,373 O,1073 O,1073 O,1322 O|P0304
+CRC|Conditions Indicator|1136 M,1073 M,1321 M,1321 O,1321 O,1321 O,1321 O|
+CTP|Pricing Information|687 O,236 X,212 X,380 X,C001 X,648 O,649 O,782 O,639 O,499 O,289 O|P0405
+CTX|Context|C998 M,721 O,719 O,447 O,C030 O,C999 O|
+CUR|Currency|98 M,100 M,280 O|
+DMG|Demographic Information|1250 X,1251 X,1068 O,1067 O,C056 O,1066 O,26 O,659 O,380 O,1270 X,1271 X|P0102 P1011 C1105
+DN1|Orthodontic Information|380 O,380 O,1073 O,352 O|
+DN2|Tooth Summary|127 M,1368 M,380 O,1250 X,1251 X,1270 O|P0405
+DSB|Disability Information|1146 M,380 O,1149 O,1154 O,1161 O,782 O,1270 X,1271 X|P0708
+DTM|Date/Time Reference|374 M,373 X,337 X,623 O,1250 X,1251 X|R020305 C0403 P0506
+DTP|Date or Time or Period|374 M,1250 M,1251 M|
+EB|Eligibility or Benefit Information|1390 M,1207 O,1365 O,1336 O,1204 O,615 O,782 O,954 O,673 X,380 X,1073 O,1073 O,C003 O,C004 O|P0910
+EC|Employment Class|1176 O,1176 O,1176 O,954 O|
+EFI|Electronic Format Identification|786 O,799 O|
+ENT|Entity|554 O,98 X,66 X,67 X,98 X,66 X,67 X,128 X,127 X|P020304 P050607 P0809
+EQ|Eligibility or Benefit Inquiry|1365 X,C003 X,1207 O,1336 O,C004 O|R0102
+FRM|Supporting Documentation|350 M,1073 X,127 X,373 X,332 X|
+GE|Functional Group Trailer|97 M,28 M|
+GS|Functional Group Header|479 M,142 M,124 M,373 M,337 M,28 M,455 M,480 M|
+HCP|Health Care Pricing|1473 M,782 O,782 O,127 O,118 O,127 O,782 O,234 O,235 X,234 X,355 X,380 X,901 O,1526 O,1527 O|P0910 P1112
+HCR|Health Care Services Review|306 M,127 O,901 O,1073 O|
+HD|Health Coverage|875 M,1203 O,1205 O,1204 O,1207 O,609 O,609 O,1211 O,1073 O,1209 O,1073 O|
+HI|Health Care Information Codes|C022 M,C022 O,C022 O,C022 O,C022 O,C022 O,C022 O,C022 O,C022 O,C022 O,C022 O,C022 O|
+HL|Hierarchical Level|628 M,734 O,735 M,736 O|
+HLH|Health Information|1212 M,65 O,81 O,81 O,352 O,1213 O,352 O|
+HSD|Health Care Services Delivery|673 X,380 X,355 O,1167 O,615 X,616 O,678 O,679 O|P0102 C0605
+ICM|Individual Income|594 M,782 M,380 O,310 O,1214 O,100 O|
+IDC|Identification Card|1204 M,1215 M,380 O,306 O|
+IEA|Interchange Control Trailer|I16 M,I12 M|
+III|Information|1270 X,1271 X,1136 X,933 X,380 O,C001 O,752 O,752 O,752 O|P0102
+IK3|Implementation Data Segment Note|721 M,719 M,447 O,620 O|
+IK4|Implementation Data Element Note|C030 M,725 O,621 M,724 O|
+IK5|Implementation Transaction Set Response Trailer|717 M,618 O,618 O,618 O,618 O,618 O|
+INS|Member Level Detail|1073 M,1069 M,875 O,1203 O,1216 O,C052 O,1219 O,584 O,1220 O,1073 O,1250 X,1251 X,1165 O,19 O,156 O,26 O,1470 O|P1112
+ISA|Interchange Control Header|I01 M,I02 M,I03 M,I04 M,I05 M,I06 M,I05 M,I07 M,I08 M,I09 M,I65 M,I11 M,I12 M,I13 M,I14 M,I15 M|
+K3|File Information|449 M,1333 O,C001 O|
+LE|Loop Trailer|447 M|
+LIN|Item Identification|350 O,235 M,234 M,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X,235 X,234 X|P0405 P0607 P0809 P1011 P1213 P1415 P1617 P1819 P2021 P2223 P2425 P2627 P2829 P3031
+LQ|Industry Code|1270 O,1271 X|C0102
+LS|Loop Header|447 M|
+LUI|Language Use|66 X,67 X,352 X,1303 O,1476 O|P0102 L010203
+LX|Transaction Set Line Number|554 M|
+MEA|Measurements|737 O,738 O,739 X,C001 X,740 X,741 X,935 O,936 X,752 O,1373 O,1270 O,1271 X|R03050608 C0504 C0604 L07030506 E0803
+MIA|Medicare Inpatient Adjudication|380 M,782 O,380 O,782 O,127 O,782 O,782 O,782 O,782 O,782 O,782 O,782 O,782 O,782 O,380 O,782 O,782 O,782 O,782 O,127 O,127 O,127 O,127 O,782 O|
+MOA|Medicare Outpatient Adjudication|954 O,782 O,127 O,127 O,127 O,127 O,127 O,782 O,782 O|
+MPI|Military Personnel Information|1201 M,584 M,1595 M,352 O,1596 O,1250 X,1251 X|P0607
+MSG|Message Text|933 M,934 X,1470 O|C0302
+N1|Party Identification|98 M,93 X,66 X,67 X,706 O,98 O|R0203 P0304
+N2|Additional Name Information|93 M,93 O|
+N3|Party Location|166 M,166 O|
+N4|Geographic Location|19 O,156 O,116 O,26 O,309 X,310 O,1715 X|E0207 C0605 C0704
+NM1|Individual or Organizational Name|98 M,1065 M,1035 X,1036 O,1037 O,1038 O,1039 O,66 X,67 X,706 X,98 X,1035 O|P0809 C1110 C1203
+NTE|Note/Special Instruction|363 O,352 M|
+OI|Other Health Insurance Information|1032 O,1383 O,1073 O,1351 O,1360 O,1363 O|
+OTI|Original Transaction Identification|110 M,128 M,127 M,142 O,124 O,373 O,337 O,28 O,329 O,143 O,480 O|
+PAT|Patient Information|1069 O,1384 O,584 O,1220 O,1250 X,1251 X,355 X,81 X,1073 O|P0506 P0708
+PER|Administrative Communications Contact|366 M,93 O,365 X,364 X,365 X,364 X,365 X,364 X,443 O|P0304 P0506 P0708
+PLA|Place or Location|306 M,98 M,373 M,337 O,1203 O|
+PLB|Provider Level Adjustment|127 M,373 M,C042 M,782 M,C042 X,782 X,C042 X,782 X,C042 X,782 X,C042 X,782 X,C042 X,782 X|P0506 P0708 P0910 P1112 P1314
+PRV|Provider Information|1221 M,128 X,127 X,156 O,C035 O,1223 O|P0203
+PS1|Purchase Service|127 M,782 M,156 O|
+PWK|Paperwork|755 M,756 O,757 O,98 O,66 X,67 X,352 O,C002 O,1525 O|P0506
+QTY|Quantity Information|673 M,380 X,C001 O,61 X|R0204 E0204
+RDM|Remittance Delivery Method|756 M,93 O,364 O,C040 O,C040 O|
+RED|Related Data|352 M,1270 X,1271 X|P0203
+REF|Reference Information|128 M,127 X,352 X,C040 O|R0203
+RMR|Remittance Advice Accounts Receivable Open Item Reference|128 X,127 X,482 O,782 O,782 O,782 O,426 X,782 X|P0102 P0708
+SBR|Subscriber Information|1138 M,1069 O,127 O,93 O,1336 O,1143 O,1073 O,584 O,1032 O|
+SE|Transaction Set Trailer|96 M,329 M|
+ST|Transaction Set Header|143 M,329 M,1705 O|
+STC|Status Information|C043 M,373 O,306 O,782 O,782 O,373 O,591 O,373 O,429 O,C043 O,C043 O,933 O|
+SV1|Professional Service|C003 M,782 O,355 X,380 X,1331 O,1365 O,C004 O,782 O,1073 O,1073 O,1073 O,1073 O,1364 O,1341 O,1327 O,1334 O,127 O,116 O,782 O,1337 O,1360 O|P0304
+SV2|Institutional Service Line|234 X,C003 X,782 O,355 X,380 X,1371 O,782 O,1345 O,1337 O,1360 O,1029 O|R0102 P0405
+SV3|Dental Service|C003 M,782 O,1331 O,C006 O,1358 O,380 O,352 O,1327 O,1360 O,1073 O,C004 O|
+SV5|Durable Medical Equipment Service|C003 M,355 M,380 M,782 O,782 O,594 O,923 O|
+SVC|Service Payment Information|C003 M,782 M,782 O,234 O,380 O,C003 O,380 O|
+SVD|Line Adjudication Information|67 M,782 M,C003 X,234 O,380 O,554 O|
+TA1|Interchange Acknowledgment|I12 M,I08 M,I09 M,I17 M,I18 M|
+TED|Technical Error Description|647 M,3 O,721 O,719 O,447 O,722 O,725 O,724 O|
+TOO|Tooth Identification|1270 X,1271 X,C005 O|P0102
+TRN|Trace|481 M,127 M,509 O,127 O|
+TS2|Transaction Supplemental Statistics|782 O,782 O,782 O,782 O,782 O,782 O,380 O,782 O,380 O,380 O,782 O,782 O,782 O,782 O,380 O,782 O,782 O,782 O,782 O|
+TS3|Transaction Statistics|127 M,1331 M,373 M,380 M,782 M,782 O,782 O,782 O,782 O,782 O,782 O,782 O,782 O,782 O,380 O,782 O,782 O,782 O,782 O,782 O,782 O,380 O,782 O,782 O|
+UM|Health Care Services Review Information|1525 M,1322 O,1365 O,C023 O,C024 O,1338 O,1213 O,923 O,1363 O,1514 O|
+UR|Peer Review Organization or Utilization Review|1271 M,373 O|
+`,
+
+	elements: `
+2|Number of Accepted Transaction Sets|N0|1|6
+3|Free Form Message|AN|1|60
+19|City Name|AN|2|30
+26|Country Code|ID|2|3
+28|Group Control Number|N0|1|9
+61|Free-form Information|AN|1|30
+65|Height|R|1|8
+66|Identification Code Qualifier|ID|1|2
+67|Identification Code|AN|2|80
+81|Weight|R|1|10
+93|Name|AN|1|60
+96|Number of Included Segments|N0|1|10
+97|Number of Transaction Sets Included|N0|1|6
+98|Entity Identifier Code|ID|2|3
+100|Currency Code|ID|3|3
+107|Payment Method Type Code|ID|1|2
+110|Application Acknowledgment Code|ID|1|2
+116|Postal Code|ID|3|15
+118|Rate|R|1|9
+123|Number of Received Transaction Sets|N0|1|6
+124|Application Receiver's Code|AN|2|15
+127|Reference Identification|AN|1|50
+128|Reference Identification Qualifier|ID|2|3
+142|Application Sender's Code|AN|2|15
+143|Transaction Set Identifier Code|ID|3|3
+156|State or Province Code|ID|2|2
+166|Address Information|AN|1|55
+212|Unit Price|R|1|17
+234|Product/Service ID|AN|1|48
+235|Product/Service ID Qualifier|ID|2|2
+236|Price Identifier Code|ID|3|3
+280|Exchange Rate|R|4|10
+289|Multiple Price Quantity|N0|1|2
+305|Transaction Handling Code|ID|1|2
+306|Action Code|ID|1|2
+309|Location Qualifier|ID|1|2
+310|Location Identifier|AN|1|30
+329|Transaction Set Control Number|AN|4|9
+332|Percent, Decimal Format|R|1|6
+337|Time|TM|4|8
+338|Terms Discount Percent|R|1|6
+350|Assigned Identification|AN|1|20
+352|Description|AN|1|80
+353|Transaction Set Purpose Code|ID|2|2
+355|Unit or Basis for Measurement Code|ID|2|2
+363|Note Reference Code|ID|3|3
+364|Communication Number|AN|1|256
+365|Communication Number Qualifier|ID|2|2
+366|Contact Function Code|ID|2|2
+373|Date|DT|8|8
+374|Date/Time Qualifier|ID|3|3
+380|Quantity|R|1|15
+426|Adjustment Reason Code|ID|2|2
+429|Check Number|AN|1|16
+443|Contact Inquiry Reference|AN|1|20
+447|Loop Identifier Code|AN|1|4
+449|Fixed Format Information|AN|1|80
+455|Responsible Agency Code|ID|1|2
+478|Credit/Debit Flag Code|ID|1|1
+479|Functional Identifier Code|ID|2|2
+480|Version / Release / Industry Identifier Code|AN|1|12
+481|Trace Type Code|ID|1|2
+482|Payment Action Code|ID|2|2
+499|Condition Value|AN|1|10
+506|(DFI) ID Number Qualifier|ID|2|2
+507|(DFI) Identification Number|AN|3|12
+508|Account Number|AN|1|35
+509|Originating Company Identifier|AN|10|10
+510|Originating Company Supplemental Code|AN|9|9
+522|Amount Qualifier Code|ID|1|3
+554|Assigned Number|N0|1|6
+559|Agency Qualifier Code|ID|2|2
+569|Account Number Qualifier|ID|1|3
+584|Employment Status Code|ID|2|2
+591|Payment Method Code|ID|3|3
+594|Frequency Code|ID|1|1
+609|Count|N0|1|9
+615|Time Period Qualifier|ID|2|2
+616|Number of Periods|N0|1|3
+618|Implementation Transaction Set Syntax Error Code|ID|1|3
+620|Implementation Segment Syntax Error Code|ID|1|3
+621|Implementation Data Element Syntax Error Code|ID|1|3
+623|Time Code|ID|2|2
+628|Hierarchical ID Number|AN|1|12
+639|Basis of Unit Price Code|ID|2|2
+640|Transaction Type Code|ID|2|2
+647|Application Error Condition Code|ID|1|3
+648|Price Multiplier Qualifier|ID|3|3
+649|Multiplier|R|1|10
+659|Basis of Verification Code|ID|1|2
+673|Quantity Qualifier|ID|2|2
+678|Ship/Delivery or Calendar Pattern Code|ID|1|2
+679|Ship/Delivery Pattern Time Code|ID|1|1
+687|Class of Trade Code|ID|2|2
+704|Paperwork/Report Action Code|ID|1|2
+706|Entity Relationship Code|ID|2|2
+715|Functional Group Acknowledge Code|ID|1|1
+716|Functional Group Syntax Error Code|ID|1|3
+717|Transaction Set Acknowledgment Code|ID|1|1
+718|Transaction Set Syntax Error Code|ID|1|3
+719|Segment Position in Transaction Set|N0|1|10
+720|Segment Syntax Error Code|ID|1|3
+721|Segment ID Code|ID|2|3
+722|Element Position in Segment|N0|1|2
+723|Data Element Syntax Error Code|ID|1|3
+724|Copy of Bad Data Element|AN|1|99
+725|Data Element Reference Number|N0|1|4
+734|Hierarchical Parent ID Number|AN|1|12
+735|Hierarchical Level Code|ID|1|2
+736|Hierarchical Child Code|ID|1|1
+737|Measurement Reference ID Code|ID|2|2
+738|Measurement Qualifier|ID|1|3
+739|Measurement Value|R|1|20
+740|Range Minimum|R|1|20
+741|Range Maximum|R|1|20
+752|Layer/Position Code|ID|2|2
+755|Report Type Code|ID|2|2
+756|Report Transmission Code|ID|1|2
+757|Report Copies Needed|N0|1|2
+782|Monetary Amount|R|1|18
+784|Length of Binary Data|N0|1|15
+785|Binary Data|B|1|999999999999999
+786|Security Level Code|ID|2|2
+799|Version Identifier|AN|1|30
+812|Payment Format Code|ID|1|10
+875|Maintenance Type Code|ID|3|3
+889|Follow-up Action Code|ID|1|1
+901|Reject Reason Code|ID|2|2
+923|Prognosis Code|ID|1|1
+933|Free-form Message Text|AN|1|264
+934|Printer Carriage Control Code|ID|2|2
+935|Measurement Significance Code|ID|2|2
+936|Measurement Attribute Code|ID|2|2
+954|Percentage as Decimal|R|1|10
+1005|Hierarchical Structure Code|ID|4|4
+1028|Claim Submitter's Identifier|AN|1|38
+1029|Claim Status Code|ID|1|2
+1032|Claim Filing Indicator Code|ID|1|2
+1033|Claim Adjustment Group Code|ID|1|2
+1034|Claim Adjustment Reason Code|ID|1|5
+1035|Name Last or Organization Name|AN|1|60
+1036|Name First|AN|1|35
+1037|Name Middle|AN|1|25
+1038|Name Prefix|AN|1|10
+1039|Name Suffix|AN|1|10
+1048|Business Function Code|ID|1|3
+1065|Entity Type Qualifier|ID|1|1
+1066|Citizenship Status Code|ID|1|2
+1067|Marital Status Code|ID|1|1
+1068|Gender Code|ID|1|1
+1069|Individual Relationship Code|ID|2|2
+1073|Yes/No Condition or Response Code|ID|1|1
+1109|Race or Ethnicity Code|ID|1|1
+1136|Code Category|ID|2|2
+1138|Payer Responsibility Sequence Number Code|ID|1|1
+1143|Coordination of Benefits Code|ID|1|1
+1146|Disability Type Code|ID|1|1
+1149|Occupation Code|ID|4|6
+1154|Work Intensity Code|ID|1|1
+1161|Product Option Code|AN|1|2
+1165|Confidentiality Code|ID|1|1
+1166|Contract Type Code|ID|2|2
+1167|Sample Selection Modulus|R|1|6
+1176|Employment Class Code|ID|2|3
+1201|Information Status Code|ID|1|1
+1203|Maintenance Reason Code|ID|2|3
+1204|Plan Coverage Description|AN|1|50
+1205|Insurance Line Code|ID|2|3
+1207|Coverage Level Code|ID|3|3
+1209|Drug House Code|ID|2|3
+1211|Underwriting Decision Code|ID|1|1
+1212|Health-Related Code|ID|1|1
+1213|Current Health Condition Code|ID|1|1
+1214|Salary Grade|AN|1|5
+1215|Identification Card Type Code|ID|1|1
+1216|Benefit Status Code|ID|1|1
+1218|Medicare Plan Code|ID|1|1
+1219|Consolidated Omnibus Budget Reconciliation Act (COBRA) Qualifying|ID|1|2
+1220|Student Status Code|ID|1|1
+1221|Provider Code|ID|1|3
+1222|Provider Specialty Code|AN|1|3
+1223|Provider Organization Code|ID|3|3
+1250|Date Time Period Format Qualifier|ID|2|3
+1251|Date Time Period|AN|1|35
+1270|Code List Qualifier Code|ID|1|3
+1271|Industry Code|AN|1|30
+1303|Use of Language Indicator|ID|1|2
+1314|Admission Source Code|ID|1|1
+1315|Admission Type Code|ID|1|1
+1316|Ambulance Transport Code|ID|1|1
+1317|Ambulance Transport Reason Code|ID|1|1
+1321|Condition Indicator|ID|2|3
+1322|Certification Type Code|ID|1|1
+1325|Claim Frequency Type Code|ID|1|1
+1327|Copay Status Code|ID|1|1
+1328|Diagnosis Code Pointer|N0|1|2
+1331|Facility Code Value|AN|1|2
+1332|Facility Code Qualifier|ID|1|2
+1333|Record Format Code|ID|1|2
+1334|Health Care Professional Shortage Area Code|ID|1|1
+1335|Insulin Dependent Code|ID|1|1
+1336|Insurance Type Code|ID|1|3
+1337|Level of Care Code|ID|1|1
+1338|Level of Service Code|ID|1|3
+1339|Procedure Modifier|AN|2|2
+1341|National or Local Assigned Review Value|AN|1|2
+1342|Nature of Condition Code|ID|1|1
+1343|Non-Institutional Claim Type Code|ID|1|2
+1345|Nursing Home Residential Status Code|ID|1|1
+1348|Oxygen Equipment Type Code|ID|1|1
+1349|Oxygen Test Condition Code|ID|1|1
+1350|Oxygen Test Findings Code|ID|1|1
+1351|Patient Signature Source Code|ID|1|1
+1352|Patient Status Code|ID|1|2
+1354|Diagnosis Related Group (DRG) Code|ID|1|4
+1358|Prosthesis, Crown or Inlay Code|ID|1|1
+1359|Provider Accept Assignment Code|ID|1|1
+1360|Provider Agreement Code|ID|1|1
+1361|Oral Cavity Designation Code|ID|1|3
+1362|Related-Causes Code|ID|2|3
+1363|Release of Information Code|ID|1|1
+1364|Review Code|ID|1|2
+1365|Service Type Code|ID|1|2
+1366|Special Program Code|ID|2|3
+1367|Subluxation Level Code|ID|2|3
+1368|Tooth Status Code|ID|1|2
+1369|Tooth Surface Code|ID|1|2
+1371|Unit Rate|R|1|10
+1373|Measurement Method or Device|ID|2|4
+1382|Oxygen Delivery System Code|ID|1|1
+1383|Claim Submission Reason Code|ID|2|2
+1384|Patient Location Code|ID|1|1
+1390|Eligibility or Benefit Information Code|ID|1|2
+1470|Number|N0|1|9
+1473|Pricing Methodology|ID|2|2
+1476|Language Proficiency Indicator|ID|1|1
+1514|Delay Reason Code|ID|1|2
+1525|Request Category Code|ID|1|2
+1526|Policy Compliance Code|ID|1|2
+1527|Exception Code|ID|1|2
+1528|Component Data Element Position in Composite|N0|1|2
+1595|Government Service Affiliation Code|ID|1|1
+1596|Military Service Rank Code|ID|2|2
+1671|Race or Ethnicity Collection Code|AN|1|30
+1686|Repeating Data Element Position|N0|1|4
+1701|Eligibility Reason Code|ID|1|1
+1705|Implementation Convention Reference|AN|1|35
+1715|Country Subdivision Code|ID|1|3
+9998|Context Reference|AN|1|35
+9999|Context Name|AN|1|35
+I01|Authorization Information Qualifier|ID|2|2
+I02|Authorization Information|AN|10|10
+I03|Security Information Qualifier|ID|2|2
+I04|Security Information|AN|10|10
+I05|Interchange ID Qualifier|ID|2|2
+I06|Interchange Sender ID|AN|15|15
+I07|Interchange Receiver ID|AN|15|15
+I08|Interchange Date|DT|6|6
+I09|Interchange Time|TM|4|4
+I11|Interchange Control Version Number|ID|5|5
+I12|Interchange Control Number|N0|9|9
+I13|Acknowledgment Requested|ID|1|1
+I14|Interchange Usage Indicator|ID|1|1
+I15|Component Element Separator|AN|1|1
+I16|Number of Included Functional Groups|N0|1|5
+I17|Interchange Acknowledgment Code|ID|1|1
+I18|Interchange Note Code|ID|3|3
+I65|Repetition Separator|AN|1|1
+`,
+
+	composites: `
+C001|Composite Unit of Measure|355 M
+C002|Actions Indicated|704 M
+C003|Composite Medical Procedure Identifier|235 M,234 M,1339 O,1339 O,1339 O,1339 O,352 O,234 O
+C004|Composite Diagnosis Code Pointer|1328 M,1328 O,1328 O,1328 O
+C005|Tooth Surface|1369 M,1369 O,1369 O,1369 O,1369 O
+C006|Oral Cavity Designation|1361 M,1361 O,1361 O,1361 O,1361 O
+C022|Health Care Code Information|1270 M,1271 M,1250 X,1251 X,782 O,380 O,799 O,1271 O,1073 X
+C023|Health Care Service Location Information|1331 M,1332 O,1325 O
+C024|Related Causes Information|1362 M,1362 O,1362 O,156 O,26 O
+C030|Position in Segment|722 M,1528 O,1686 O
+C035|Provider Specialty Information|1222 M,559 O,1073 O
+C040|Reference Identifier|128 M,127 M,128 X,127 X,128 X,127 X
+C042|Adjustment Identifier|426 M,127 O
+C043|Health Care Claim Status|1271 M,1271 M,98 O,1270 O
+C052|Medicare Status Code|1218 M,1701 O,1701 O,1701 O,1701 O
+C056|Composite Race or Ethnicity Information|1109 X,1270 X,1671 X
+C998|Context Identification|9999 M,9998 O
+C999|Reference in Segment|722 M,1528 O,1686 O,725 O
+`,
+
+	codes: `
+98|03|Dependent
+98|1P|Provider
+98|2B|Third-Party Administrator
+98|36|Employer
+98|40|Receiver
+98|41|Submitter
+98|45|Drop-off Location
+98|71|Attending Physician
+98|72|Operating Physician
+98|74|Corrected Insured
+98|77|Service Location
+98|82|Rendering Provider
+98|85|Billing Provider
+98|87|Pay-to Provider
+98|DK|Ordering Physician
+98|DN|Referring Provider
+98|DQ|Supervising Physician
+98|FA|Facility
+98|GP|Gateway Provider
+98|IL|Insured or Subscriber
+98|IN|Insurer
+98|P3|Primary Care Provider
+98|P5|Plan Sponsor
+98|PE|Payee
+98|PR|Payer
+98|PW|Pickup Address
+98|QC|Patient
+98|TT|Transfer To
+66|24|Employer's Identification Number
+66|34|Social Security Number
+66|46|Electronic Transmitter Identification Number (ETIN)
+66|FI|Federal Taxpayer's Identification Number
+66|II|Standard Unique Health Identifier for each Individual in the United States
+66|MI|Member Identification Number
+66|PI|Payor Identification
+66|SV|Service Provider Number
+66|XV|Centers for Medicare and Medicaid Services PlanID
+66|XX|Centers for Medicare and Medicaid Services National Provider Identifier
+66|ZZ|Mutually Defined
+110|TA|Transaction Set Accept
+110|TC|Transaction Set Accept with Change
+110|TE|Transaction Set Accept with Error
+110|TR|Transaction Set Reject
+128|0B|State License Number
+128|0F|Subscriber Number
+128|17|Client Reporting Category
+128|1G|Provider UPIN Number
+128|1L|Group or Policy Number
+128|1W|Member Identification Number
+128|23|Client Number
+128|2U|Payer Identification Number
+128|38|Master Policy Number
+128|4N|Special Payment Reference Number
+128|6P|Group Number
+128|6R|Provider Control Number
+128|9A|Repriced Claim Reference Number
+128|9F|Referral Number
+128|CE|Class of Contract Code
+128|D9|Claim Number
+128|EA|Medical Record Identification Number
+128|EI|Employer's Identification Number
+128|EV|Receiver Identification Number
+128|F8|Original Reference Number
+128|G1|Prior Authorization Number
+128|G2|Provider Commercial Number
+128|IG|Insurance Policy Number
+128|LU|Location Number
+128|NF|National Association of Insurance Commissioners (NAIC) Code
+128|SY|Social Security Number
+128|TJ|Federal Taxpayer's Identification Number
+128|TN|Transaction Reference Number
+128|ZZ|Mutually Defined
+143|270|Eligibility, Coverage or Benefit Inquiry
+143|271|Eligibility, Coverage or Benefit Information
+143|275|Patient Information
+143|276|Health Care Claim Status Request
+143|277|Health Care Information Status Notification
+143|278|Health Care Services Review Information
+143|820|Payment Order/Remittance Advice
+143|824|Application Advice
+143|834|Benefit Enrollment and Maintenance
+143|835|Health Care Claim Payment/Advice
+143|837|Health Care Claim
+143|997|Functional Acknowledgment
+143|999|Implementation Acknowledgment
+305|C|Payment Accompanies Remittance Advice
+305|D|Make Payment Only
+305|H|Notification Only
+305|I|Remittance Information Only
+305|P|Prenotification of Future Transfers
+305|U|Split Payment and Remittance
+305|X|Handling Party's Option to Split Payment and Remittance
+353|00|Original
+353|01|Cancellation
+353|02|Add
+353|04|Change
+353|05|Replace
+353|08|Status
+353|11|Response
+353|13|Request
+353|15|Re-Submission
+353|18|Reissue
+353|22|Information Copy
+365|EM|Electronic Mail
+365|EX|Telephone Extension
+365|FX|Facsimile
+365|TE|Telephone
+365|UR|Uniform Resource Locator (URL)
+366|BL|Technical Department
+366|CX|Payers Claim Office
+366|IC|Information Contact
+374|009|Process
+374|036|Expiration
+374|050|Received
+374|096|Discharge
+374|102|Issue
+374|150|Service Period Start
+374|151|Service Period End
+374|232|Claim Statement Period Start
+374|233|Claim Statement Period End
+374|291|Plan
+374|296|Initial Disability Period Return To Work
+374|297|Initial Disability Period Last Day Worked
+374|303|Maintenance Effective
+374|304|Latest Visit or Consultation
+374|307|Eligibility
+374|314|Disability
+374|346|Plan Begin
+374|347|Plan End
+374|348|Benefit Begin
+374|349|Benefit End
+374|356|Eligibility Begin
+374|357|Eligibility End
+374|360|Initial Disability Period Start
+374|361|Initial Disability Period End
+374|382|Enrollment
+374|405|Production
+374|431|Onset of Current Symptoms or Illness
+374|434|Statement
+374|435|Admission
+374|439|Accident
+374|453|Acute Manifestation of a Chronic Condition
+374|454|Initial Treatment
+374|455|Last X-Ray
+374|471|Prescription
+374|472|Service
+374|484|Last Menstrual Period
+374|573|Date Claim Paid
+455|T|Transportation Data Coordinating Committee (TDCC)
+455|X|Accredited Standards Committee X12
+478|C|Credit
+478|D|Debit
+479|AG|Application Advice (824)
+479|BE|Benefit Enrollment and Maintenance (834)
+479|FA|Functional or Implementation Acknowledgment Transaction Sets (997, 999)
+479|HB|Eligibility, Coverage or Benefit Information (271)
+479|HC|Health Care Claim (837)
+479|HI|Health Care Services Review Information (278)
+479|HN|Health Care Information Status Notification (277)
+479|HP|Health Care Claim Payment/Advice (835)
+479|HR|Health Care Claim Status Request (276)
+479|HS|Eligibility, Coverage or Benefit Inquiry (270)
+479|PI|Patient Information (275)
+479|RA|Payment Order/Remittance Advice (820)
+591|ACH|Automated Clearing House (ACH)
+591|BOP|Financial Institution Option
+591|CHK|Check
+591|FWT|Federal Reserve Funds/Wire Transfer - Nonrepetitive
+591|NON|Non-Payment Data
+618|1|Transaction Set Not Supported
+618|2|Transaction Set Trailer Missing
+618|3|Transaction Set Control Number in Header and Trailer Do Not Match
+618|4|Number of Included Segments Does Not Match Actual Count
+618|5|One or More Segments in Error
+618|6|Missing or Invalid Transaction Set Identifier
+618|7|Missing or Invalid Transaction Set Control Number
+618|8|Authentication Key Name Unknown
+618|9|Encryption Key Name Unknown
+618|10|Requested Service (Authentication or Encrypted) Not Available
+618|11|Unknown Security Recipient
+618|12|Incorrect Message Length (Encryption Only)
+618|13|Message Authentication Code Failed
+618|15|Unknown Security Originator
+618|16|Syntax Error in Decrypted Text
+618|17|Security Not Supported
+618|18|Transaction Set not in Functional Group
+618|19|Invalid Transaction Set Implementation Convention Reference
+618|23|Transaction Set Control Number Not Unique within the Functional Group
+618|24|S3E Security End Segment Missing for S3S Security Start Segment
+618|25|S3S Security Start Segment Missing for S3E Security End Segment
+618|26|S4E Security End Segment Missing for S4S Security Start Segment
+618|27|S4S Security Start Segment Missing for S4E Security End Segment
+618|I5|Implementation One or More Segments in Error
+618|I6|Implementation Convention Not Supported
+620|1|Unrecognized segment ID
+620|2|Unexpected segment
+620|3|Required Segment Missing
+620|4|Loop Occurs Over Maximum Times
+620|5|Segment Exceeds Maximum Use
+620|6|Segment Not in Defined Transaction Set
+620|7|Segment Not in Proper Sequence
+620|8|Segment Has Data Element Errors
+620|I4|Implementation "Not Used" Segment Present
+620|I6|Implementation Dependent Segment Missing
+620|I7|Implementation Loop Occurs Under Minimum Times
+620|I8|Implementation Segment Below Minimum Use
+620|I9|Implementation Dependent "Not Used" Segment Present
+621|1|Required Data Element Missing
+621|2|Conditional Required Data Element Missing
+621|3|Too Many Data Elements
+621|4|Data Element Too Short
+621|5|Data Element Too Long
+621|6|Invalid Character In Data Element
+621|7|Invalid Code Value
+621|8|Invalid Date
+621|9|Invalid Time
+621|10|Exclusion Condition Violated
+621|12|Too Many Repetitions
+621|13|Too Many Components
+621|I6|Code Value Not Used in Implementation
+621|I9|Implementation Dependent Data Element Missing
+621|I10|Implementation "Not Used" Data Element Present
+621|I11|Implementation Too Few Repetitions
+621|I12|Implementation Pattern Match Failure
+621|I13|Implementation Dependent "Not Used" Data Element Present
+715|A|Accepted
+715|E|Accepted, But Errors Were Noted.
+715|M|Rejected, Message Authentication Code (MAC) Failed
+715|P|Partially Accepted, At Least One Transaction Set Was Rejected
+715|R|Rejected
+715|W|Rejected, Assurance Failed Validity Tests
+715|X|Rejected, Content After Decryption Could Not Be Analyzed
+716|1|Functional Group Not Supported
+716|2|Functional Group Version Not Supported
+716|3|Functional Group Trailer Missing
+716|4|Group Control Number in the Functional Group Header and Trailer Do Not Agree
+716|5|Number of Included Transaction Sets Does Not Match Actual Count
+716|6|Group Control Number Violates Syntax
+716|10|Authentication Key Name Unknown
+716|11|Encryption Key Name Unknown
+716|12|Requested Service (Authentication or Encryption) Not Available
+716|13|Unknown Security Recipient
+716|14|Unknown Security Originator
+716|15|Syntax Error in Decrypted Text
+716|16|Security Not Supported
+716|17|Incorrect Message Length (Encryption Only)
+716|18|Message Authentication Code Failed
+716|19|Functional Group Control Number not Unique within Interchange
+716|23|S3E Security End Segment Missing for S3S Security Start Segment
+716|24|S3S Security Start Segment Missing for S3E End Segment
+716|25|S4E Security End Segment Missing for S4S Security Start Segment
+716|26|S4S Security Start Segment Missing for S4E Security End Segment
+717|A|Accepted
+717|E|Accepted But Errors Were Noted
+717|M|Rejected, Message Authentication Code (MAC) Failed
+717|R|Rejected
+717|W|Rejected, Assurance Failed Validity Tests
+717|X|Rejected, Content After Decryption Could Not Be Analyzed
+718|1|Transaction Set Not Supported
+718|2|Transaction Set Trailer Missing
+718|3|Transaction Set Control Number in Header and Trailer Do Not Match
+718|4|Number of Included Segments Does Not Match Actual Count
+718|5|One or More Segments in Error
+718|6|Missing or Invalid Transaction Set Identifier
+718|7|Missing or Invalid Transaction Set Control Number
+718|8|Authentication Key Name Unknown
+718|9|Encryption Key Name Unknown
+718|10|Requested Service (Authentication or Encrypted) Not Available
+718|11|Unknown Security Recipient
+718|12|Incorrect Message Length (Encryption Only)
+718|13|Message Authentication Code Failed
+718|15|Unknown Security Originator
+718|16|Syntax Error in Decrypted Text
+718|17|Security Not Supported
+718|19|Invalid Transaction Set Implementation Convention Reference
+718|23|Transaction Set Control Number Not Unique within the Functional Group
+718|24|S3E Security End Segment Missing for S3S Security Start Segment
+718|25|S3S Security Start Segment Missing for S3E Security End Segment
+718|26|S4E Security End Segment Missing for S4S Security Start Segment
+718|27|S4S Security Start Segment Missing for S4E Security End Segment
+720|1|Unrecognized segment ID
+720|2|Unexpected segment
+720|3|Mandatory segment missing
+720|4|Loop Occurs Over Maximum Times
+720|5|Segment Exceeds Maximum Use
+720|6|Segment Not in Defined Transaction Set
+720|7|Segment Not in Proper Sequence
+720|8|Segment Has Data Element Errors
+723|1|Mandatory data element missing
+723|2|Conditional required data element missing.
+723|3|Too many data elements.
+723|4|Data element too short.
+723|5|Data element too long.
+723|6|Invalid character in data element.
+723|7|Invalid code value.
+723|8|Invalid Date
+723|9|Invalid Time
+723|10|Exclusion Condition Violated
+723|12|Too Many Repetitions
+723|13|Too Many Components
+735|19|Provider of Service
+735|20|Information Source
+735|21|Information Receiver
+735|22|Subscriber
+735|23|Dependent
+735|EV|Event
+735|PT|Patient
+735|SS|Services
+1005|0010|Information Source, Information Receiver, Provider of Service, Subscriber, Dependent
+1005|0019|Information Source, Subscriber, Dependent
+1005|0022|Information Source, Information Receiver, Subscriber, Dependent
+1029|1|Processed as Primary
+1029|2|Processed as Secondary
+1029|3|Processed as Tertiary
+1029|4|Denied
+1029|19|Processed as Primary, Forwarded to Additional Payer(s)
+1029|20|Processed as Secondary, Forwarded to Additional Payer(s)
+1029|21|Processed as Tertiary, Forwarded to Additional Payer(s)
+1029|22|Reversal of Previous Payment
+1029|23|Not Our Claim, Forwarded to Additional Payer(s)
+1029|25|Predetermination Pricing Only - No Payment
+1032|11|Other Non-Federal Programs
+1032|12|Preferred Provider Organization (PPO)
+1032|13|Point of Service (POS)
+1032|14|Exclusive Provider Organization (EPO)
+1032|15|Indemnity Insurance
+1032|16|Health Maintenance Organization (HMO) Medicare Risk
+1032|17|Dental Maintenance Organization
+1032|AM|Automobile Medical
+1032|BL|Blue Cross/Blue Shield
+1032|CH|Champus
+1032|CI|Commercial Insurance Co.
+1032|DS|Disability
+1032|FI|Federal Employees Program
+1032|HM|Health Maintenance Organization
+1032|LM|Liability Medical
+1032|MA|Medicare Part A
+1032|MB|Medicare Part B
+1032|MC|Medicaid
+1032|OF|Other Federal Program
+1032|TV|Title V
+1032|VA|Veterans Affairs Plan
+1032|WC|Workers' Compensation Health Claim
+1032|ZZ|Mutually Defined
+1033|CO|Contractual Obligations
+1033|CR|Correction and Reversals
+1033|OA|Other adjustments
+1033|PI|Payor Initiated Reductions
+1033|PR|Patient Responsibility
+1065|1|Person
+1065|2|Non-Person Entity
+1068|F|Female
+1068|M|Male
+1068|U|Unknown
+1069|01|Spouse
+1069|18|Self
+1069|19|Child
+1069|20|Employee
+1069|21|Unknown
+1069|39|Organ Donor
+1069|40|Cadaver Donor
+1069|53|Life Partner
+1069|G8|Other Relationship
+1073|N|No
+1073|U|Unknown
+1073|W|Not Applicable
+1073|Y|Yes
+1138|P|Primary
+1138|S|Secondary
+1138|T|Tertiary
+1138|U|Unknown
+1221|AT|Attending
+1221|BI|Billing
+1221|OP|Operating
+1221|PE|Performing
+1221|RF|Referring
+1221|SU|Supervising
+1250|D8|Date Expressed in Format CCYYMMDD
+1250|DT|Date and Time Expressed in Format CCYYMMDDHHMM
+1250|RD8|Range of Dates Expressed in Format CCYYMMDD-CCYYMMDD
+1250|TM|Time Expressed in Format HHMM
+1390|1|Active Coverage
+1390|2|Active - Full Risk Capitation
+1390|3|Active - Services Capitated
+1390|4|Active - Services Capitated to Primary Care Physician
+1390|5|Active - Pending Investigation
+1390|6|Inactive
+1390|7|Inactive - Pending Eligibility Update
+1390|8|Inactive - Pending Investigation
+1390|A|Co-Insurance
+1390|B|Co-Payment
+1390|C|Deductible
+1390|CB|Coverage Basis
+1390|D|Benefit Description
+1390|E|Exclusions
+1390|F|Limitations
+1390|G|Out of Pocket (Stop Loss)
+1390|H|Unlimited
+1390|I|Non-Covered
+1390|J|Cost Containment
+1390|K|Reserve
+1390|L|Primary Care Provider
+1390|M|Pre-existing Condition
+1390|MC|Managed Care Coordinator
+1390|N|Services Restricted to Following Provider
+1390|O|Not Deemed a Medical Necessity
+1390|P|Benefit Disclaimer
+1390|Q|Second Surgical Opinion Required
+1390|R|Other or Additional Payor
+1390|S|Prior Year(s) History
+1390|T|Card(s) Reported Lost/Stolen
+1390|U|Contact Following Entity for Eligibility or Benefit Information
+1390|V|Cannot Process
+1390|W|Other Source of Data
+1390|X|Health Care Facility
+1390|Y|Spend Down
+1525|AR|Admission Review
+1525|HS|Health Services Review
+1525|SC|Specialty Care Review
+I01|00|No Authorization Information Present (No Meaningful Information in I02)
+I01|03|Additional Data Identification
+I03|00|No Security Information Present (No Meaningful Information in I04)
+I03|01|Password
+I05|01|Duns (Dun & Bradstreet)
+I05|02|SCAC (Standard Carrier Alpha Code)
+I05|08|UCC EDI Communications ID (Comm ID)
+I05|12|Phone (Telephone Companies)
+I05|14|Duns Plus Suffix
+I05|20|Health Industry Number (HIN)
+I05|27|Carrier Identification Number as assigned by Health Care Financing Administration (HCFA)
+I05|28|Fiscal Intermediary Identification Number as assigned by Health Care Financing Administration (HCFA)
+I05|29|Medicare Provider and Supplier Identification Number as assigned by Health Care Financing Administration (HCFA)
+I05|30|U.S. Federal Tax Identification Number
+I05|33|National Association of Insurance Commissioners Company Code (NAIC)
+I05|ZZ|Mutually Defined
+I13|0|No Interchange Acknowledgment Requested
+I13|1|Interchange Acknowledgment Requested (TA1)
+I14|I|Information
+I14|P|Production Data
+I14|T|Test Data
+I17|A|The Transmitted Interchange Control Structure Header and Trailer Have Been Received and Have No Errors.
+I17|E|The Transmitted Interchange Control Structure Header and Trailer Have Been Received and Are Accepted But Errors Are Noted. This Means the Sender Must Not Resend This Data.
+I17|R|The Transmitted Interchange Control Structure Header and Trailer are Rejected Because of Errors.
+I18|000|No error
+I18|001|The Interchange Control Number in the Header and Trailer Do Not Match. The Value From the Header is Used in the Acknowledgment.
+I18|002|This Standard as Noted in the Control Standards Identifier is Not Supported.
+I18|003|This Version of the Controls is Not Supported
+I18|004|The Segment Terminator is Invalid
+I18|005|Invalid Interchange ID Qualifier for Sender
+I18|006|Invalid Interchange Sender ID
+I18|007|Invalid Interchange ID Qualifier for Receiver
+I18|008|Invalid Interchange Receiver ID
+I18|009|Unknown Interchange Receiver ID
+I18|010|Invalid Authorization Information Qualifier Value
+I18|011|Invalid Authorization Information Value
+I18|012|Invalid Security Information Qualifier Value
+I18|013|Invalid Security Information Value
+I18|014|Invalid Interchange Date Value
+I18|015|Invalid Interchange Time Value
+I18|016|Invalid Interchange Standards Identifier Value
+I18|017|Invalid Interchange Version ID Value
+I18|018|Invalid Interchange Control Number Value
+I18|019|Invalid Acknowledgment Requested Value
+I18|020|Invalid Test Indicator Value
+I18|021|Invalid Number of Included Groups Value
+I18|022|Invalid Control Structure
+I18|023|Improper (Premature) End-of-File (Transmission)
+I18|024|Invalid Interchange Content (e.g., Invalid GS Segment)
+I18|025|Duplicate Interchange Control Number
+I18|026|Invalid Data Element Separator
+I18|027|Invalid Component Element Separator
+I18|028|Invalid Delivery Date in Deferred Delivery Request
+I18|029|Invalid Delivery Time in Deferred Delivery Request
+I18|030|Invalid Delivery Time Code in Deferred Delivery Request
+I18|031|Invalid Grade of Service Code
+`,
+}
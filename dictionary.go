@@ -0,0 +1,57 @@
+package x12
+
+import (
+	"strings"
+
+	"github.com/tmc/x12/dict"
+)
+
+// Name returns the segment's name in the 005010 dictionary, such as
+// "Health Claim" for CLM, or "" if the segment is unknown.
+func (s Segment) Name() string {
+	if sg := dict.Default.Segment(s.ID); sg != nil {
+		return sg.Name
+	}
+	return ""
+}
+
+// ElementName returns the name of the data element at the 1-based
+// position pos in the 005010 dictionary, such as "Claim Submitter's
+// Identifier" for CLM01, or "" if it is unknown. Use package dict
+// directly for other versions.
+func (s Segment) ElementName(pos int) string {
+	return dict.Default.ElementName(s.ID, pos)
+}
+
+// CodeName returns the meaning of the code held by the element at the
+// 1-based position pos in the 005010 dictionary, such as
+// "Billing Provider" for NM101 "85", or "" if the element is absent or
+// its code is not in the dictionary.
+func (s Segment) CodeName(pos int) string {
+	if pos < 1 || pos > len(s.Elements) {
+		return ""
+	}
+	return dict.Default.CodeName(s.ID, pos, strings.TrimSpace(s.Elements[pos-1].Value))
+}
+
+// SegmentDefs returns definitions of the segments in d, keyed by
+// segment ID, for checking segments with SegmentDef.Check or for a
+// validator's segment table.
+func SegmentDefs(d *dict.Dictionary) map[string]*SegmentDef {
+	defs := make(map[string]*SegmentDef, len(d.Segments))
+	for id, sg := range d.Segments {
+		def := &SegmentDef{ID: id, Name: sg.Name}
+		for _, u := range sg.Elements {
+			e := ElementDef{Ref: u.Ref, Requirement: Requirement(u.Requirement)}
+			if el := d.Element(u.Ref); el != nil {
+				e.Name = el.Name
+			}
+			def.Elements = append(def.Elements, e)
+		}
+		for _, note := range sg.Syntax {
+			def.Syntax = append(def.Syntax, MustParseSyntaxNote(note))
+		}
+		defs[id] = def
+	}
+	return defs
+}
@@ -0,0 +1,51 @@
+package x12_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/dict"
+)
+
+func TestSegmentDictionary(t *testing.T) {
+	seg := x12.Segment{ID: "NM1", Elements: []x12.Element{
+		{Value: "85"}, {Value: "2"}, {Value: "ACME"}, {}, {}, {}, {}, {Value: "XX"}, {Value: "1234567893"},
+	}}
+	tests := []struct {
+		name, got, want string
+	}{
+		{"Name", seg.Name(), "Individual or Organizational Name"},
+		{"ElementName(1)", seg.ElementName(1), "Entity Identifier Code"},
+		{"ElementName(9)", seg.ElementName(9), "Identification Code"},
+		{"ElementName(13)", seg.ElementName(13), ""},
+		{"CodeName(1)", seg.CodeName(1), "Billing Provider"},
+		{"CodeName(2)", seg.CodeName(2), "Non-Person Entity"},
+		{"CodeName(8)", seg.CodeName(8), "Centers for Medicare and Medicaid Services National Provider Identifier"},
+		{"CodeName(3)", seg.CodeName(3), ""},
+		{"CodeName(11)", seg.CodeName(11), ""},
+		{"unknown segment", x12.Segment{ID: "ZZZ"}.Name(), ""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestSegmentDefs(t *testing.T) {
+	defs := x12.SegmentDefs(dict.Default)
+	if diff := cmp.Diff(perDef, defs["PER"], cmpopts.IgnoreFields(x12.ElementDef{}, "Ref")); diff != "" {
+		t.Errorf("SegmentDefs PER mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := defs["CLM"].Elements[0].Ref, "1028"; got != want {
+		t.Errorf("CLM01 Ref = %q, want %q", got, want)
+	}
+
+	seg := x12.Segment{ID: "NM1", Elements: []x12.Element{{Value: "85"}, {Value: "2"}, {Value: "ACME"}, {}, {}, {}, {}, {Value: "XX"}}}
+	errs := defs["NM1"].Check(seg)
+	if len(errs) != 1 || errs[0].Error() != "x12: NM109: syntax note P0809: if any of NM108, NM109 is present, all must be" {
+		t.Errorf("Check(NM1) = %v", errs)
+	}
+}
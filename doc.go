@@ -42,9 +42,56 @@
 // minimal envelope is synthesized and the document's
 // EnvelopeAutomaticallyAdded field is set.
 //
+// A binary data segment (BIN) is read by the length its first element
+// declares: its second element holds that many bytes verbatim, segment
+// terminators, separators, and line breaks included.
+//
 // Validate checks that the envelope is structurally sound: headers and
 // trailers are present, their control numbers match, and the trailer
-// counts (IEA01, GE01, SE01) match the document's contents.
+// counts (IEA01, GE01, SE01) match the document's contents. It also
+// checks the ISA and GS values of an envelope that was not synthesized:
+// field widths, qualifier and indicator codes, dates and times, numeric
+// control numbers, and that the ISA11 and ISA16 delimiters are distinct
+// from each other and from the document's separators. Each group must
+// carry a single transaction set type whose functional identifier
+// (FunctionalIDCode) is GS01, with ST03 agreeing with GS08. Its errors
+// are *EnvelopeError values naming the segment and element at fault.
+//
+// # Interchange acknowledgments
+//
+// NewTA1 answers an interchange from the error Decoder.Decode or
+// Validate returned for it: a TA1 rejects an interchange whose ISA or IEA
+// is in error, with the note code (TA105) for the element at fault, and
+// accepts one otherwise when its ISA14 requests an acknowledgment.
+// Decoder.Header returns the ISA of an interchange Decode failed to read.
+// AcknowledgeInterchange wraps the TA1 in an interchange of its own, and
+// TA1 segments received are decoded into Interchange.Acknowledgments.
+//
+// # Segment definitions
+//
+// A SegmentDef describes a segment as an X12 standard publishes it: its
+// element positions, each Mandatory, Optional, or Relational, and the
+// syntax notes that relate them, such as P0304 (PER03 and PER04 are
+// paired). SegmentDef.Check reports missing mandatory elements as
+// *ElementError values and violated syntax notes as *SyntaxError
+// values.
+//
+// Package dict is a data dictionary of segment and element names, data
+// types, lengths, and code meanings per X12 version. Segment.Name,
+// Segment.ElementName, and Segment.CodeName consult its 005010
+// dictionary, and SegmentDefs derives SegmentDefs from a dictionary.
+//
+// Implementation guides narrow a standard further, with loops, usage,
+// and situational rules. Package schema describes guides and checks
+// transactions against them, and package hipaa provides the 005010
+// HIPAA guides. Package snip combines these checks into a validation
+// pipeline organized by the WEDI SNIP levels, with Validate as the
+// base of level 1.
+//
+// Package segments maps segments and guide loops to Go structs, and the
+// subpackages of hipaa use it to model transactions as typed values,
+// such as the claims and service lines of an 837 in package
+// hipaa/x837p or the claim payments of an 835 in package hipaa/x835.
 //
 // # Errors
 //
@@ -61,6 +108,7 @@
 // case of inspecting or transforming whole interchanges, at the cost
 // of holding a document in memory while it is processed. An event- or
 // segment-level streaming API, and typed transaction-set layers (837,
-// 835, ...) validated against implementation guides, are out of scope
-// and belong in packages built on top of this one.
+// 835, ...), are out of scope and belong in packages built on top of
+// this one, as guide validation does in package schema and the typed
+// models do in the subpackages of hipaa.
 package x12
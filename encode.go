@@ -137,6 +137,14 @@ func (enc *Encoder) Encode(doc *Document) error {
 	if err := state.encodeISA(doc.Interchange.Header); err != nil {
 		return err
 	}
+	for _, ta1 := range doc.Interchange.Acknowledgments {
+		if ta1 == nil {
+			return fmt.Errorf("%w: nil TA1 segment", ErrInvalidFormat)
+		}
+		if err := state.encodeTA1(ta1); err != nil {
+			return err
+		}
+	}
 	for _, group := range doc.Interchange.FunctionGroups {
 		if err := state.encodeFunctionGroup(group); err != nil {
 			return err
@@ -234,6 +242,17 @@ func (state *encodeState) encodeISA(h *ISA) error {
 	})
 }
 
+func (state *encodeState) encodeTA1(t *TA1) error {
+	return state.writeSegment([]string{
+		"TA1",
+		t.ControlNumber,
+		t.Date,
+		t.Time,
+		t.AcknowledgmentCode,
+		t.NoteCode,
+	})
+}
+
 func (state *encodeState) encodeIEA(t *IEA) error {
 	return state.writeSegment([]string{
 		"IEA",
@@ -0,0 +1,374 @@
+package x12
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISA and GS code lists, from the X12 control segment definitions.
+var (
+	authorizationQualifiers = codeList("00", "01", "02", "03", "04", "05", "06")
+	securityQualifiers      = codeList("00", "01")
+	interchangeIDQualifiers = codeList(
+		"01", "02", "03", "04", "07", "08", "09", "10", "11", "12", "13",
+		"14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24",
+		"25", "26", "27", "28", "29", "30", "31", "32", "33", "34", "35",
+		"36", "37", "38", "AM", "NR", "SA", "SN", "ZZ")
+	acknowledgmentRequested = codeList("0", "1")
+	usageIndicators         = codeList("P", "T", "I")
+	responsibleAgencies     = codeList("T", "X")
+)
+
+// interchangeIDDigits holds the number of digits in the interchange IDs
+// of the qualifiers (ISA05, ISA07) that fix the ID's form.
+var interchangeIDDigits = map[string]int{
+	"01": 9,  // Duns (Dun & Bradstreet)
+	"12": 10, // Phone (telephone companies)
+	"14": 13, // Duns plus suffix
+	"30": 9,  // U.S. Federal Tax Identification Number
+	"33": 5,  // NAIC Company Code
+}
+
+func codeList(codes ...string) map[string]bool {
+	m := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		m[c] = true
+	}
+	return m
+}
+
+// An EnvelopeError describes an envelope that Document.Validate found
+// malformed: a control segment (ISA, IEA, GS, GE, ST, or SE) that is
+// missing, one of its elements that is invalid, or a trailer that
+// disagrees with its header or with the units it closes. It wraps
+// ErrInvalidFormat, or a more specific sentinel error.
+type EnvelopeError struct {
+	SegmentID string
+	Element   int // 1-based position of the offending element, or 0
+	Err       error
+}
+
+func (e *EnvelopeError) Error() string { return e.Err.Error() }
+
+func (e *EnvelopeError) Unwrap() error { return e.Err }
+
+// envelopeErrorf returns an *EnvelopeError about element of segmentID.
+func envelopeErrorf(segmentID string, element int, format string, args ...any) error {
+	return &EnvelopeError{SegmentID: segmentID, Element: element, Err: fmt.Errorf(format, args...)}
+}
+
+// fieldErrorf returns an *EnvelopeError about the element name, such as
+// "ISA09".
+func fieldErrorf(name, format string, args ...any) error {
+	id := strings.TrimRight(name, "0123456789")
+	pos, _ := strconv.Atoi(name[len(id):])
+	return envelopeErrorf(id, pos, format, args...)
+}
+
+// checkISA checks the values of the interchange header: field widths,
+// qualifier and indicator codes, the date and time, the control number,
+// and that the delimiters it declares differ from the document's own.
+// Fixed-width fields are compared without their padding.
+func (doc *Document) checkISA() error {
+	isa := doc.Interchange.Header
+	fields := []struct {
+		name     string
+		value    string
+		min, max int
+		codes    map[string]bool
+	}{
+		{"ISA01", isa.AuthorizationInfoQualifier, 2, 2, authorizationQualifiers},
+		{"ISA02", isa.AuthorizationInformation, 0, 10, nil},
+		{"ISA03", isa.SecurityInfoQualifier, 2, 2, securityQualifiers},
+		{"ISA04", isa.SecurityInfo, 0, 10, nil},
+		{"ISA05", isa.SenderIDQualifier, 2, 2, interchangeIDQualifiers},
+		{"ISA06", isa.SenderID, 1, 15, nil},
+		{"ISA07", isa.ReceiverIDQualifier, 2, 2, interchangeIDQualifiers},
+		{"ISA08", isa.ReceiverID, 1, 15, nil},
+		{"ISA12", isa.Version, 5, 5, nil},
+		{"ISA14", isa.AcknowledgmentRequested, 1, 1, acknowledgmentRequested},
+		{"ISA15", isa.UsageIndicator, 1, 1, usageIndicators},
+	}
+	for _, f := range fields {
+		if err := checkField(f.name, f.value, f.min, f.max, f.codes); err != nil {
+			return err
+		}
+	}
+	if err := checkInterchangeID("ISA06", isa.SenderIDQualifier, isa.SenderID); err != nil {
+		return err
+	}
+	if err := checkInterchangeID("ISA08", isa.ReceiverIDQualifier, isa.ReceiverID); err != nil {
+		return err
+	}
+	if !isDigits(strings.TrimSpace(isa.Version)) {
+		return envelopeErrorf("ISA", 12, "%w: ISA12 version %q is not numeric", ErrInvalidFormat, isa.Version)
+	}
+	if err := checkDate("ISA09", isa.Date, "060102"); err != nil {
+		return err
+	}
+	if err := checkTime("ISA10", isa.Time, 4); err != nil {
+		return err
+	}
+	if v := strings.TrimSpace(isa.ControlNumber); len(v) != 9 || !isDigits(v) {
+		return envelopeErrorf("ISA", 13, "%w: ISA13 control number %q is not 9 digits", ErrInvalidFormat, isa.ControlNumber)
+	}
+	return doc.checkDelimiters()
+}
+
+// checkDelimiters checks that the component element separator (ISA16)
+// and repetition separator (ISA11) are single characters distinct from
+// each other and from the element separator and segment terminator.
+// Padding, as in the relaxed ISA layouts some senders use, is ignored.
+// An ISA11 of "U", the interchange control standards identifier of
+// versions before 4020, declares no repetition separator.
+func (doc *Document) checkDelimiters() error {
+	isa := doc.Interchange.Header
+	elemSep, term := doc.ElementSeparator, doc.SegmentTerminator
+	if elemSep == "" {
+		elemSep = DefaultElementSeparator
+	}
+	if term == "" {
+		term = DefaultSegmentTerminator
+	}
+	used := map[string]string{elemSep: "element separator", term: "segment terminator"}
+	type delim struct {
+		name    string
+		element int
+		value   string
+	}
+	delims := []delim{{"ISA16 component element separator", 16, isa.ComponentElementSeparator}}
+	if isa.RepetitionSeparator != "U" {
+		delims = append(delims, delim{"ISA11 repetition separator", 11, isa.RepetitionSeparator})
+	}
+	for _, d := range delims {
+		if len(d.value) > 1 {
+			d.value = strings.TrimSpace(d.value)
+		}
+		if len(d.value) != 1 {
+			return envelopeErrorf("ISA", d.element, "%w: %s %q is not a single character", ErrInvalidFormat, d.name, d.value)
+		}
+		if other, ok := used[d.value]; ok {
+			return envelopeErrorf("ISA", d.element, "%w: %s %q is also the %s", ErrInvalidFormat, d.name, d.value, other)
+		}
+		used[d.value] = d.name
+	}
+	return nil
+}
+
+// checkGS checks the values of a functional group header: field widths,
+// the date and time, the control number, and the responsible agency
+// code.
+func checkGS(gs *GS) error {
+	fields := []struct {
+		name     string
+		value    string
+		min, max int
+		codes    map[string]bool
+	}{
+		{"GS01", gs.FunctionalIDCode, 2, 2, nil},
+		{"GS02", gs.SenderCode, 2, 15, nil},
+		{"GS03", gs.ReceiverCode, 2, 15, nil},
+		{"GS07", gs.ResponsibleAgencyCode, 1, 2, responsibleAgencies},
+		{"GS08", gs.Version, 1, 12, nil},
+	}
+	for _, f := range fields {
+		if err := checkField(f.name, f.value, f.min, f.max, f.codes); err != nil {
+			return err
+		}
+	}
+	if err := checkDate("GS04", gs.Date, "20060102"); err != nil {
+		return err
+	}
+	if err := checkTime("GS05", gs.Time, 4, 6, 7, 8); err != nil {
+		return err
+	}
+	if v := strings.TrimSpace(gs.ControlNumber); len(v) == 0 || len(v) > 9 || !isDigits(v) {
+		return envelopeErrorf("GS", 6, "%w: GS06 control number %q is not 1 to 9 digits", ErrInvalidFormat, gs.ControlNumber)
+	}
+	return nil
+}
+
+// checkField checks that value, without surrounding spaces, has between
+// min and max characters and, if codes is not nil, is one of codes.
+func checkField(name, value string, min, max int, codes map[string]bool) error {
+	v := strings.TrimSpace(value)
+	if len(v) < min || len(v) > max {
+		if min == max {
+			return fieldErrorf(name, "%w: %s %q is not %d characters", ErrInvalidFormat, name, value, min)
+		}
+		return fieldErrorf(name, "%w: %s %q is not %d to %d characters", ErrInvalidFormat, name, value, min, max)
+	}
+	if codes != nil && !codes[v] {
+		return fieldErrorf(name, "%w: %s %q is not a valid code", ErrInvalidFormat, name, value)
+	}
+	return nil
+}
+
+// checkInterchangeID checks an interchange ID against the form its
+// qualifier requires.
+func checkInterchangeID(name, qualifier, id string) error {
+	n, ok := interchangeIDDigits[strings.TrimSpace(qualifier)]
+	if !ok {
+		return nil
+	}
+	if v := strings.TrimSpace(id); len(v) != n || !isDigits(v) {
+		return fieldErrorf(name, "%w: %s %q is not %d digits as qualifier %s requires", ErrInvalidFormat, name, id, n, strings.TrimSpace(qualifier))
+	}
+	return nil
+}
+
+// checkDate checks that value is a calendar date in layout.
+func checkDate(name, value, layout string) error {
+	v := strings.TrimSpace(value)
+	if len(v) != len(layout) || !isDigits(v) {
+		return fieldErrorf(name, "%w: %s date %q is not %d digits", ErrInvalidFormat, name, value, len(layout))
+	}
+	if _, err := time.Parse(layout, v); err != nil {
+		return fieldErrorf(name, "%w: %s date %q is not a valid date", ErrInvalidFormat, name, value)
+	}
+	return nil
+}
+
+// checkTime checks that value is a time of day, HHMM followed by
+// optional seconds and decimal seconds, with one of the given lengths.
+func checkTime(name, value string, lengths ...int) error {
+	v := strings.TrimSpace(value)
+	ok := isDigits(v)
+	if ok {
+		ok = false
+		for _, n := range lengths {
+			ok = ok || len(v) == n
+		}
+	}
+	if !ok {
+		return fieldErrorf(name, "%w: %s time %q is not in HHMM form", ErrInvalidFormat, name, value)
+	}
+	if v[0:2] > "23" || v[2:4] > "59" || len(v) >= 6 && v[4:6] > "59" {
+		return fieldErrorf(name, "%w: %s time %q is not a valid time", ErrInvalidFormat, name, value)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// functionalIDs maps transaction set identifiers (ST01) to the
+// functional identifier codes (GS01) of the groups that carry them.
+var functionalIDs = map[string]string{
+	"180": "AN", // Return Merchandise Authorization and Notification
+	"204": "SM", // Motor Carrier Load Tender
+	"210": "IM", // Motor Carrier Freight Details and Invoice
+	"214": "QM", // Transportation Carrier Shipment Status Message
+	"270": "HS", // Eligibility, Coverage or Benefit Inquiry
+	"271": "HB", // Eligibility, Coverage or Benefit Information
+	"275": "PI", // Patient Information
+	"276": "HR", // Health Care Claim Status Request
+	"277": "HN", // Health Care Claim Status Notification
+	"278": "HI", // Health Care Services Review Information
+	"810": "IN", // Invoice
+	"812": "CD", // Credit/Debit Adjustment
+	"820": "RA", // Payment Order/Remittance Advice
+	"824": "AG", // Application Advice
+	"834": "BE", // Benefit Enrollment and Maintenance
+	"835": "HP", // Health Care Claim Payment/Advice
+	"837": "HC", // Health Care Claim
+	"850": "PO", // Purchase Order
+	"855": "PR", // Purchase Order Acknowledgment
+	"856": "SH", // Ship Notice/Manifest
+	"860": "PC", // Purchase Order Change Request - Buyer Initiated
+	"864": "TX", // Text Message
+	"940": "OW", // Warehouse Shipping Order
+	"945": "SW", // Warehouse Shipping Advice
+	"990": "GF", // Response to a Load Tender
+	"997": "FA", // Functional Acknowledgment
+	"999": "FA", // Implementation Acknowledgment
+}
+
+// FunctionalIDCode returns the functional identifier code (GS01) of the
+// groups that carry transaction sets with identifier id (ST01), such as
+// "HC" for "837", or "" if id is not in the package's table.
+func FunctionalIDCode(id string) string {
+	return functionalIDs[strings.TrimSpace(id)]
+}
+
+// checkGroupTransactions checks that a functional group's transaction
+// sets agree with its header: all share one transaction set identifier,
+// GS01 is the functional identifier of that transaction set, and each
+// ST03 agrees with GS08. ST03 agrees when either value is empty or one
+// begins with the other, as when GS08 gives only the version ("005010")
+// and ST03 names the implementation guide ("005010X222A1").
+func checkGroupTransactions(fg *FunctionGroup) error {
+	gs := fg.Header
+	gs01 := strings.TrimSpace(gs.FunctionalIDCode)
+	gs08 := strings.TrimSpace(gs.Version)
+	var first string
+	for _, tx := range fg.Transactions {
+		id := strings.TrimSpace(tx.Header.IDCode)
+		if first == "" {
+			first = id
+		} else if id != first {
+			return envelopeErrorf("ST", 1, "%w: group %s mixes transaction sets %s and %s", ErrInvalidFormat, gs.ControlNumber, first, id)
+		}
+		if want := FunctionalIDCode(id); want != "" && gs01 != want {
+			return envelopeErrorf("GS", 1, "%w: group %s GS01 %q does not carry transaction set %s (want %q)", ErrInvalidFormat, gs.ControlNumber, gs.FunctionalIDCode, id, want)
+		}
+		st03 := strings.TrimSpace(tx.Header.ImplementationConventionReference)
+		if st03 != "" && gs08 != "" && !strings.HasPrefix(st03, gs08) && !strings.HasPrefix(gs08, st03) {
+			return envelopeErrorf("ST", 3, "%w: transaction %s ST03 %q does not agree with GS08 %q", ErrInvalidFormat, tx.Header.ControlNumber, tx.Header.ImplementationConventionReference, gs.Version)
+		}
+	}
+	return nil
+}
+
+// Reply returns the header of an interchange answering the one with
+// header isa, as acknowledgments do: its sender and receiver are isa's
+// receiver and sender, it uses isa's delimiters, version, and usage
+// indicator, requests no acknowledgment, and is dated now. Its control
+// number (ISA13) is control, 1 to 9 digits, padded with zeros.
+func (isa *ISA) Reply(control string, now time.Time) (*ISA, error) {
+	if len(control) == 0 || len(control) > 9 || !isDigits(control) {
+		return nil, fmt.Errorf("%w: control number %q is not 1 to 9 digits", ErrInvalidArgument, control)
+	}
+	return &ISA{
+		AuthorizationInfoQualifier: "00",
+		AuthorizationInformation:   strings.Repeat(" ", 10),
+		SecurityInfoQualifier:      "00",
+		SecurityInfo:               strings.Repeat(" ", 10),
+		SenderIDQualifier:          isa.ReceiverIDQualifier,
+		SenderID:                   padRight(isa.ReceiverID, 15),
+		ReceiverIDQualifier:        isa.SenderIDQualifier,
+		ReceiverID:                 padRight(isa.SenderID, 15),
+		Date:                       now.Format("060102"),
+		Time:                       now.Format("1504"),
+		RepetitionSeparator:        orDefault(isa.RepetitionSeparator, "^"),
+		Version:                    orDefault(isa.Version, "00501"),
+		ControlNumber:              strings.Repeat("0", 9-len(control)) + control,
+		AcknowledgmentRequested:    "0",
+		UsageIndicator:             orDefault(isa.UsageIndicator, "P"),
+		ComponentElementSeparator:  orDefault(isa.ComponentElementSeparator, DefaultComponentSeparator),
+	}, nil
+}
+
+// padRight pads s with spaces to the width of a fixed-width ISA field.
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// orDefault returns s, or def if s is blank.
+func orDefault(s, def string) string {
+	if strings.TrimSpace(s) == "" {
+		return def
+	}
+	return s
+}
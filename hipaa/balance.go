@@ -0,0 +1,113 @@
+package hipaa
+
+import (
+	"fmt"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/snip"
+)
+
+// BalancingChecks are the SNIP level 3 balancing requirements the
+// guides share, for use in a snip.Validator's Checks:
+//
+//   - In a health care claim (837), each claim's total charge (CLM02)
+//     equals the sum of its service lines' charges (SV102, SV203, or
+//     SV302).
+//   - In a payment/advice (835), the total payment (BPR02) equals the
+//     sum of the claim payments (CLP04) less the provider adjustments
+//     (PLB04, PLB06, ...).
+var BalancingChecks = []snip.Check{
+	{Name: "claim charge balance", Level: snip.Balancing, TransactionSet: "837", Func: claimChargeBalance},
+	{Name: "payment balance", Level: snip.Balancing, TransactionSet: "835", Func: paymentBalance},
+}
+
+// lineCharges maps the 837 service line segments to the position of
+// their charge amount.
+var lineCharges = map[string]int{"SV1": 2, "SV2": 3, "SV3": 2}
+
+func claimChargeBalance(t *snip.Target) []error {
+	var errs []error
+	clm, clmPos := (*x12.Segment)(nil), 0
+	var lines int64
+	flush := func() {
+		if clm == nil {
+			return
+		}
+		charge, ok := cents(element(*clm, 2))
+		if ok && charge != lines {
+			errs = append(errs, &schema.Error{
+				Position: clmPos, SegmentID: "CLM", LoopID: "2300", Element: 2,
+				Err: fmt.Errorf("%w: claim charge %s, service lines total %s", snip.ErrOutOfBalance, model.Amount(charge), model.Amount(lines)),
+			})
+		}
+		clm = nil
+	}
+	for i := range t.Transaction.Segments {
+		seg := &t.Transaction.Segments[i]
+		switch {
+		case seg.ID == "CLM":
+			flush()
+			clm, clmPos, lines = seg, i+2, 0
+		case seg.ID == "HL":
+			flush()
+		case lineCharges[seg.ID] > 0:
+			if c, ok := cents(element(*seg, lineCharges[seg.ID])); ok {
+				lines += c
+			}
+		}
+	}
+	flush()
+	return errs
+}
+
+func paymentBalance(t *snip.Target) []error {
+	var bpr *x12.Segment
+	bprPos := 0
+	var claims, adjustments int64
+	for i := range t.Transaction.Segments {
+		seg := &t.Transaction.Segments[i]
+		switch seg.ID {
+		case "BPR":
+			bpr, bprPos = seg, i+2
+		case "CLP":
+			if c, ok := cents(element(*seg, 4)); ok {
+				claims += c
+			}
+		case "PLB":
+			for pos := 4; pos <= 14; pos += 2 {
+				if c, ok := cents(element(*seg, pos)); ok {
+					adjustments += c
+				}
+			}
+		}
+	}
+	if bpr == nil {
+		return nil
+	}
+	total, ok := cents(element(*bpr, 2))
+	if !ok || total == claims-adjustments {
+		return nil
+	}
+	return []error{&schema.Error{
+		Position: bprPos, SegmentID: "BPR", Element: 2,
+		Err: fmt.Errorf("%w: total payment %s, claim payments %s less adjustments %s", snip.ErrOutOfBalance, model.Amount(total), model.Amount(claims), model.Amount(adjustments)),
+	}}
+}
+
+// element returns the value of seg's element at the 1-based position
+// pos, or "".
+func element(seg x12.Segment, pos int) string {
+	if pos > len(seg.Elements) {
+		return ""
+	}
+	return seg.Elements[pos-1].Value
+}
+
+// cents parses a monetary amount into cents. It reports false for an
+// empty or malformed amount, which balancing leaves to other levels.
+func cents(s string) (int64, bool) {
+	c, err := model.Cents(s)
+	return c, err == nil
+}
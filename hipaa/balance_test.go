@@ -0,0 +1,46 @@
+package hipaa_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/snip"
+)
+
+func TestBalancingChecksFixtures(t *testing.T) {
+	v := &snip.Validator{Checks: hipaa.BalancingChecks}
+	for _, prefix := range []string{"005010x221", "005010x222", "005010x223", "005010x224"} {
+		for name, tx := range decodeFixtures(t, prefix) {
+			doc := &x12.Document{Interchange: &x12.Interchange{
+				Header:         &x12.ISA{},
+				FunctionGroups: []*x12.FunctionGroup{{Header: &x12.GS{}, Transactions: []*x12.Transaction{tx}}},
+			}}
+			for _, f := range v.Validate(doc).Level(snip.Balancing) {
+				t.Errorf("%s: %v", name, f)
+			}
+		}
+	}
+}
+
+func TestClaimChargeBalance(t *testing.T) {
+	input := strings.Replace(patientClaim, "CLM*26407789*79.04*", "CLM*26407789*80.04*", 1)
+	doc, err := x12.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &snip.Validator{Schemas: hipaa.Schemas, Checks: hipaa.BalancingChecks}
+	fs := v.Validate(doc).Level(snip.Balancing)
+	if len(fs) != 1 {
+		t.Fatalf("balancing findings = %v, want one", fs)
+	}
+	f := fs[0]
+	if f.Claim != "26407789" || f.Position != 24 || !errors.Is(f.Err, snip.ErrOutOfBalance) {
+		t.Errorf("finding = %v (position %d), want claim 26407789 out of balance at 24", f, f.Position)
+	}
+	if want := "claim charge 80.04, service lines total 79.04"; !strings.Contains(f.Err.Error(), want) {
+		t.Errorf("error = %q, want it to mention %q", f.Err, want)
+	}
+}
@@ -0,0 +1,110 @@
+package hipaa_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/schema"
+)
+
+// decodeFixtures decodes the testdata files whose names begin with
+// prefix and returns their transactions, keyed by file name.
+func decodeFixtures(t *testing.T, prefix string) map[string]*x12.Transaction {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("..", "testdata", prefix+"*.edi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("no fixtures match %s", prefix)
+	}
+	txs := make(map[string]*x12.Transaction)
+	for _, path := range paths {
+		txs[filepath.Base(path)], _ = hipaatest.ReadFile(t, path)
+	}
+	return txs
+}
+
+// schemaErrors returns the errors of arranging tx with ts and checking
+// its usage and rules.
+func schemaErrors(ts *schema.TransactionSet, tx *x12.Transaction) []error {
+	root, errs := ts.Parse(tx)
+	errs = append(errs, root.CheckUsage()...)
+	return append(errs, root.CheckRules()...)
+}
+
+// The guides without published examples are checked against the
+// transactions in this package's testdata, and against edits of each
+// that the guide rejects.
+func TestSchemaFixtures(t *testing.T) {
+	type edit struct {
+		name     string
+		old, new string
+		want     []error // in order
+	}
+	missing, unexpected := schema.ErrMissingSegment, schema.ErrUnexpectedSegment
+	tests := []struct {
+		ts    *schema.TransactionSet
+		file  string
+		edits []edit
+	}{
+		{hipaa.X186A1, "005010x186a1-advice-rejected-enrollment.edi", []edit{
+			{"missing receiver", "N1*40*SMITHCO*46*A1234~\n", "", []error{missing}},
+			{"unknown acknowledgment code", "OTI*TR*", "OTI*XX*", []error{unexpected, unexpected, unexpected, missing}},
+		}},
+		{hipaa.X210, "005010x210-unsolicited-attachment.edi", []edit{
+			{"missing attachment control number", "TRN*2*ATT0001~\n", "", []error{missing}},
+			{"missing binary data", "BIN*12*<ClinicalD~>~\n", "", []error{missing}},
+		}},
+		{hipaa.X218, "005010x218-premium-payment.edi", []edit{
+			{"missing trace number", "TRN*3*78905*1512345678~\n", "", []error{missing}},
+			{"unknown payer qualifier", "N1*PR*", "N1*XX*", []error{unexpected, missing}},
+		}},
+		{hipaa.X220A1, "005010x220a1-enrollment.edi", []edit{
+			{"missing subscriber identifier", "REF*0F*123456789~\n", "", []error{missing}},
+			{"coverage without dates", "DTP*348*D8*20240601~\n", "", []error{missing}},
+		}},
+		{hipaa.X231A1, "005010x231a1-accept-and-reject.edi", []edit{
+			{"missing trailer", "AK9*P*2*2*1~\n", "", []error{missing}},
+			{"missing response trailer", "IK5*A~\n", "", []error{missing}},
+			{"unknown acknowledgment code", "AK9*P*", "AK9*X*", []error{unexpected, missing}},
+		}},
+		{hipaa.X997, "997-accept-and-reject.edi", []edit{
+			{"missing trailer", "AK9*P*2*2*1~\n", "", []error{missing}},
+			{"missing response trailer", "AK5*A~\n", "", []error{missing}},
+			{"element note outside segment note", "AK3*CLM*22**8~\n", "", []error{unexpected}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			in := string(data)
+			for _, err := range schemaErrors(tt.ts, hipaatest.Decode(t, in)) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			for _, e := range tt.edits {
+				if !strings.Contains(in, e.old) {
+					t.Fatalf("%s: %q not found", e.name, e.old)
+				}
+				errs := schemaErrors(tt.ts, hipaatest.Decode(t, strings.Replace(in, e.old, e.new, 1)))
+				if len(errs) != len(e.want) {
+					t.Fatalf("%s: errors = %v, want %v", e.name, errs, e.want)
+				}
+				for i, err := range errs {
+					if !errors.Is(err, e.want[i]) {
+						t.Errorf("%s: error %d = %v, want %v", e.name, i, err, e.want[i])
+					}
+				}
+			}
+		})
+	}
+}
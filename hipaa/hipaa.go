@@ -0,0 +1,84 @@
+// Package hipaa provides schemas for the ASC X12 005010 implementation
+// guides adopted under HIPAA, for use with package schema.
+//
+// The schemas describe each guide's loop structure, segment usage and
+// repeats, and the qualifier codes that distinguish one use of a
+// segment from another. Situational usage notes are attached as
+// schema.Rules; those that can be decided from the transaction itself
+// name conditions this package registers, and the rest carry the
+// guide's text for reference. Element detail beyond qualifiers is not
+// yet described.
+//
+// Subpackages model individual transactions as Go types, converted to
+// and from x12.Transaction values with these schemas: x837p for the
+// professional claim, x837i for the institutional claim, x837d for the
+// dental claim, x835 for the claim payment/advice, x270 and x271 for the
+// eligibility inquiry and response, x276 and x277 for the claim status
+// request and response, x278 for the services review request and
+// response, x834 for benefit enrollment and maintenance, x820 for the
+// premium payment, x275 for claim attachments, x824 for the
+// application advice, x999 for the implementation acknowledgment, and x997
+// for the functional acknowledgment that some trading partners still
+// exchange in its place.
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+const (
+	req = schema.Required
+	sit = schema.Situational
+)
+
+// seg returns a segment use whose qualifying element (the first
+// element, or HL03 for HL) is restricted to codes.
+func seg(id, name string, usage schema.Usage, max int, codes ...string) *schema.Segment {
+	sg := &schema.Segment{ID: id, Name: name, Usage: usage, Max: max}
+	if len(codes) > 0 {
+		pos := 1
+		if id == "HL" {
+			pos = 3
+		}
+		sg.Elements = make([]*schema.Element, pos)
+		sg.Elements[pos-1] = &schema.Element{Usage: schema.Required, Codes: codes}
+	}
+	return sg
+}
+
+// loop returns a loop of the given children, the first of which is its
+// trigger segment.
+func loop(id, name string, usage schema.Usage, max int, children ...schema.Node) *schema.Loop {
+	return &schema.Loop{ID: id, Name: name, Usage: usage, Max: max, Children: children}
+}
+
+// withRule attaches rule to sg and returns it.
+func withRule(sg *schema.Segment, rule *schema.Rule) *schema.Segment {
+	sg.Rule = rule
+	return sg
+}
+
+// loopWithRule attaches rule to l and returns it.
+func loopWithRule(l *schema.Loop, rule *schema.Rule) *schema.Loop {
+	l.Rule = rule
+	return l
+}
+
+// Schemas lists the implementation guides this package provides, for
+// use in a snip.Validator.
+var Schemas = []*schema.TransactionSet{
+	X186A1,
+	X210,
+	X212Request,
+	X212Response,
+	X217Request,
+	X217Response,
+	X218,
+	X220A1,
+	X221A1,
+	X222A1,
+	X223A2,
+	X224A2,
+	X231A1,
+	X279A1Request,
+	X279A1Response,
+	X997,
+}
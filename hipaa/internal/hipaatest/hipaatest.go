@@ -0,0 +1,94 @@
+// Package hipaatest decodes the transactions the tests of hipaa and its
+// subpackages run against, and round-trips them through the typed
+// models.
+package hipaatest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/segments"
+)
+
+// Decode decodes s, a transaction set with or without its envelope, and
+// returns its first transaction set. It fails the test if s does not
+// decode.
+func Decode(t testing.TB, s string) *x12.Transaction {
+	t.Helper()
+	doc, err := x12.Decode(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc.Interchange.FunctionGroups[0].Transactions[0]
+}
+
+// ReadFile decodes the file at path and returns its first transaction
+// set and the delimiters it was written with. Segment IDs padded with
+// whitespace, as some published examples are, are accepted. It fails the
+// test if the file cannot be read or decoded.
+func ReadFile(t testing.TB, path string) (*x12.Transaction, segments.Delimiters) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := x12.Decode(f, x12.WithRelaxedSegmentIDWhitespace())
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return doc.Interchange.FunctionGroups[0].Transactions[0], segments.DelimitersOf(doc)
+}
+
+// Glob returns the names of the files matching pattern. It fails the
+// test if there are none.
+func Glob(t testing.TB, pattern string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(pattern)
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no fixtures match %s: %v", pattern, err)
+	}
+	return paths
+}
+
+// A Model is a typed transaction set, such as *x837p.Transaction.
+type Model interface {
+	ToTransaction(d segments.Delimiters) (*x12.Transaction, error)
+	Validate(d segments.Delimiters) []error
+}
+
+// RoundTrip runs a subtest for each file in paths. It converts the
+// file's transaction set to a model with from and back with
+// ToTransaction, and reports any difference from the file, compared
+// with opts, and any error Validate returns. If check is not nil, it is
+// called with the model and the converted transaction set for the
+// checks particular to a guide.
+func RoundTrip[M Model](t *testing.T, paths []string, from func(*x12.Transaction, segments.Delimiters) (M, error), check func(t *testing.T, path string, m M, got *x12.Transaction), opts ...cmp.Option) {
+	t.Helper()
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			tx, d := ReadFile(t, path)
+			m, err := from(tx, d)
+			if err != nil {
+				t.Fatalf("FromTransaction() error: %v", err)
+			}
+			got, err := m.ToTransaction(d)
+			if err != nil {
+				t.Fatalf("ToTransaction() error: %v", err)
+			}
+			if diff := cmp.Diff(tx, got, opts...); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+			if check != nil {
+				check(t, path, m, got)
+			}
+			for _, err := range m.Validate(d) {
+				t.Errorf("Validate() error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/x12"
+)
+
+// Cents parses s, a monetary amount of X12 data element type R, into
+// cents, rounding half away from zero. An R value is an optional minus
+// sign, digits, and an optional decimal point among them; Cents returns
+// an error wrapping x12.ErrInvalidFormat for anything else, including an
+// empty value, spaces, a plus sign, an exponent, and "NaN" or "Inf".
+func Cents(s string) (int64, error) {
+	v, neg := s, strings.HasPrefix(s, "-")
+	if neg {
+		v = v[1:]
+	}
+	whole, frac, _ := strings.Cut(v, ".")
+	if whole+frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: amount %q", x12.ErrInvalidFormat, s)
+	}
+	frac += "000"
+	c, err := strconv.ParseInt(whole+frac[:2], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: amount %q", x12.ErrInvalidFormat, s)
+	}
+	if frac[2] >= '5' {
+		c++
+	}
+	if neg {
+		c = -c
+	}
+	return c, nil
+}
+
+// Amount formats c cents as a decimal amount.
+func Amount(c int64) string {
+	sign := ""
+	if c < 0 {
+		sign, c = "-", -c
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, c/100, c%100)
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/model"
+)
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0", 0},
+		{"100", 10000},
+		{"12.5", 1250},
+		{"12.50", 1250},
+		{".75", 75},
+		{"3.", 300},
+		{"-25", -2500},
+		{"-0.015", -2},
+		{"1.004", 100},
+		{"1.005", 101},
+	}
+	for _, tt := range tests {
+		got, err := model.Cents(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Cents(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "-", ".", " 12", "12 ", "+12", "1e3", "0x1p4", "NaN", "Inf", "-Inf", "1,000", "1.2.3", "--1", "8OO", "99999999999999999999"} {
+		if got, err := model.Cents(in); !errors.Is(err, x12.ErrInvalidFormat) {
+			t.Errorf("Cents(%q) = %d, %v, want %v", in, got, err, x12.ErrInvalidFormat)
+		}
+	}
+}
+
+func TestAmount(t *testing.T) {
+	for c, want := range map[int64]string{0: "0.00", 5: "0.05", 1250: "12.50", -2500: "-25.00", -7: "-0.07"} {
+		if got := model.Amount(c); got != want {
+			t.Errorf("Amount(%d) = %q, want %q", c, got, want)
+		}
+	}
+}
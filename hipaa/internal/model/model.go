@@ -0,0 +1,164 @@
+// Package model holds the conversions shared by the typed transaction
+// models in the subpackages of hipaa, and the envelope of the
+// acknowledgments they generate.
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+)
+
+// Unmarshal arranges tx with ts and stores it in the loop struct v, and
+// ST02 and ST03 in v's ControlNumber and Version fields. It returns an
+// error wrapping x12.ErrInvalidArgument if tx or its ST is nil. Errors
+// are prefixed with pkg, the calling package's name.
+func Unmarshal(pkg string, ts *schema.TransactionSet, tx *x12.Transaction, v any, d segments.Delimiters) error {
+	if tx == nil || tx.Header == nil {
+		return fmt.Errorf("%w: %s: transaction has no ST segment", x12.ErrInvalidArgument, pkg)
+	}
+	root, errs := ts.Parse(tx)
+	switch len(errs) {
+	case 0:
+	case 1:
+		return fmt.Errorf("%s: %w", pkg, errs[0])
+	default:
+		return fmt.Errorf("%s: %w (and %d more errors)", pkg, errs[0], len(errs)-1)
+	}
+	if err := segments.UnmarshalLoop(root, v, d); err != nil {
+		return fmt.Errorf("%s: %w", pkg, err)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Struct {
+		setString(rv.Elem(), "ControlNumber", tx.Header.ControlNumber)
+		setString(rv.Elem(), "Version", tx.Header.ImplementationConventionReference)
+	}
+	return nil
+}
+
+// setString sets the string field name of the struct v, if it has one.
+func setString(v reflect.Value, name, value string) {
+	if f := v.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.CanSet() {
+		f.SetString(value)
+	}
+}
+
+// Marshal returns the loop struct v as a transaction of ts with the
+// given control number and implementation convention reference, adding
+// the ST and SE segments. An empty version defaults to ts.Version
+// unless read is set, as for a model read from a transaction, which is
+// written back as it was read, without an ST03.
+func Marshal(pkg string, ts *schema.TransactionSet, control, version string, read bool, v any, d segments.Delimiters) (*x12.Transaction, error) {
+	segs, err := segments.MarshalLoop(v, d)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg, err)
+	}
+	if version == "" && !read {
+		version = ts.Version
+	}
+	return &x12.Transaction{
+		Header: &x12.ST{
+			IDCode:                            ts.ID,
+			ControlNumber:                     control,
+			ImplementationConventionReference: version,
+		},
+		Segments: segs,
+		Trailer: &x12.SE{
+			SegmentCount:  strconv.Itoa(len(segs) + 2),
+			ControlNumber: control,
+		},
+	}, nil
+}
+
+// Validate reports the ways tx fails to conform to ts: loop structure,
+// segment usage and repeats, and the guide's situational rules.
+func Validate(ts *schema.TransactionSet, tx *x12.Transaction) []error {
+	root, errs := ts.Parse(tx)
+	errs = append(errs, root.CheckUsage()...)
+	return append(errs, root.CheckRules()...)
+}
+
+// Clone returns a deep copy of *v: its slices and pointers, and theirs
+// in turn, are copied, so that a ToTransaction can fill in values in the
+// copy without modifying the model it was given.
+func Clone[T any](v *T) *T {
+	c := new(T)
+	*c = *v
+	detach(reflect.ValueOf(c).Elem())
+	return c
+}
+
+// detach replaces the slices and pointers reachable from v through
+// exported fields with copies.
+func detach(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				detach(f)
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		v.Set(c)
+		for i := 0; i < c.Len(); i++ {
+			detach(c.Index(i))
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(v.Elem())
+		v.Set(c)
+		detach(c.Elem())
+	}
+}
+
+// Levels numbers hierarchical levels in the order they are visited.
+type Levels struct {
+	n int
+}
+
+// Number fills in hl's empty values: HL01 with the next number, HL02
+// with parent, HL03 with code, and HL04 from whether the level has
+// children. It returns HL01, to pass as the parent of nested levels.
+// Levels that are already numbered advance the count past their
+// number.
+func (l *Levels) Number(hl *segments.HL, code, parent string, children bool) string {
+	if hl.ID == "" {
+		l.n++
+		hl.ID = strconv.Itoa(l.n)
+	} else if id, err := strconv.Atoi(hl.ID); err == nil && id > l.n {
+		l.n = id
+	}
+	if hl.ParentID == "" {
+		hl.ParentID = parent
+	}
+	if hl.LevelCode == "" {
+		hl.LevelCode = code
+	}
+	if hl.ChildCode == "" {
+		hl.ChildCode = "0"
+		if children {
+			hl.ChildCode = "1"
+		}
+	}
+	return hl.ID
+}
+
+// Leaf is Number for a level without children whose HL04 the guide does
+// not use: it fills in HL01, HL02, and HL03 only.
+func (l *Levels) Leaf(hl *segments.HL, code, parent string) string {
+	child := hl.ChildCode
+	id := l.Number(hl, code, parent, false)
+	hl.ChildCode = child
+	return id
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tmc/x12"
+)
+
+// Reply returns an interchange answering doc, as acknowledgments do: one
+// functional group of txs with the functional identifier code and
+// version given, whose ISA and GS exchange the senders and receivers of
+// doc's ISA and first GS. The interchange is dated now and numbered
+// control, 1 to 9 digits: ISA13 is control padded with zeros, and GS06
+// is control. Errors are prefixed with pkg, the calling package's name.
+func Reply(pkg string, doc *x12.Document, functionalID, version, control string, now time.Time, txs []*x12.Transaction) (*x12.Document, error) {
+	if doc == nil || doc.Interchange == nil {
+		return nil, fmt.Errorf("%w: %s: document has no interchange", x12.ErrInvalidArgument, pkg)
+	}
+	in := doc.Interchange.Header
+	if in == nil {
+		in = &x12.ISA{}
+	}
+	isa, err := in.Reply(control, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg, err)
+	}
+	gs := &x12.GS{
+		FunctionalIDCode:      functionalID,
+		Date:                  now.Format("20060102"),
+		Time:                  now.Format("1504"),
+		ControlNumber:         control,
+		ResponsibleAgencyCode: "X",
+		Version:               version,
+	}
+	if groups := doc.Interchange.FunctionGroups; len(groups) > 0 && groups[0] != nil && groups[0].Header != nil {
+		gs.SenderCode, gs.ReceiverCode = groups[0].Header.ReceiverCode, groups[0].Header.SenderCode
+	}
+	return &x12.Document{
+		Interchange: &x12.Interchange{
+			Header: isa,
+			FunctionGroups: []*x12.FunctionGroup{{
+				Header:       gs,
+				Transactions: txs,
+				Trailer:      &x12.GE{TransactionSetCount: strconv.Itoa(len(txs)), ControlNumber: control},
+			}},
+			Trailer: &x12.IEA{FunctionalGroupCount: "1", ControlNumber: isa.ControlNumber},
+		},
+		SegmentTerminator: doc.SegmentTerminator,
+		ElementSeparator:  doc.ElementSeparator,
+	}, nil
+}
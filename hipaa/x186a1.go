@@ -0,0 +1,36 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X186A1 is the Application Reporting for Insurance (824)
+// implementation guide, 005010X186A1: the application advice that
+// accepts or rejects the transactions of an interchange after they pass
+// syntax checks, reporting the errors found in them.
+var X186A1 = &schema.TransactionSet{
+	ID:      "824",
+	Version: "005010X186A1",
+	Name:    "Application Reporting for Insurance",
+	Loop: &schema.Loop{Children: []schema.Node{
+		seg("BGN", "Beginning Segment", req, 1),
+		loop("1000A", "Submitter Name", req, 1,
+			seg("N1", "Submitter Name", req, 1, "41"),
+			seg("PER", "Submitter Contact Information", sit, 3, "IC"),
+		),
+		loop("1000B", "Receiver Name", req, 1,
+			seg("N1", "Receiver Name", req, 1, "40"),
+			seg("PER", "Receiver Contact Information", sit, 3, "IC"),
+		),
+		loop("2000", "Original Transaction Identification", req, 0,
+			seg("OTI", "Original Transaction Identification", req, 1, "TA", "TE", "TR"),
+			seg("REF", "Original Transaction Reference", sit, 12),
+			seg("DTM", "Original Transaction Date", sit, 2),
+			seg("AMT", "Original Transaction Amount", sit, 10),
+			seg("QTY", "Original Transaction Quantity", sit, 10),
+			loop("2100", "Error or Warning", sit, 0,
+				seg("TED", "Error or Warning", req, 1),
+				seg("NTE", "Error Message", sit, 100),
+				seg("RED", "Related Data", sit, 100),
+			),
+		),
+	}},
+}
@@ -0,0 +1,42 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X210 is the Additional Information to Support a Health Care Claim or
+// Encounter (275) implementation guide, 005010X210: attachments, such as
+// clinical documents, sent with or in answer to a request about a claim.
+var X210 = &schema.TransactionSet{
+	ID:      "275",
+	Version: "005010X210",
+	Name:    "Additional Information to Support a Health Care Claim or Encounter",
+	Loop: &schema.Loop{Children: []schema.Node{
+		seg("BGN", "Beginning Segment", req, 1, "02", "11"),
+		loop("1000A", "Payer Name", req, 1,
+			seg("NM1", "Payer Name", req, 1, "PR"),
+			seg("PER", "Payer Contact Information", sit, 1, "IC"),
+		),
+		loop("1000B", "Submitter Name", req, 1,
+			seg("NM1", "Submitter Name", req, 1, "41"),
+			seg("PER", "Submitter Contact Information", sit, 1, "IC"),
+		),
+		loop("1000C", "Provider Name", req, 1,
+			seg("NM1", "Provider Name", req, 1, "1P", "85"),
+			seg("PER", "Provider Contact Information", sit, 1, "IC"),
+		),
+		loop("1000D", "Patient Name", req, 1,
+			seg("NM1", "Patient Name", req, 1, "QC"),
+			seg("REF", "Patient Control Number", sit, 1, "EJ"),
+			seg("REF", "Medical Record Number", sit, 1, "EA"),
+			seg("DTP", "Claim Service Date", sit, 1, "472"),
+		),
+		loop("2000A", "Assigned Number", req, 0,
+			seg("LX", "Assigned Number", req, 1),
+			seg("TRN", "Attachment Control Number", req, 1, "1", "2"),
+			loop("2100A", "Additional Information", req, 1,
+				seg("CAT", "Additional Information Category", req, 1),
+				seg("EFI", "Electronic Format Identification", req, 1),
+				seg("BIN", "Binary Data", req, 1),
+			),
+		),
+	}},
+}
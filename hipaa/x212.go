@@ -0,0 +1,128 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X212Request is the Health Care Claim Status Request (276)
+// implementation guide, 005010X212.
+var X212Request = &schema.TransactionSet{
+	ID:      "276",
+	Version: "005010X212",
+	Name:    "Health Care Claim Status Request",
+	Loop:    x212Request(),
+}
+
+// X212Response is the Health Care Claim Status Response (277)
+// implementation guide, 005010X212.
+var X212Response = &schema.TransactionSet{
+	ID:      "277",
+	Version: "005010X212",
+	Name:    "Health Care Claim Status Response",
+	Loop:    x212Response(),
+}
+
+// x212Levels returns the information source, receiver, and service
+// provider levels the 276 and 277 share, with receiver and provider
+// holding the children of their levels that follow the name loop.
+func x212Levels(receiver, provider []schema.Node, payer ...schema.Node) []schema.Node {
+	return []schema.Node{
+		seg("BHT", "Beginning of Hierarchical Transaction", req, 1),
+		loop("2000A", "Information Source Level", req, 0,
+			seg("HL", "Information Source Level", req, 1, "20"),
+			loop("2100A", "Payer Name", req, 1,
+				append([]schema.Node{seg("NM1", "Payer Name", req, 1, "PR")}, payer...)...,
+			),
+			loop("2000B", "Information Receiver Level", req, 0, append([]schema.Node{
+				seg("HL", "Information Receiver Level", req, 1, "21"),
+				loop("2100B", "Information Receiver Name", req, 1,
+					seg("NM1", "Information Receiver Name", req, 1, "41"),
+				),
+			}, append(receiver,
+				loop("2000C", "Service Provider Level", req, 0, append([]schema.Node{
+					seg("HL", "Service Provider Level", req, 1, "19"),
+					loop("2100C", "Provider Name", req, 1,
+						seg("NM1", "Provider Name", req, 1, "1P"),
+					),
+				}, provider...)...),
+			)...)...),
+		),
+	}
+}
+
+func x212Request() *schema.Loop {
+	// member returns the subscriber (D) or dependent (E) level of a 276.
+	member := func(level, who, hlCode, nm1Code string, usage schema.Usage) *schema.Loop {
+		return loop("2000"+level, who+" Level", usage, 0,
+			seg("HL", who+" Level", req, 1, hlCode),
+			seg("DMG", who+" Demographic Information", sit, 1),
+			loop("2100"+level, who+" Name", req, 1,
+				seg("NM1", who+" Name", req, 1, nm1Code),
+			),
+			loop("2200"+level, "Claim Status Tracking Number", sit, 0,
+				seg("TRN", "Claim Status Tracking Number", req, 1, "1"),
+				seg("REF", "Payer Claim Control Number", sit, 1, "1K"),
+				seg("REF", "Institutional Bill Type Identification", sit, 1, "BLT"),
+				seg("REF", "Application or Location System Identifier", sit, 1, "LU"),
+				seg("REF", "Group Number", sit, 1, "6P"),
+				seg("REF", "Patient Control Number", sit, 1, "EJ"),
+				seg("REF", "Pharmacy Prescription Number", sit, 1, "XZ"),
+				seg("REF", "Claim Identification Number For Clearinghouses and Other Transmission Intermediaries", sit, 1, "D9"),
+				seg("AMT", "Claim Submitted Charges", sit, 1, "T3"),
+				seg("DTP", "Claim Service Date", sit, 1, "472"),
+				loop("2210"+level, "Service Line Information", sit, 0,
+					seg("SVC", "Service Line Information", req, 1),
+					seg("REF", "Service Line Item Identification", sit, 1, "FJ"),
+					seg("DTP", "Service Line Date", sit, 1, "472"),
+				),
+			),
+		)
+	}
+	subscriber := member("D", "Subscriber", "22", "IL", req)
+	subscriber.Children = append(subscriber.Children, member("E", "Dependent", "23", "QC", sit))
+
+	return &schema.Loop{Children: x212Levels(nil, []schema.Node{subscriber})}
+}
+
+func x212Response() *schema.Loop {
+	// trace returns the trace loop of a receiver (B) or provider (C)
+	// level, by which a 277 reports on a request as a whole.
+	trace := func(level, who string) *schema.Loop {
+		return loop("2200"+level, who+" Trace Identifier", sit, 1,
+			seg("TRN", who+" Trace Identifier", req, 1, "1", "2"),
+			seg("STC", who+" Status Information", req, 0),
+		)
+	}
+	// member returns the subscriber (D) or dependent (E) level of a 277.
+	member := func(level, who, hlCode, nm1Code string, usage schema.Usage) *schema.Loop {
+		return loop("2000"+level, who+" Level", usage, 0,
+			seg("HL", who+" Level", req, 1, hlCode),
+			loop("2100"+level, who+" Name", req, 1,
+				seg("NM1", who+" Name", req, 1, nm1Code),
+			),
+			loop("2200"+level, "Claim Status Tracking Number", sit, 0,
+				seg("TRN", "Claim Status Tracking Number", req, 1, "2"),
+				seg("STC", "Claim Level Status Information", req, 0),
+				seg("REF", "Payer Claim Control Number", sit, 1, "1K"),
+				seg("REF", "Institutional Bill Type Identification", sit, 1, "BLT"),
+				seg("REF", "Patient Control Number", sit, 1, "EJ"),
+				seg("REF", "Pharmacy Prescription Number", sit, 1, "XZ"),
+				seg("REF", "Voucher Identifier", sit, 1, "VV"),
+				seg("REF", "Claim Identification Number For Clearinghouses and Other Transmission Intermediaries", sit, 1, "D9"),
+				seg("DTP", "Claim Service Date", sit, 1, "472"),
+				loop("2220"+level, "Service Line Information", sit, 0,
+					seg("SVC", "Service Line Information", req, 1),
+					seg("STC", "Service Line Status Information", req, 0),
+					seg("REF", "Service Line Item Identification", sit, 1, "FJ"),
+					seg("DTP", "Service Line Date", sit, 1, "472"),
+				),
+			),
+		)
+	}
+	subscriber := member("D", "Subscriber", "22", "IL", sit)
+	subscriber.Children = append(subscriber.Children, member("E", "Dependent", "23", "QC", sit))
+
+	return &schema.Loop{Children: x212Levels(
+		[]schema.Node{trace("B", "Information Receiver")},
+		[]schema.Node{trace("C", "Provider of Service"), subscriber},
+		seg("PER", "Payer Contact Information", sit, 1, "IC"),
+	)}
+}
@@ -0,0 +1,32 @@
+package hipaa_test
+
+import (
+	"testing"
+
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+)
+
+func TestX212Fixtures(t *testing.T) {
+	for name, tx := range decodeFixtures(t, "005010x212") {
+		t.Run(name, func(t *testing.T) {
+			ts := hipaa.X212Request
+			if tx.Header.IDCode == "277" {
+				ts = hipaa.X212Response
+			}
+			for _, err := range schemaErrors(ts, tx) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			root, _ := ts.Parse(tx)
+			levels := 0
+			root.Walk(func(n *schema.LoopNode) {
+				if n.Segment("HL") != nil {
+					levels++
+				}
+			})
+			if levels < 2 {
+				t.Errorf("found %d hierarchical levels, want at least 2", levels)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X217Request is the Health Care Services Review Request for Review
+// (278) implementation guide, 005010X217.
+//
+// The request and response share ST01 and ST03; BHT02 tells them
+// apart, "13" marking a request and "11" a response.
+var X217Request = &schema.TransactionSet{
+	ID:      "278",
+	Version: "005010X217",
+	Name:    "Health Care Services Review Request for Review",
+	Loop:    x217(false),
+}
+
+// X217Response is the Health Care Services Review Response (278)
+// implementation guide, 005010X217.
+var X217Response = &schema.TransactionSet{
+	ID:      "278",
+	Version: "005010X217",
+	Name:    "Health Care Services Review Response",
+	Loop:    x217(true),
+}
+
+// x217 returns the loop structure of the 278 request or response. The
+// two share their loops; segments only one of them uses are marked not
+// used in the other.
+func x217(response bool) *schema.Loop {
+	purpose, inRequest, inResponse := "13", sit, schema.NotUsed
+	if response {
+		purpose, inRequest, inResponse = "11", schema.NotUsed, sit
+	}
+	bht := seg("BHT", "Beginning of Hierarchical Transaction", req, 1)
+	bht.Elements = []*schema.Element{nil, {Usage: req, Codes: []string{purpose}}}
+
+	providers := []string{"1T", "71", "72", "73", "77", "AAJ", "DD", "DK", "DN", "FA", "G3", "P3", "QB", "QV", "SJ"}
+	reviewRefs := []string{"9F", "BB", "G1", "NT"}
+	eventDates := []string{"439", "484", "ABG", "AAH"}
+
+	// provider returns a provider name loop: a requester (2010B), an
+	// event provider (2010EA), or a service provider (2010F).
+	provider := func(id, name string, usage schema.Usage, max int, codes ...string) *schema.Loop {
+		return loop(id, name, usage, max,
+			seg("NM1", name, req, 1, codes...),
+			seg("REF", name+" Supplemental Identification", sit, 7, "1G", "1J", "EI", "N5", "N7", "SY", "ZH"),
+			seg("N3", name+" Address", sit, 1),
+			seg("N4", name+" City, State, ZIP Code", sit, 1),
+			seg("PER", name+" Contact Information", sit, 1, "IC"),
+			seg("AAA", name+" Request Validation", inResponse, 9),
+			seg("PRV", name+" Provider Information", sit, 1),
+		)
+	}
+	// service returns a service level, nested in the patient event.
+	service := func() *schema.Loop {
+		return loop("2000F", "Service Level", sit, 0,
+			seg("HL", "Service Level", req, 1, "SS"),
+			seg("TRN", "Service Trace Number", sit, 2, "1", "2"),
+			seg("AAA", "Service Request Validation", inResponse, 9),
+			seg("UM", "Health Care Services Review Information", sit, 1),
+			seg("HCR", "Health Care Services Review", inResponse, 1),
+			seg("REF", "Previous Review Authorization Number", sit, 2, reviewRefs...),
+			seg("DTP", "Service Date", sit, 1, "472"),
+			seg("SV1", "Professional Service", sit, 1),
+			seg("SV2", "Institutional Service Line", sit, 1),
+			seg("SV3", "Dental Service", sit, 1),
+			seg("TOO", "Tooth Information", sit, 32),
+			seg("HSD", "Health Care Services Delivery", sit, 1),
+			seg("PWK", "Additional Service Information", sit, 10),
+			seg("MSG", "Message Text", inResponse, 1),
+			provider("2010F", "Service Provider Name", sit, 3, providers...),
+		)
+	}
+	// event returns the patient event level, which nests in the level
+	// of the patient, subscriber or dependent.
+	event := func() *schema.Loop {
+		return loop("2000E", "Patient Event Level", sit, 1,
+			seg("HL", "Patient Event Level", req, 1, "EV"),
+			seg("TRN", "Patient Event Trace Number", sit, 3, "1", "2"),
+			seg("AAA", "Patient Event Request Validation", inResponse, 9),
+			seg("UM", "Health Care Services Review Information", req, 1),
+			seg("HCR", "Health Care Services Review", inResponse, 1),
+			seg("REF", "Previous Review Authorization Number", sit, 2, reviewRefs...),
+			seg("DTP", "Event Date", sit, 1, eventDates...),
+			seg("DTP", "Admission Date", sit, 1, "435"),
+			seg("DTP", "Discharge Date", sit, 1, "096"),
+			seg("HI", "Patient Diagnosis", sit, 1),
+			seg("HSD", "Health Care Services Delivery", sit, 1),
+			seg("CRC", "Conditions Indicator", sit, 13),
+			seg("CL1", "Institutional Claim Code", sit, 1),
+			seg("CR1", "Ambulance Transport Information", sit, 1),
+			seg("CR2", "Spinal Manipulation Service Information", sit, 1),
+			seg("CR5", "Home Oxygen Therapy Information", sit, 1),
+			seg("CR6", "Home Health Care Information", sit, 1),
+			seg("PWK", "Additional Patient Information", sit, 10),
+			seg("MSG", "Message Text", inResponse, 1),
+			provider("2010EA", "Patient Event Provider Name", sit, 12, providers...),
+			loop("2010EB", "Additional Patient Information Contact Name", inRequest, 1,
+				seg("NM1", "Additional Patient Information Contact Name", req, 1, "2B", "36", "PR", "X3"),
+				seg("N3", "Additional Patient Information Contact Address", sit, 1),
+				seg("N4", "Additional Patient Information Contact City, State, ZIP Code", sit, 1),
+				seg("PER", "Additional Patient Information Contact Information", sit, 1, "IC"),
+			),
+			loop("2010EC", "Patient Event Transport Information", sit, 5,
+				seg("NM1", "Patient Event Transport Information", req, 1, "45", "FS", "ND", "PW", "R3"),
+				seg("N3", "Patient Event Transport Location Address", req, 1),
+				seg("N4", "Patient Event Transport Location City, State, ZIP Code", req, 1),
+			),
+			service(),
+		)
+	}
+	// member returns the subscriber (C) or dependent (D) level.
+	member := func(level, who, hlCode, nm1Code string, usage schema.Usage) *schema.Loop {
+		return loop("2000"+level, who+" Level", usage, 1,
+			seg("HL", who+" Level", req, 1, hlCode),
+			seg("AAA", who+" Request Validation", inResponse, 9),
+			loop("2010"+level, who+" Name", req, 1,
+				seg("NM1", who+" Name", req, 1, nm1Code),
+				seg("REF", who+" Supplemental Identification", sit, 9, "3H", "6P", "EJ", "HJ", "IG", "SY"),
+				seg("N3", who+" Address", sit, 1),
+				seg("N4", who+" City, State, ZIP Code", sit, 1),
+				seg("AAA", who+" Request Validation", inResponse, 9),
+				seg("DMG", who+" Demographic Information", sit, 1),
+				seg("INS", who+" Relationship", sit, 1),
+			),
+			event(),
+		)
+	}
+	subscriber := member("C", "Subscriber", "22", "IL", req)
+	subscriber.Children = append(subscriber.Children, member("D", "Dependent", "23", "QC", sit))
+
+	return &schema.Loop{Children: []schema.Node{
+		bht,
+		loop("2000A", "Utilization Management Organization (UMO) Level", req, 1,
+			seg("HL", "Utilization Management Organization (UMO) Level", req, 1, "20"),
+			seg("AAA", "Request Validation", inResponse, 9),
+			loop("2010A", "Utilization Management Organization (UMO) Name", req, 1,
+				seg("NM1", "Utilization Management Organization (UMO) Name", req, 1, "FA", "PR", "X3"),
+				seg("PER", "Utilization Management Organization (UMO) Contact Information", inResponse, 3, "IC"),
+				seg("AAA", "Utilization Management Organization (UMO) Request Validation", inResponse, 9),
+			),
+			loop("2000B", "Requester Level", req, 1,
+				seg("HL", "Requester Level", req, 1, "21"),
+				provider("2010B", "Requester Name", req, 1, "1P", "FA"),
+				subscriber,
+			),
+		),
+	}}
+}
@@ -0,0 +1,25 @@
+package hipaa_test
+
+import (
+	"testing"
+
+	"github.com/tmc/x12/hipaa"
+)
+
+func TestX217Fixtures(t *testing.T) {
+	for name, tx := range decodeFixtures(t, "005010x217") {
+		t.Run(name, func(t *testing.T) {
+			ts, other := hipaa.X217Request, hipaa.X217Response
+			if tx.Segments[0].Elements[1].Value == "11" {
+				ts, other = other, ts
+			}
+			for _, err := range schemaErrors(ts, tx) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			root, _ := other.Parse(tx)
+			if len(root.CheckUsage()) == 0 {
+				t.Errorf("%s CheckUsage() found no errors", other.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X218 is the Payroll Deducted and Other Group Premium Payment for
+// Insurance Products (820) implementation guide, 005010X218.
+//
+// The guide's Organization Summary Remittance (2000A) and Individual
+// Remittance (2000B) loops share their trigger, an ENT segment, so a
+// positional parse cannot tell them apart: X218 has one Remittance loop
+// (2000) whose Individual Name loop (2100) is present for individual
+// remittances and absent for organization summaries, and likewise one
+// Remittance Detail loop (2300) and Adjustment loop (2320).
+var X218 = &schema.TransactionSet{
+	ID:      "820",
+	Version: "005010X218",
+	Name:    "Payroll Deducted and Other Group Premium Payment for Insurance Products",
+	Loop:    x218(),
+}
+
+func x218() *schema.Loop {
+	// name returns a premium receiver or payer name loop.
+	name := func(id, name string, codes ...string) *schema.Loop {
+		return loop(id, name, req, 1,
+			seg("N1", name, req, 1, codes...),
+			seg("N2", name+" Additional Name", sit, 1),
+			seg("N3", name+" Address", sit, 1),
+			seg("N4", name+" City, State, ZIP Code", sit, 1),
+			seg("PER", name+" Contact Information", sit, 1, "IC"),
+		)
+	}
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BPR", "Financial Information", req, 1),
+		seg("TRN", "Reassociation Trace Number", req, 1, "1", "3"),
+		seg("CUR", "Foreign Currency Information", sit, 1),
+		seg("REF", "Premium Receiver's Identification Key", sit, 1, "14", "18", "2F", "38", "72"),
+		seg("DTM", "Process Date", sit, 1, "009"),
+		seg("DTM", "Delivery Date", sit, 1, "035"),
+		seg("DTM", "Coverage Period", sit, 1, "582"),
+		name("1000A", "Premium Receiver's Name", "PE"),
+		name("1000B", "Premium Payer's Name", "PR", "RM"),
+		loop("2000", "Remittance", sit, 0,
+			seg("ENT", "Remittance", req, 1),
+			loop("2100", "Individual Name", sit, 1,
+				seg("NM1", "Individual Name", req, 1, "EY", "IL", "QE"),
+				seg("REF", "Individual Identification", sit, 0),
+			),
+			loop("2300", "Remittance Detail", sit, 0,
+				seg("RMR", "Remittance Detail", req, 1),
+				seg("REF", "Remittance Reference", sit, 1),
+				seg("DTM", "Coverage Period", sit, 1, "582"),
+				loop("2320", "Adjustment", sit, 0,
+					seg("ADX", "Adjustment", req, 1),
+				),
+			),
+		),
+	}}
+}
@@ -0,0 +1,125 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X220A1 is the Benefit Enrollment and Maintenance (834) implementation
+// guide, 005010X220A1.
+var X220A1 = &schema.TransactionSet{
+	ID:      "834",
+	Version: "005010X220A1",
+	Name:    "Benefit Enrollment and Maintenance",
+	Loop:    x220a1(),
+}
+
+func x220a1() *schema.Loop {
+	memberDates := []string{"050", "286", "296", "297", "300", "301", "303", "336", "337", "338", "339", "340", "341", "350", "351", "356", "357", "383", "385", "386", "393", "394", "473", "474"}
+	memberRefs := []string{"17", "23", "3H", "4A", "6O", "ABB", "D3", "F6", "P5", "Q4", "QQ", "ZZ"}
+
+	// party returns a name loop of the member level made of an NM1, its
+	// contact, and its address.
+	party := func(id, name string, max int, codes ...string) *schema.Loop {
+		return loop(id, name, sit, max,
+			seg("NM1", name, req, 1, codes...),
+			seg("PER", name+" Communications Numbers", sit, 1, "AP"),
+			seg("N3", name+" Street Address", sit, 1),
+			seg("N4", name+" City, State, ZIP Code", sit, 1),
+		)
+	}
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BGN", "Beginning Segment", req, 1),
+		seg("REF", "Transaction Set Policy Number", sit, 1, "38"),
+		seg("DTP", "File Effective Date", sit, 0, "007", "090", "091", "303", "382", "388"),
+		seg("QTY", "Transaction Set Control Totals", sit, 3, "DT", "ET", "TO"),
+		loop("1000A", "Sponsor Name", req, 1,
+			seg("N1", "Sponsor Name", req, 1, "P5"),
+		),
+		loop("1000B", "Payer", req, 1,
+			seg("N1", "Payer", req, 1, "IN"),
+		),
+		loop("1000C", "TPA/Broker Name", sit, 2,
+			seg("N1", "TPA/Broker Name", req, 1, "BO", "TV"),
+			loop("1100C", "TPA/Broker Account Information", sit, 1,
+				seg("ACT", "TPA/Broker Account Information", req, 1),
+			),
+		),
+		loop("2000", "Member Level Detail", req, 0,
+			seg("INS", "Member Level Detail", req, 1),
+			seg("REF", "Subscriber Identifier", req, 1, "0F"),
+			seg("REF", "Member Policy Number", sit, 1, "1L"),
+			seg("REF", "Member Supplemental Identifier", sit, 13, memberRefs...),
+			seg("DTP", "Member Level Dates", sit, 24, memberDates...),
+			loop("2100A", "Member Name", req, 1,
+				seg("NM1", "Member Name", req, 1, "74", "IL"),
+				seg("PER", "Member Communications Numbers", sit, 1, "IP"),
+				seg("N3", "Member Residence Street Address", sit, 1),
+				seg("N4", "Member City, State, ZIP Code", sit, 1),
+				seg("DMG", "Member Demographics", sit, 1),
+				seg("EC", "Employment Class", sit, 0),
+				seg("ICM", "Member Income", sit, 1),
+				seg("AMT", "Member Policy Amounts", sit, 7, "B9", "C1", "D2", "EBA", "FK", "P3", "R"),
+				seg("HLH", "Member Health Information", sit, 1),
+				seg("LUI", "Member Language", sit, 5),
+			),
+			loop("2100B", "Incorrect Member Name", sit, 1,
+				seg("NM1", "Incorrect Member Name", req, 1, "70"),
+				seg("DMG", "Incorrect Member Demographics", sit, 1),
+			),
+			loop("2100C", "Member Mailing Address", sit, 1,
+				seg("NM1", "Member Mailing Address", req, 1, "31"),
+				seg("N3", "Member Mail Street Address", req, 1),
+				seg("N4", "Member Mail City, State, ZIP Code", req, 1),
+			),
+			party("2100D", "Member Employer", 3, "36"),
+			party("2100E", "Member School", 3, "M8"),
+			party("2100F", "Custodial Parent", 1, "S3"),
+			party("2100G", "Responsible Person", 13, "6Y", "9K", "E1", "EI", "EXS", "GB", "GD", "J6", "LR", "QD", "S1", "TZ", "X4"),
+			loop("2100H", "Drop Off Location", sit, 1,
+				seg("NM1", "Drop Off Location", req, 1, "45"),
+				seg("N3", "Drop Off Location Street Address", sit, 1),
+				seg("N4", "Drop Off Location City, State, ZIP Code", sit, 1),
+			),
+			loop("2200", "Disability Information", sit, 30,
+				seg("DSB", "Disability Information", req, 1),
+				seg("DTP", "Disability Eligibility Dates", sit, 2, "360", "361"),
+			),
+			loop("2300", "Health Coverage", sit, 99,
+				seg("HD", "Health Coverage", req, 1),
+				seg("DTP", "Health Coverage Dates", req, 6, "300", "303", "343", "348", "349", "543", "695"),
+				seg("AMT", "Health Coverage Policy", sit, 9, "B9", "C1", "D2", "EBA", "FK", "P3", "R"),
+				seg("REF", "Health Coverage Policy Number", sit, 14, "17", "1L", "9V", "CE", "E8", "M7", "PID", "RB", "X9", "XM", "XX1", "XX2", "ZX", "ZZ"),
+				seg("REF", "Prior Coverage Months", sit, 1, "QQ"),
+				seg("IDC", "Identification Card", sit, 3),
+				loop("2310", "Provider Information", sit, 30,
+					seg("LX", "Provider Information", req, 1),
+					seg("NM1", "Provider Name", req, 1, "1X", "3D", "80", "FA", "OD", "P3", "QA", "QN", "Y2"),
+					seg("N3", "Provider Address", sit, 2),
+					seg("N4", "Provider City, State, ZIP Code", sit, 1),
+					seg("PER", "Provider Communications Numbers", sit, 2, "IC"),
+					seg("PLA", "Provider Change Reason", sit, 1),
+				),
+				loop("2320", "Coordination of Benefits", sit, 5,
+					seg("COB", "Coordination of Benefits", req, 1),
+					seg("REF", "Additional Coordination of Benefits Identifiers", sit, 4, "60", "6P", "SY", "ZZ"),
+					seg("DTP", "Coordination of Benefits Eligibility Dates", sit, 2, "344", "345"),
+					loop("2330", "Coordination of Benefits Related Entity", sit, 3,
+						seg("NM1", "Coordination of Benefits Related Entity", req, 1, "36", "GW", "IN"),
+						seg("N3", "Coordination of Benefits Related Entity Address", sit, 1),
+						seg("N4", "Coordination of Benefits Other Insurance Company City, State, ZIP Code", sit, 1),
+						seg("PER", "Administrative Communications Contact", sit, 1, "CN"),
+					),
+				),
+			),
+			seg("LS", "Additional Reporting Categories", sit, 1, "2700"),
+			loop("2700", "Member Reporting Categories", sit, 0,
+				seg("LX", "Member Reporting Categories", req, 1),
+				loop("2750", "Reporting Category", sit, 1,
+					seg("N1", "Reporting Category", req, 1, "75"),
+					seg("REF", "Reporting Category Reference", req, 1),
+					seg("DTP", "Reporting Category Date", sit, 1, "007"),
+				),
+			),
+			seg("LE", "Additional Reporting Categories Loop Termination", sit, 1, "2700"),
+		),
+	}}
+}
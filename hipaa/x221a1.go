@@ -0,0 +1,87 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X221A1 is the Health Care Claim Payment/Advice (835) implementation
+// guide, 005010X221A1.
+var X221A1 = &schema.TransactionSet{
+	ID:      "835",
+	Version: "005010X221A1",
+	Name:    "Health Care Claim Payment/Advice",
+	Loop:    x221a1(),
+}
+
+func x221a1() *schema.Loop {
+	otherClaimIDs := []string{"1L", "1W", "28", "6P", "9A", "9C", "BB", "CE", "EA", "F8", "G1", "G3", "IG", "SY"}
+	providerIDs := []string{"0B", "1A", "1B", "1C", "1D", "1G", "1H", "1J", "D3", "G2", "LU"}
+	supplementalAmounts := []string{"AU", "D8", "DY", "F5", "I", "NL", "T", "T2", "ZK", "ZL", "ZM", "ZN", "ZO"}
+	supplementalQuantities := []string{"CA", "CD", "LA", "LE", "NE", "NR", "OU", "PS", "VS", "ZK", "ZL", "ZM", "ZN", "ZO"}
+
+	servicePayment := loop("2110", "Service Payment Information", sit, 999,
+		seg("SVC", "Service Payment Information", req, 1),
+		seg("DTM", "Service Date", sit, 2, "150", "151", "472"),
+		seg("CAS", "Service Adjustment", sit, 99),
+		seg("REF", "Service Identification", sit, 8, "1S", "APC", "BB", "E9", "G1", "G3", "LU", "RB"),
+		seg("REF", "Line Item Control Number", sit, 1, "6R"),
+		seg("REF", "Rendering Provider Information", sit, 10, append(providerIDs, "HPI", "SY", "TJ")...),
+		seg("REF", "HealthCare Policy Identification", sit, 5, "0K"),
+		seg("AMT", "Service Supplemental Amount", sit, 9, "B6", "KH", "T", "T2", "ZK", "ZL", "ZM", "ZN", "ZO"),
+		seg("QTY", "Service Supplemental Quantity", sit, 6, "ZK", "ZL", "ZM", "ZN", "ZO"),
+		seg("LQ", "Health Care Remark Codes", sit, 99, "HE", "RX"),
+	)
+
+	claimPayment := loop("2100", "Claim Payment Information", req, 0,
+		seg("CLP", "Claim Payment Information", req, 1),
+		seg("CAS", "Claim Adjustment", sit, 99),
+		seg("NM1", "Patient Name", req, 1, "QC"),
+		seg("NM1", "Insured Name", sit, 1, "IL"),
+		seg("NM1", "Corrected Patient/Insured Name", sit, 1, "74"),
+		seg("NM1", "Service Provider Name", sit, 1, "82"),
+		seg("NM1", "Crossover Carrier Name", sit, 1, "TT"),
+		seg("NM1", "Corrected Priority Payer Name", sit, 1, "PR"),
+		seg("NM1", "Other Subscriber Name", sit, 1, "GB"),
+		seg("MIA", "Inpatient Adjudication Information", sit, 1),
+		seg("MOA", "Outpatient Adjudication Information", sit, 1),
+		seg("REF", "Other Claim Related Identification", sit, 5, otherClaimIDs...),
+		seg("REF", "Rendering Provider Identification", sit, 10, providerIDs...),
+		seg("DTM", "Statement From or To Date", sit, 2, "232", "233"),
+		seg("DTM", "Coverage Expiration Date", sit, 1, "036"),
+		seg("DTM", "Claim Received Date", sit, 1, "050"),
+		seg("PER", "Claim Contact Information", sit, 2, "CX"),
+		seg("AMT", "Claim Supplemental Information", sit, 13, supplementalAmounts...),
+		seg("QTY", "Claim Supplemental Information Quantity", sit, 14, supplementalQuantities...),
+		servicePayment,
+	)
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BPR", "Financial Information", req, 1),
+		seg("TRN", "Reassociation Trace Number", req, 1, "1"),
+		seg("CUR", "Foreign Currency Information", sit, 1),
+		seg("REF", "Receiver Identification", sit, 1, "EV"),
+		seg("REF", "Version Identification", sit, 1, "F2"),
+		seg("DTM", "Production Date", sit, 1, "405"),
+		loop("1000A", "Payer Identification", req, 1,
+			seg("N1", "Payer Identification", req, 1, "PR"),
+			seg("N3", "Payer Address", req, 1),
+			seg("N4", "Payer City, State, ZIP Code", req, 1),
+			seg("REF", "Additional Payer Identification", sit, 4, "2U", "EO", "HI", "NF"),
+			seg("PER", "Payer Business Contact Information", sit, 1, "CX"),
+			seg("PER", "Payer Technical Contact Information", req, 0, "BL"),
+			seg("PER", "Payer WEB Site", sit, 1, "IC"),
+		),
+		loop("1000B", "Payee Identification", req, 1,
+			seg("N1", "Payee Identification", req, 1, "PE"),
+			seg("N3", "Payee Address", sit, 1),
+			seg("N4", "Payee City, State, ZIP Code", sit, 1),
+			seg("REF", "Payee Additional Identification", sit, 0, "0B", "D3", "PQ", "TJ"),
+			seg("RDM", "Remittance Delivery Method", sit, 1),
+		),
+		loop("2000", "Header Number", sit, 0,
+			seg("LX", "Header Number", req, 1),
+			seg("TS3", "Provider Summary Information", sit, 1),
+			seg("TS2", "Provider Supplemental Summary Information", sit, 1),
+			claimPayment,
+		),
+		seg("PLB", "Provider Adjustment", sit, 0),
+	}}
+}
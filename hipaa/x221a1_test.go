@@ -0,0 +1,41 @@
+package hipaa_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+)
+
+// misplaced lists the positions of the segments each fixture has out of
+// place. Example 8a sends a taxpayer ID (REF*TJ) in loop 2100, which the
+// guide allows only for the payee and the rendering provider of a
+// service.
+var misplaced = map[string]int{
+	"005010x221-example-8a-claim-submitted-incorrect-subscriber-patient-and-incorrect-id.edi": 24,
+}
+
+func TestX221A1Fixtures(t *testing.T) {
+	for name, tx := range decodeFixtures(t, "005010x221") {
+		t.Run(name, func(t *testing.T) {
+			for _, err := range schemaErrors(hipaa.X221A1, tx) {
+				var e *schema.Error
+				if errors.As(err, &e) && errors.Is(err, schema.ErrUnexpectedSegment) && e.Position == misplaced[name] {
+					continue
+				}
+				t.Errorf("unexpected error: %v", err)
+			}
+			root, _ := hipaa.X221A1.Parse(tx)
+			services := 0
+			root.Walk(func(n *schema.LoopNode) {
+				if n.ID() == "2110" && n.Parent.ID() == "2100" {
+					services++
+				}
+			})
+			if services == 0 {
+				t.Error("no 2110 service payments found in 2100 claim payments")
+			}
+		})
+	}
+}
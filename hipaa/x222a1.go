@@ -0,0 +1,356 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// Condition names registered for 005010X222A1 rules.
+const (
+	// CondPatientIsSubscriber holds when the enclosing subscriber loop
+	// (2000B) reports the subscriber as the patient (SBR02 is "18").
+	CondPatientIsSubscriber = "005010X222A1.patient-is-subscriber"
+	// CondPatientNotSubscriber holds when the enclosing subscriber loop
+	// (2000B) does not report the subscriber as the patient.
+	CondPatientNotSubscriber = "005010X222A1.patient-not-subscriber"
+)
+
+func init() {
+	schema.RegisterCondition(CondPatientIsSubscriber, func(ctx *schema.Context) bool {
+		return subscriberIsPatient(ctx)
+	})
+	schema.RegisterCondition(CondPatientNotSubscriber, func(ctx *schema.Context) bool {
+		return !subscriberIsPatient(ctx)
+	})
+}
+
+// subscriberIsPatient reports whether the subscriber loop (2000B)
+// enclosing ctx identifies the subscriber as the patient.
+func subscriberIsPatient(ctx *schema.Context) bool {
+	sub := ctx.Loop.Ancestor("2000B")
+	return sub != nil && sub.Value("SBR02") == "18"
+}
+
+// X222A1 is the Health Care Claim: Professional (837) implementation
+// guide, 005010X222A1.
+var X222A1 = &schema.TransactionSet{
+	ID:      "837",
+	Version: "005010X222A1",
+	Name:    "Health Care Claim: Professional",
+	Loop:    x222a1(),
+}
+
+func x222a1() *schema.Loop {
+	patientIsSubscriber := &schema.Rule{
+		Text:      "Required when the patient is the subscriber.",
+		Condition: CondPatientIsSubscriber,
+	}
+
+	claim := loop("2300", "Claim Information", sit, 100,
+		seg("CLM", "Claim Information", req, 1),
+		seg("DTP", "Date - Onset of Current Illness or Symptom", sit, 1, "431"),
+		seg("DTP", "Date - Initial Treatment Date", sit, 1, "454"),
+		seg("DTP", "Date - Last Seen Date", sit, 1, "304"),
+		seg("DTP", "Date - Acute Manifestation", sit, 1, "453"),
+		seg("DTP", "Date - Accident", sit, 1, "439"),
+		seg("DTP", "Date - Last Menstrual Period", sit, 1, "484"),
+		seg("DTP", "Date - Last X-ray Date", sit, 1, "455"),
+		seg("DTP", "Date - Hearing and Vision Prescription Date", sit, 1, "471"),
+		seg("DTP", "Date - Disability Dates", sit, 1, "314", "360", "361"),
+		seg("DTP", "Date - Last Worked", sit, 1, "297"),
+		seg("DTP", "Date - Authorized Return to Work", sit, 1, "296"),
+		seg("DTP", "Date - Admission", sit, 1, "435"),
+		seg("DTP", "Date - Discharge", sit, 1, "096"),
+		seg("DTP", "Date - Assumed and Relinquished Care Dates", sit, 2, "090", "091"),
+		seg("DTP", "Date - Property and Casualty Date of First Contact", sit, 1, "444"),
+		seg("DTP", "Date - Repricer Received Date", sit, 1, "050"),
+		seg("PWK", "Claim Supplemental Information", sit, 10),
+		seg("CN1", "Contract Information", sit, 1),
+		seg("AMT", "Patient Amount Paid", sit, 1, "F5"),
+		seg("REF", "Service Authorization Exception Code", sit, 1, "4N"),
+		seg("REF", "Mandatory Medicare (Section 4081) Crossover Indicator", sit, 1, "F5"),
+		seg("REF", "Mammography Certification Number", sit, 1, "EW"),
+		seg("REF", "Referral Number", sit, 1, "9F"),
+		seg("REF", "Prior Authorization", sit, 1, "G1"),
+		seg("REF", "Payer Claim Control Number", sit, 1, "F8"),
+		seg("REF", "Clinical Laboratory Improvement Amendment (CLIA) Number", sit, 1, "X4"),
+		seg("REF", "Repriced Claim Number", sit, 1, "9A"),
+		seg("REF", "Adjusted Repriced Claim Number", sit, 1, "9C"),
+		seg("REF", "Investigational Device Exemption Number", sit, 1, "LX"),
+		seg("REF", "Claim Identifier For Transmission Intermediaries", sit, 1, "D9"),
+		seg("REF", "Medical Record Number", sit, 1, "EA"),
+		seg("REF", "Demonstration Project Identifier", sit, 1, "P4"),
+		seg("REF", "Care Plan Oversight", sit, 1, "1J"),
+		seg("K3", "File Information", sit, 10),
+		seg("NTE", "Claim Note", sit, 1),
+		seg("CR1", "Ambulance Transport Information", sit, 1),
+		seg("CR2", "Spinal Manipulation Service Information", sit, 1),
+		seg("CRC", "Ambulance Certification", sit, 3, "07"),
+		seg("CRC", "Patient Condition Information: Vision", sit, 3, "E1", "E2", "E3"),
+		seg("CRC", "Homebound Indicator", sit, 1, "75"),
+		seg("CRC", "EPSDT Referral", sit, 1, "ZZ"),
+		seg("HI", "Health Care Diagnosis Code", req, 1, "ABK", "BK"),
+		seg("HI", "Anesthesia Related Procedure", sit, 1, "BP"),
+		seg("HI", "Condition Information", sit, 2, "BG"),
+		seg("HCP", "Claim Pricing/Repricing Information", sit, 1),
+		loop("2310A", "Referring Provider Name", sit, 2,
+			seg("NM1", "Referring Provider Name", req, 1, "DN", "P3"),
+			seg("REF", "Referring Provider Secondary Identification", sit, 3, "0B", "1G", "G2"),
+		),
+		loopWithRule(loop("2310B", "Rendering Provider Name", sit, 1,
+			seg("NM1", "Rendering Provider Name", req, 1, "82"),
+			seg("PRV", "Rendering Provider Specialty Information", sit, 1, "PE"),
+			seg("REF", "Rendering Provider Secondary Identification", sit, 4, "0B", "1G", "G2", "LU"),
+		), &schema.Rule{Text: "Required when the Rendering Provider NM1 information is different than that carried in the Billing Provider NM1 loop."}),
+		loop("2310C", "Service Facility Location Name", sit, 1,
+			seg("NM1", "Service Facility Location Name", req, 1, "77"),
+			seg("N3", "Service Facility Location Address", req, 1),
+			seg("N4", "Service Facility Location City, State, ZIP Code", req, 1),
+			seg("REF", "Service Facility Location Secondary Identification", sit, 3, "G2", "LU"),
+			seg("PER", "Service Facility Contact Information", sit, 1, "IC"),
+		),
+		loop("2310D", "Supervising Provider Name", sit, 1,
+			seg("NM1", "Supervising Provider Name", req, 1, "DQ"),
+			seg("REF", "Supervising Provider Secondary Identification", sit, 4, "0B", "1G", "G2", "LU"),
+		),
+		loop("2310E", "Ambulance Pick-up Location", sit, 1,
+			seg("NM1", "Ambulance Pick-up Location", req, 1, "PW"),
+			seg("N3", "Ambulance Pick-up Location Address", req, 1),
+			seg("N4", "Ambulance Pick-up Location City, State, ZIP Code", req, 1),
+		),
+		loop("2310F", "Ambulance Drop-off Location", sit, 1,
+			seg("NM1", "Ambulance Drop-off Location", req, 1, "45"),
+			seg("N3", "Ambulance Drop-off Location Address", req, 1),
+			seg("N4", "Ambulance Drop-off Location City, State, ZIP Code", req, 1),
+		),
+		loop("2320", "Other Subscriber Information", sit, 10,
+			seg("SBR", "Other Subscriber Information", req, 1),
+			seg("CAS", "Claim Level Adjustments", sit, 5),
+			seg("AMT", "Coordination of Benefits (COB) Payer Paid Amount", sit, 1, "D"),
+			seg("AMT", "Remaining Patient Liability", sit, 1, "EAF"),
+			seg("AMT", "Coordination of Benefits (COB) Total Non-Covered Amount", sit, 1, "A8"),
+			seg("OI", "Other Insurance Coverage Information", req, 1),
+			seg("MOA", "Outpatient Adjudication Information", sit, 1),
+			loop("2330A", "Other Subscriber Name", req, 1,
+				seg("NM1", "Other Subscriber Name", req, 1, "IL"),
+				seg("N3", "Other Subscriber Address", sit, 1),
+				seg("N4", "Other Subscriber City, State, ZIP Code", sit, 1),
+				seg("REF", "Other Subscriber Secondary Identification", sit, 1, "SY"),
+			),
+			loop("2330B", "Other Payer Name", req, 1,
+				seg("NM1", "Other Payer Name", req, 1, "PR"),
+				seg("N3", "Other Payer Address", sit, 1),
+				seg("N4", "Other Payer City, State, ZIP Code", sit, 1),
+				seg("DTP", "Claim Check or Remittance Date", sit, 1, "573"),
+				seg("REF", "Other Payer Secondary Identifier", sit, 2, "2U", "EI", "FY", "NF"),
+				seg("REF", "Other Payer Prior Authorization Number", sit, 1, "G1"),
+				seg("REF", "Other Payer Referral Number", sit, 1, "9F"),
+				seg("REF", "Other Payer Claim Adjustment Indicator", sit, 1, "T4"),
+				seg("REF", "Other Payer Claim Control Number", sit, 1, "F8"),
+			),
+			loop("2330C", "Other Payer Referring Provider", sit, 2,
+				seg("NM1", "Other Payer Referring Provider", req, 1, "DN", "P3"),
+				seg("REF", "Other Payer Referring Provider Secondary Identification", req, 3, "0B", "1G", "G2"),
+			),
+			loop("2330D", "Other Payer Rendering Provider", sit, 1,
+				seg("NM1", "Other Payer Rendering Provider", req, 1, "82"),
+				seg("REF", "Other Payer Rendering Provider Secondary Identification", req, 3, "0B", "1G", "G2", "LU"),
+			),
+			loop("2330E", "Other Payer Service Facility Location", sit, 1,
+				seg("NM1", "Other Payer Service Facility Location", req, 1, "77"),
+				seg("REF", "Other Payer Service Facility Location Secondary Identification", req, 3, "0B", "G2", "LU"),
+			),
+			loop("2330F", "Other Payer Supervising Provider", sit, 1,
+				seg("NM1", "Other Payer Supervising Provider", req, 1, "DQ"),
+				seg("REF", "Other Payer Supervising Provider Secondary Identification", req, 3, "0B", "1G", "G2", "LU"),
+			),
+			loop("2330G", "Other Payer Billing Provider", sit, 1,
+				seg("NM1", "Other Payer Billing Provider", req, 1, "85"),
+				seg("REF", "Other Payer Billing Provider Secondary Identification", req, 2, "G2", "LU"),
+			),
+		),
+		loop("2400", "Service Line Number", req, 50,
+			seg("LX", "Service Line Number", req, 1),
+			seg("SV1", "Professional Service", req, 1),
+			seg("SV5", "Durable Medical Equipment Service", sit, 1),
+			seg("PWK", "Line Supplemental Information", sit, 10),
+			seg("PWK", "Durable Medical Equipment Certificate of Medical Necessity Indicator", sit, 1, "CT"),
+			seg("CR1", "Ambulance Transport Information", sit, 1),
+			seg("CR3", "Durable Medical Equipment Certification", sit, 1),
+			seg("CRC", "Ambulance Certification", sit, 3, "07"),
+			seg("CRC", "Hospice Employee Indicator", sit, 1, "70"),
+			seg("CRC", "Condition Indicator/Durable Medical Equipment", sit, 1, "09"),
+			seg("DTP", "Date - Service Date", req, 1, "472"),
+			seg("DTP", "Date - Prescription Date", sit, 1, "471"),
+			seg("DTP", "Date - Certification Revision/Recertification Date", sit, 1, "607"),
+			seg("DTP", "Date - Begin Therapy Date", sit, 1, "463"),
+			seg("DTP", "Date - Last Certification Date", sit, 1, "461"),
+			seg("DTP", "Date - Last Seen Date", sit, 1, "304"),
+			seg("DTP", "Date - Test Date", sit, 2, "738", "739"),
+			seg("DTP", "Date - Shipped Date", sit, 1, "011"),
+			seg("DTP", "Date - Last X-ray Date", sit, 1, "455"),
+			seg("DTP", "Date - Initial Treatment Date", sit, 1, "454"),
+			seg("QTY", "Ambulance Patient Count", sit, 1, "PT"),
+			seg("QTY", "Obstetric Anesthesia Additional Units", sit, 1, "FL"),
+			seg("MEA", "Test Result", sit, 5),
+			seg("CN1", "Contract Information", sit, 1),
+			seg("REF", "Repriced Line Item Reference Number", sit, 1, "9B"),
+			seg("REF", "Adjusted Repriced Line Item Reference Number", sit, 1, "9D"),
+			seg("REF", "Prior Authorization", sit, 5, "G1"),
+			seg("REF", "Line Item Control Number", sit, 1, "6R"),
+			seg("REF", "Mammography Certification Number", sit, 1, "EW"),
+			seg("REF", "Clinical Laboratory Improvement Amendment (CLIA) Number", sit, 1, "X4"),
+			seg("REF", "Referring Clinical Laboratory Improvement Amendment (CLIA) Facility Identification", sit, 1, "F4"),
+			seg("REF", "Immunization Batch Number", sit, 1, "BT"),
+			seg("REF", "Referral Number", sit, 5, "9F"),
+			seg("AMT", "Sales Tax Amount", sit, 1, "T"),
+			seg("AMT", "Postage Claimed Amount", sit, 1, "F4"),
+			seg("K3", "File Information", sit, 10),
+			seg("NTE", "Line Note", sit, 1, "ADD", "DCP"),
+			seg("NTE", "Third Party Organization Notes", sit, 1, "TPO"),
+			seg("PS1", "Purchased Service Information", sit, 1),
+			seg("HCP", "Line Pricing/Repricing Information", sit, 1),
+			loop("2410", "Drug Identification", sit, 1,
+				seg("LIN", "Drug Identification", req, 1),
+				seg("CTP", "Drug Quantity", req, 1),
+				seg("REF", "Prescription or Compound Drug Association Number", sit, 1, "VY", "XZ"),
+			),
+			loop("2420A", "Rendering Provider Name", sit, 1,
+				seg("NM1", "Rendering Provider Name", req, 1, "82"),
+				seg("PRV", "Rendering Provider Specialty Information", sit, 1, "PE"),
+				seg("REF", "Rendering Provider Secondary Identification", sit, 20, "0B", "1G", "G2", "LU"),
+			),
+			loop("2420B", "Purchased Service Provider Name", sit, 1,
+				seg("NM1", "Purchased Service Provider Name", req, 1, "QB"),
+				seg("REF", "Purchased Service Provider Secondary Identification", sit, 20, "0B", "1G", "G2"),
+			),
+			loop("2420C", "Service Facility Location Name", sit, 1,
+				seg("NM1", "Service Facility Location Name", req, 1, "77"),
+				seg("N3", "Service Facility Location Address", req, 1),
+				seg("N4", "Service Facility Location City, State, ZIP Code", req, 1),
+				seg("REF", "Service Facility Location Secondary Identification", sit, 3, "G2", "LU"),
+			),
+			loop("2420D", "Supervising Provider Name", sit, 1,
+				seg("NM1", "Supervising Provider Name", req, 1, "DQ"),
+				seg("REF", "Supervising Provider Secondary Identification", sit, 20, "0B", "1G", "G2", "LU"),
+			),
+			loop("2420E", "Ordering Provider Name", sit, 1,
+				seg("NM1", "Ordering Provider Name", req, 1, "DK"),
+				seg("N3", "Ordering Provider Address", sit, 1),
+				seg("N4", "Ordering Provider City, State, ZIP Code", sit, 1),
+				seg("REF", "Ordering Provider Secondary Identification", sit, 20, "0B", "1G", "G2"),
+				seg("PER", "Ordering Provider Contact Information", sit, 1, "IC"),
+			),
+			loop("2420F", "Referring Provider Name", sit, 2,
+				seg("NM1", "Referring Provider Name", req, 1, "DN", "P3"),
+				seg("REF", "Referring Provider Secondary Identification", sit, 20, "0B", "1G", "G2"),
+			),
+			loop("2420G", "Ambulance Pick-up Location", sit, 1,
+				seg("NM1", "Ambulance Pick-up Location", req, 1, "PW"),
+				seg("N3", "Ambulance Pick-up Location Address", req, 1),
+				seg("N4", "Ambulance Pick-up Location City, State, ZIP Code", req, 1),
+			),
+			loop("2420H", "Ambulance Drop-off Location", sit, 1,
+				seg("NM1", "Ambulance Drop-off Location", req, 1, "45"),
+				seg("N3", "Ambulance Drop-off Location Address", req, 1),
+				seg("N4", "Ambulance Drop-off Location City, State, ZIP Code", req, 1),
+			),
+			loop("2430", "Line Adjudication Information", sit, 15,
+				seg("SVD", "Line Adjudication Information", req, 1),
+				seg("CAS", "Line Adjustment", sit, 5),
+				seg("DTP", "Line Check or Remittance Date", req, 1, "573"),
+				seg("AMT", "Remaining Patient Liability", sit, 1, "EAF"),
+			),
+			loop("2440", "Form Identification Code", sit, 0,
+				seg("LQ", "Form Identification Code", req, 1),
+				seg("FRM", "Supporting Documentation", req, 99),
+			),
+		),
+	)
+
+	patient := loopWithRule(loop("2000C", "Patient Hierarchical Level", sit, 0,
+		seg("HL", "Patient Hierarchical Level", req, 1, "23"),
+		seg("PAT", "Patient Information", req, 1),
+		loop("2010CA", "Patient Name", req, 1,
+			seg("NM1", "Patient Name", req, 1, "QC"),
+			seg("N3", "Patient Address", req, 1),
+			seg("N4", "Patient City, State, ZIP Code", req, 1),
+			seg("DMG", "Patient Demographic Information", req, 1),
+			seg("REF", "Property and Casualty Claim Number", sit, 1, "Y4"),
+			seg("REF", "Property and Casualty Patient Identifier", sit, 1, "1W", "SY"),
+			seg("PER", "Property and Casualty Patient Contact Information", sit, 1, "IC"),
+		),
+		claim,
+	), &schema.Rule{
+		Text:      "Required when the patient is a different person than the subscriber. If not required by this implementation guide, do not send.",
+		Condition: CondPatientNotSubscriber,
+		Exclusive: true,
+	})
+
+	subscriber := loop("2000B", "Subscriber Hierarchical Level", req, 0,
+		seg("HL", "Subscriber Hierarchical Level", req, 1, "22"),
+		seg("SBR", "Subscriber Information", req, 1),
+		withRule(seg("PAT", "Patient Information", sit, 1), &schema.Rule{
+			Text:      "Required when the patient is the subscriber and the subscriber is known to be deceased, or when reporting the patient's weight for a pregnancy. If not required by this implementation guide, do not send.",
+			Exclusive: true,
+		}),
+		loop("2010BA", "Subscriber Name", req, 1,
+			seg("NM1", "Subscriber Name", req, 1, "IL"),
+			withRule(seg("N3", "Subscriber Address", sit, 1), patientIsSubscriber),
+			withRule(seg("N4", "Subscriber City, State, ZIP Code", sit, 1), patientIsSubscriber),
+			withRule(seg("DMG", "Subscriber Demographic Information", sit, 1), patientIsSubscriber),
+			seg("REF", "Subscriber Secondary Identification", sit, 1, "SY"),
+			seg("REF", "Property and Casualty Claim Number", sit, 1, "Y4"),
+			seg("PER", "Property and Casualty Subscriber Contact Information", sit, 1, "IC"),
+		),
+		loop("2010BB", "Payer Name", req, 1,
+			seg("NM1", "Payer Name", req, 1, "PR"),
+			seg("N3", "Payer Address", sit, 1),
+			seg("N4", "Payer City, State, ZIP Code", sit, 1),
+			seg("REF", "Payer Secondary Identification", sit, 0, "2U", "EI", "FY", "NF"),
+			seg("REF", "Billing Provider Secondary Identification", sit, 2, "G2", "LU"),
+		),
+		claim,
+		patient,
+	)
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BHT", "Beginning of Hierarchical Transaction", req, 1),
+		loop("1000A", "Submitter Name", req, 1,
+			seg("NM1", "Submitter Name", req, 1, "41"),
+			seg("PER", "Submitter EDI Contact Information", req, 2, "IC"),
+		),
+		loop("1000B", "Receiver Name", req, 1,
+			seg("NM1", "Receiver Name", req, 1, "40"),
+		),
+		loop("2000A", "Billing Provider Hierarchical Level", req, 0,
+			seg("HL", "Billing Provider Hierarchical Level", req, 1, "20"),
+			seg("PRV", "Billing Provider Specialty Information", sit, 1, "BI"),
+			seg("CUR", "Foreign Currency Information", sit, 1, "85"),
+			loop("2010AA", "Billing Provider Name", req, 1,
+				seg("NM1", "Billing Provider Name", req, 1, "85"),
+				seg("N3", "Billing Provider Address", req, 1),
+				seg("N4", "Billing Provider City, State, ZIP Code", req, 1),
+				seg("REF", "Billing Provider Tax Identification", req, 1, "EI", "SY"),
+				seg("REF", "Billing Provider UPIN/License Information", sit, 2, "0B", "1G"),
+				seg("PER", "Billing Provider Contact Information", sit, 2, "IC"),
+			),
+			loopWithRule(loop("2010AB", "Pay-to Address Name", sit, 1,
+				seg("NM1", "Pay-to Address Name", req, 1, "87"),
+				seg("N3", "Pay-to Address - ADDRESS", req, 1),
+				seg("N4", "Pay-To Address City, State, ZIP Code", req, 1),
+			), &schema.Rule{
+				Text:      "Required when the address for payment is different than that of the Billing Provider. If not required by this implementation guide, do not send.",
+				Exclusive: true,
+			}),
+			loopWithRule(loop("2010AC", "Pay-To Plan Name", sit, 1,
+				seg("NM1", "Pay-To Plan Name", req, 1, "PE"),
+				seg("N3", "Pay-to Plan Address", req, 1),
+				seg("N4", "Pay-To Plan City, State, ZIP Code", req, 1),
+				seg("REF", "Pay-to Plan Secondary Identification", sit, 1, "2U", "FY", "NF"),
+				seg("REF", "Pay-To Plan Tax Identification Number", req, 1, "EI"),
+			), &schema.Rule{
+				Text:      "Required when willing trading partners agree to use this implementation for their subrogation payment requests. If not required by this implementation guide, do not send.",
+				Exclusive: true,
+			}),
+			subscriber,
+		),
+	}}
+}
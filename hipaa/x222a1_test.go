@@ -0,0 +1,101 @@
+package hipaa_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/schema"
+)
+
+func TestX222A1Fixtures(t *testing.T) {
+	for name, tx := range decodeFixtures(t, "005010x222") {
+		t.Run(name, func(t *testing.T) {
+			for _, err := range schemaErrors(hipaa.X222A1, tx) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			root, _ := hipaa.X222A1.Parse(tx)
+			claims := 0
+			root.Walk(func(n *schema.LoopNode) {
+				if n.ID() == "2300" {
+					claims++
+				}
+			})
+			if claims == 0 {
+				t.Error("no 2300 claim loops found")
+			}
+		})
+	}
+}
+
+const patientClaim = `ST*837*0021*005010X222A1~
+BHT*0019*00*0123*20051015*1023*CH~
+NM1*41*2*PREMIER BILLING SERVICE*****46*TGJ23~
+PER*IC*JERRY*TE*3055552222~
+NM1*40*2*XYZ REPRICER*****46*66783JJT~
+HL*1**20*1~
+NM1*85*1*KILDARE*BEN****XX*1999996666~
+N3*234 SEAWAY ST~
+N4*MIAMI*FL*33111~
+REF*EI*123456789~
+HL*2*1*22*1~
+SBR*P*18*******CI~
+NM1*IL*1*SMITH*JANE****MI*111223333~
+N3*236 N MAIN ST~
+N4*MIAMI*FL*33413~
+DMG*D8*19430501*F~
+NM1*PR*2*KEY INSURANCE COMPANY*****PI*999996666~
+HL*3*2*23*0~
+PAT*19~
+NM1*QC*1*SMITH*TED~
+N3*236 N MAIN ST~
+N4*MIAMI*FL*33413~
+DMG*D8*19730501*M~
+CLM*26407789*79.04***11:B:1*Y*A*Y*I~
+HI*BK:4779~
+LX*1~
+SV1*HC:99213*79.04*UN*1***1~
+DTP*472*D8*20051003~
+SE*29*0021~`
+
+func TestX222A1PatientRules(t *testing.T) {
+	// SBR02 says the subscriber is the patient, yet a patient loop
+	// follows.
+	root, errs := hipaa.X222A1.Parse(hipaatest.Decode(t, patientClaim))
+	if len(errs) > 0 {
+		t.Fatalf("Parse() = %v", errs)
+	}
+	errs = root.CheckRules()
+	if len(errs) != 1 {
+		t.Fatalf("CheckRules() = %v, want one error", errs)
+	}
+	var serr *schema.Error
+	if !errors.As(errs[0], &serr) || serr.LoopID != "2000C" || serr.Position != 18 || !errors.Is(serr, schema.ErrNotUsed) {
+		t.Errorf("CheckRules() = %v, want 2000C not used at segment 18", errs[0])
+	}
+	if serr.Rule == nil || serr.Rule.Condition != hipaa.CondPatientNotSubscriber {
+		t.Errorf("Rule = %+v, want %s", serr.Rule, hipaa.CondPatientNotSubscriber)
+	}
+
+	// Clearing SBR02 makes the patient loop required and the
+	// subscriber's address and demographics optional.
+	root, _ = hipaa.X222A1.Parse(hipaatest.Decode(t, strings.Replace(patientClaim, "SBR*P*18*", "SBR*P**", 1)))
+	if errs := root.CheckRules(); len(errs) != 0 {
+		t.Errorf("CheckRules() = %v, want none", errs)
+	}
+
+	// With SBR02 cleared and the patient loop removed, the patient
+	// loop is missing.
+	noPatient := strings.Replace(patientClaim, "SBR*P*18*", "SBR*P**", 1)
+	noPatient = strings.Replace(noPatient, "HL*3*2*23*0~\nPAT*19~\nNM1*QC*1*SMITH*TED~\nN3*236 N MAIN ST~\nN4*MIAMI*FL*33413~\nDMG*D8*19730501*M~\n", "", 1)
+	root, errs = hipaa.X222A1.Parse(hipaatest.Decode(t, noPatient))
+	if len(errs) > 0 {
+		t.Fatalf("Parse() = %v", errs)
+	}
+	errs = root.CheckRules()
+	if len(errs) != 1 || !errors.Is(errs[0], schema.ErrMissingSegment) || !errors.As(errs[0], &serr) || serr.LoopID != "2000C" || serr.SegmentID != "HL" {
+		t.Errorf("CheckRules() = %v, want missing 2000C", errs)
+	}
+}
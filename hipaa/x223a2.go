@@ -0,0 +1,250 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// Condition names registered for 005010X223A2 rules.
+const (
+	// CondInstitutionalPatientIsSubscriber holds when the enclosing
+	// subscriber loop (2000B) reports the subscriber as the patient
+	// (SBR02 is "18").
+	CondInstitutionalPatientIsSubscriber = "005010X223A2.patient-is-subscriber"
+	// CondInstitutionalPatientNotSubscriber holds when the enclosing
+	// subscriber loop (2000B) does not report the subscriber as the
+	// patient.
+	CondInstitutionalPatientNotSubscriber = "005010X223A2.patient-not-subscriber"
+)
+
+func init() {
+	schema.RegisterCondition(CondInstitutionalPatientIsSubscriber, func(ctx *schema.Context) bool {
+		return subscriberIsPatient(ctx)
+	})
+	schema.RegisterCondition(CondInstitutionalPatientNotSubscriber, func(ctx *schema.Context) bool {
+		return !subscriberIsPatient(ctx)
+	})
+}
+
+// X223A2 is the Health Care Claim: Institutional (837) implementation
+// guide, 005010X223A2.
+var X223A2 = &schema.TransactionSet{
+	ID:      "837",
+	Version: "005010X223A2",
+	Name:    "Health Care Claim: Institutional",
+	Loop:    x223a2(),
+}
+
+func x223a2() *schema.Loop {
+	patientIsSubscriber := &schema.Rule{
+		Text:      "Required when the patient is the subscriber.",
+		Condition: CondInstitutionalPatientIsSubscriber,
+	}
+
+	// Loops 2310A-F and 2330C-I name the claim's providers; loops
+	// 2420A-D name a line's.
+	provider := func(id, name string, usage schema.Usage, codes ...string) *schema.Loop {
+		return loop(id, name, usage, 1,
+			seg("NM1", name, req, 1, codes...),
+			seg("REF", name+" Secondary Identification", sit, 4, "0B", "1G", "G2", "LU"),
+		)
+	}
+
+	claim := loop("2300", "Claim Information", sit, 100,
+		seg("CLM", "Claim Information", req, 1),
+		seg("DTP", "Discharge Hour", sit, 1, "096"),
+		seg("DTP", "Statement Dates", req, 1, "434"),
+		seg("DTP", "Admission Date/Hour", sit, 1, "435"),
+		seg("DTP", "Date - Repricer Received Date", sit, 1, "050"),
+		seg("CL1", "Institutional Claim Code", sit, 1),
+		seg("PWK", "Claim Supplemental Information", sit, 10),
+		seg("CN1", "Contract Information", sit, 1),
+		seg("AMT", "Patient Estimated Amount Due", sit, 1, "F3"),
+		seg("REF", "Service Authorization Exception Code", sit, 1, "4N"),
+		seg("REF", "Referral Number", sit, 1, "9F"),
+		seg("REF", "Prior Authorization", sit, 1, "G1"),
+		seg("REF", "Payer Claim Control Number", sit, 1, "F8"),
+		seg("REF", "Repriced Claim Number", sit, 1, "9A"),
+		seg("REF", "Adjusted Repriced Claim Number", sit, 1, "9C"),
+		seg("REF", "Investigational Device Exemption Number", sit, 5, "LX"),
+		seg("REF", "Claim Identifier For Transmission Intermediaries", sit, 1, "D9"),
+		seg("REF", "Auto Accident State", sit, 1, "LU"),
+		seg("REF", "Medical Record Number", sit, 1, "EA"),
+		seg("REF", "Demonstration Project Identifier", sit, 1, "P4"),
+		seg("REF", "Peer Review Organization (PRO) Approval Number", sit, 1, "G4"),
+		seg("K3", "File Information", sit, 10),
+		seg("NTE", "Claim Note", sit, 10),
+		seg("NTE", "Billing Note", sit, 1, "ADD"),
+		seg("CRC", "EPSDT Referral", sit, 1, "ZZ"),
+		seg("HI", "Principal Diagnosis", req, 1, "ABK", "BK"),
+		seg("HI", "Admitting Diagnosis", sit, 1, "ABJ", "BJ"),
+		seg("HI", "Patient's Reason For Visit", sit, 1, "APR", "PR"),
+		seg("HI", "External Cause of Injury", sit, 1, "ABN", "BN"),
+		seg("HI", "Diagnosis Related Group (DRG) Information", sit, 1, "DR"),
+		seg("HI", "Other Diagnosis Information", sit, 2, "ABF", "BF"),
+		seg("HI", "Principal Procedure Information", sit, 1, "BBR", "BR", "CAH"),
+		seg("HI", "Other Procedure Information", sit, 2, "BBQ", "BQ"),
+		seg("HI", "Occurrence Span Information", sit, 2, "BI"),
+		seg("HI", "Occurrence Information", sit, 2, "BH"),
+		seg("HI", "Value Information", sit, 2, "BE"),
+		seg("HI", "Condition Information", sit, 2, "BG"),
+		seg("HI", "Treatment Code Information", sit, 2, "TC"),
+		seg("HCP", "Claim Pricing/Repricing Information", sit, 1),
+		loop("2310A", "Attending Provider Name", sit, 1,
+			seg("NM1", "Attending Provider Name", req, 1, "71"),
+			seg("PRV", "Attending Provider Specialty Information", sit, 1, "AT"),
+			seg("REF", "Attending Provider Secondary Identification", sit, 4, "0B", "1G", "G2", "LU"),
+		),
+		provider("2310B", "Operating Physician Name", sit, "72"),
+		provider("2310C", "Other Operating Physician Name", sit, "ZZ"),
+		provider("2310D", "Rendering Provider Name", sit, "82"),
+		loop("2310E", "Service Facility Location Name", sit, 1,
+			seg("NM1", "Service Facility Location Name", req, 1, "77"),
+			seg("N3", "Service Facility Location Address", req, 1),
+			seg("N4", "Service Facility Location City, State, ZIP Code", req, 1),
+			seg("REF", "Service Facility Location Secondary Identification", sit, 3, "0B", "G2", "LU"),
+		),
+		provider("2310F", "Referring Provider Name", sit, "DN"),
+		loop("2320", "Other Subscriber Information", sit, 10,
+			seg("SBR", "Other Subscriber Information", req, 1),
+			seg("CAS", "Claim Level Adjustments", sit, 5),
+			seg("AMT", "Coordination of Benefits (COB) Payer Paid Amount", sit, 1, "D"),
+			seg("AMT", "Remaining Patient Liability", sit, 1, "EAF"),
+			seg("AMT", "Coordination of Benefits (COB) Total Non-Covered Amount", sit, 1, "A8"),
+			seg("OI", "Other Insurance Coverage Information", req, 1),
+			seg("MIA", "Inpatient Adjudication Information", sit, 1),
+			seg("MOA", "Outpatient Adjudication Information", sit, 1),
+			loop("2330A", "Other Subscriber Name", req, 1,
+				seg("NM1", "Other Subscriber Name", req, 1, "IL"),
+				seg("N3", "Other Subscriber Address", sit, 1),
+				seg("N4", "Other Subscriber City, State, ZIP Code", sit, 1),
+				seg("REF", "Other Subscriber Secondary Identification", sit, 1, "SY"),
+			),
+			loop("2330B", "Other Payer Name", req, 1,
+				seg("NM1", "Other Payer Name", req, 1, "PR"),
+				seg("N3", "Other Payer Address", sit, 1),
+				seg("N4", "Other Payer City, State, ZIP Code", sit, 1),
+				seg("DTP", "Claim Check or Remittance Date", sit, 1, "573"),
+				seg("REF", "Other Payer Secondary Identifier", sit, 2, "2U", "EI", "FY", "NF"),
+				seg("REF", "Other Payer Prior Authorization Number", sit, 1, "G1"),
+				seg("REF", "Other Payer Referral Number", sit, 1, "9F"),
+				seg("REF", "Other Payer Claim Adjustment Indicator", sit, 1, "T4"),
+				seg("REF", "Other Payer Claim Control Number", sit, 1, "F8"),
+			),
+			provider("2330C", "Other Payer Attending Provider", sit, "71"),
+			provider("2330D", "Other Payer Operating Physician", sit, "72"),
+			provider("2330E", "Other Payer Other Operating Physician", sit, "ZZ"),
+			provider("2330F", "Other Payer Service Facility Location", sit, "77"),
+			provider("2330G", "Other Payer Rendering Provider Name", sit, "82"),
+			provider("2330H", "Other Payer Referring Provider", sit, "DN"),
+			provider("2330I", "Other Payer Billing Provider", sit, "85"),
+		),
+		loop("2400", "Service Line Number", req, 999,
+			seg("LX", "Service Line Number", req, 1),
+			seg("SV2", "Institutional Service Line", req, 1),
+			seg("PWK", "Line Supplemental Information", sit, 10),
+			seg("DTP", "Date - Service Date", sit, 1, "472"),
+			seg("REF", "Line Item Control Number", sit, 1, "6R"),
+			seg("REF", "Repriced Line Item Reference Number", sit, 1, "9B"),
+			seg("REF", "Adjusted Repriced Line Item Reference Number", sit, 1, "9D"),
+			seg("AMT", "Service Tax Amount", sit, 1, "GT"),
+			seg("AMT", "Facility Tax Amount", sit, 1, "N8"),
+			seg("NTE", "Third Party Organization Notes", sit, 1, "TPO"),
+			seg("HCP", "Line Pricing/Repricing Information", sit, 1),
+			loop("2410", "Drug Identification", sit, 1,
+				seg("LIN", "Drug Identification", req, 1),
+				seg("CTP", "Drug Quantity", req, 1),
+				seg("REF", "Prescription or Compound Drug Association Number", sit, 1, "VY", "XZ"),
+			),
+			provider("2420A", "Operating Physician Name", sit, "72"),
+			provider("2420B", "Other Operating Physician Name", sit, "ZZ"),
+			provider("2420C", "Rendering Provider Name", sit, "82"),
+			provider("2420D", "Referring Provider Name", sit, "DN"),
+			loop("2430", "Line Adjudication Information", sit, 15,
+				seg("SVD", "Line Adjudication Information", req, 1),
+				seg("CAS", "Line Adjustment", sit, 5),
+				seg("DTP", "Line Check or Remittance Date", req, 1, "573"),
+				seg("AMT", "Remaining Patient Liability", sit, 1, "EAF"),
+			),
+		),
+	)
+
+	patient := loopWithRule(loop("2000C", "Patient Hierarchical Level", sit, 0,
+		seg("HL", "Patient Hierarchical Level", req, 1, "23"),
+		seg("PAT", "Patient Information", req, 1),
+		loop("2010CA", "Patient Name", req, 1,
+			seg("NM1", "Patient Name", req, 1, "QC"),
+			seg("N3", "Patient Address", req, 1),
+			seg("N4", "Patient City, State, ZIP Code", req, 1),
+			seg("DMG", "Patient Demographic Information", req, 1),
+			seg("REF", "Property and Casualty Claim Number", sit, 1, "Y4"),
+			seg("REF", "Property and Casualty Patient Identifier", sit, 1, "1W", "SY"),
+		),
+		claim,
+	), &schema.Rule{
+		Text:      "Required when the patient is a different person than the subscriber. If not required by this implementation guide, do not send.",
+		Condition: CondInstitutionalPatientNotSubscriber,
+		Exclusive: true,
+	})
+
+	subscriber := loop("2000B", "Subscriber Hierarchical Level", req, 0,
+		seg("HL", "Subscriber Hierarchical Level", req, 1, "22"),
+		seg("SBR", "Subscriber Information", req, 1),
+		loop("2010BA", "Subscriber Name", req, 1,
+			seg("NM1", "Subscriber Name", req, 1, "IL"),
+			withRule(seg("N3", "Subscriber Address", sit, 1), patientIsSubscriber),
+			withRule(seg("N4", "Subscriber City, State, ZIP Code", sit, 1), patientIsSubscriber),
+			withRule(seg("DMG", "Subscriber Demographic Information", sit, 1), patientIsSubscriber),
+			seg("REF", "Subscriber Secondary Identification", sit, 1, "SY"),
+			seg("REF", "Property and Casualty Claim Number", sit, 1, "Y4"),
+		),
+		loop("2010BB", "Payer Name", req, 1,
+			seg("NM1", "Payer Name", req, 1, "PR"),
+			seg("N3", "Payer Address", sit, 1),
+			seg("N4", "Payer City, State, ZIP Code", sit, 1),
+			seg("REF", "Payer Secondary Identification", sit, 0, "2U", "EI", "FY", "NF"),
+			seg("REF", "Billing Provider Secondary Identification", sit, 2, "G2", "LU"),
+		),
+		claim,
+		patient,
+	)
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BHT", "Beginning of Hierarchical Transaction", req, 1),
+		loop("1000A", "Submitter Name", req, 1,
+			seg("NM1", "Submitter Name", req, 1, "41"),
+			seg("PER", "Submitter EDI Contact Information", req, 2, "IC"),
+		),
+		loop("1000B", "Receiver Name", req, 1,
+			seg("NM1", "Receiver Name", req, 1, "40"),
+		),
+		loop("2000A", "Billing Provider Hierarchical Level", req, 0,
+			seg("HL", "Billing Provider Hierarchical Level", req, 1, "20"),
+			seg("PRV", "Billing Provider Specialty Information", sit, 1, "BI"),
+			seg("CUR", "Foreign Currency Information", sit, 1),
+			loop("2010AA", "Billing Provider Name", req, 1,
+				seg("NM1", "Billing Provider Name", req, 1, "85"),
+				seg("N3", "Billing Provider Address", req, 1),
+				seg("N4", "Billing Provider City, State, ZIP Code", req, 1),
+				seg("REF", "Billing Provider Tax Identification", req, 1, "EI"),
+				seg("PER", "Billing Provider Contact Information", sit, 2, "IC"),
+			),
+			loopWithRule(loop("2010AB", "Pay-to Address Name", sit, 1,
+				seg("NM1", "Pay-to Address Name", req, 1, "87"),
+				seg("N3", "Pay-to Address - ADDRESS", req, 1),
+				seg("N4", "Pay-To Address City, State, ZIP Code", req, 1),
+			), &schema.Rule{
+				Text:      "Required when the address for payment is different than that of the Billing Provider. If not required by this implementation guide, do not send.",
+				Exclusive: true,
+			}),
+			loopWithRule(loop("2010AC", "Pay-To Plan Name", sit, 1,
+				seg("NM1", "Pay-To Plan Name", req, 1, "PE"),
+				seg("N3", "Pay-to Plan Address", req, 1),
+				seg("N4", "Pay-To Plan City, State, ZIP Code", req, 1),
+				seg("REF", "Pay-to Plan Secondary Identification", sit, 1, "2U", "FY", "NF"),
+				seg("REF", "Pay-To Plan Tax Identification Number", req, 1, "EI"),
+			), &schema.Rule{
+				Text:      "Required when willing trading partners agree to use this implementation for their subrogation payment requests. If not required by this implementation guide, do not send.",
+				Exclusive: true,
+			}),
+			subscriber,
+		),
+	}}
+}
@@ -0,0 +1,28 @@
+package hipaa_test
+
+import (
+	"testing"
+
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+)
+
+func TestX223A2Fixtures(t *testing.T) {
+	for name, tx := range decodeFixtures(t, "005010x223") {
+		t.Run(name, func(t *testing.T) {
+			for _, err := range schemaErrors(hipaa.X223A2, tx) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			root, _ := hipaa.X223A2.Parse(tx)
+			lines := 0
+			root.Walk(func(n *schema.LoopNode) {
+				if n.ID() == "2400" && n.Segment("SV2") != nil {
+					lines++
+				}
+			})
+			if lines == 0 {
+				t.Error("no 2400 service lines with SV2 found")
+			}
+		})
+	}
+}
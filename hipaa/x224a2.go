@@ -0,0 +1,246 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// Condition names registered for 005010X224A2 rules.
+const (
+	// CondDentalPatientIsSubscriber holds when the enclosing subscriber
+	// loop (2000B) reports the subscriber as the patient (SBR02 is
+	// "18").
+	CondDentalPatientIsSubscriber = "005010X224A2.patient-is-subscriber"
+	// CondDentalPatientNotSubscriber holds when the enclosing subscriber
+	// loop (2000B) does not report the subscriber as the patient.
+	CondDentalPatientNotSubscriber = "005010X224A2.patient-not-subscriber"
+)
+
+func init() {
+	schema.RegisterCondition(CondDentalPatientIsSubscriber, func(ctx *schema.Context) bool {
+		return subscriberIsPatient(ctx)
+	})
+	schema.RegisterCondition(CondDentalPatientNotSubscriber, func(ctx *schema.Context) bool {
+		return !subscriberIsPatient(ctx)
+	})
+}
+
+// X224A2 is the Health Care Claim: Dental (837) implementation guide,
+// 005010X224A2.
+var X224A2 = &schema.TransactionSet{
+	ID:      "837",
+	Version: "005010X224A2",
+	Name:    "Health Care Claim: Dental",
+	Loop:    x224a2(),
+}
+
+func x224a2() *schema.Loop {
+	patientIsSubscriber := &schema.Rule{
+		Text:      "Required when the patient is the subscriber.",
+		Condition: CondDentalPatientIsSubscriber,
+	}
+
+	// provider returns a provider name loop of an NM1 and its
+	// secondary identifiers; withSpecialty adds a PRV between them.
+	provider := func(id, name string, codes ...string) *schema.Loop {
+		return loop(id, name, sit, 1,
+			seg("NM1", name, req, 1, codes...),
+			seg("REF", name+" Secondary Identification", sit, 3, "0B", "1G", "G2", "LU"),
+		)
+	}
+	withSpecialty := func(id, name string, codes ...string) *schema.Loop {
+		return loop(id, name, sit, 1,
+			seg("NM1", name, req, 1, codes...),
+			seg("PRV", name+" Specialty Information", sit, 1, "PE", "AS"),
+			seg("REF", name+" Secondary Identification", sit, 4, "0B", "1G", "G2", "LU"),
+		)
+	}
+	facility := func(id, name string) *schema.Loop {
+		return loop(id, name, sit, 1,
+			seg("NM1", name, req, 1, "77"),
+			seg("N3", name+" Address", req, 1),
+			seg("N4", name+" City, State, ZIP Code", req, 1),
+			seg("REF", name+" Secondary Identification", sit, 3, "G2", "LU"),
+		)
+	}
+
+	claim := loop("2300", "Claim Information", sit, 100,
+		seg("CLM", "Claim Information", req, 1),
+		seg("DTP", "Date - Accident", sit, 1, "439"),
+		seg("DTP", "Date - Appliance Placement", sit, 5, "452"),
+		seg("DTP", "Date - Service", sit, 1, "472"),
+		seg("DTP", "Date - Repricer Received Date", sit, 1, "050"),
+		seg("DTP", "Date - Admission", sit, 1, "435"),
+		seg("DTP", "Date - Discharge", sit, 1, "096"),
+		seg("DN1", "Orthodontic Total Months of Treatment", sit, 1),
+		seg("DN2", "Tooth Status", sit, 35),
+		seg("PWK", "Claim Supplemental Information", sit, 10),
+		seg("CN1", "Contract Information", sit, 1),
+		seg("AMT", "Patient Amount Paid", sit, 1, "F5"),
+		seg("REF", "Service Authorization Exception Code", sit, 1, "4N"),
+		seg("REF", "Mandatory Medicare (Section 4081) Crossover Indicator", sit, 1, "F5"),
+		seg("REF", "Prior Authorization", sit, 1, "G1"),
+		seg("REF", "Referral Number", sit, 1, "9F"),
+		seg("REF", "Payer Claim Control Number", sit, 1, "F8"),
+		seg("REF", "Repriced Claim Number", sit, 1, "9A"),
+		seg("REF", "Adjusted Repriced Claim Number", sit, 1, "9C"),
+		seg("REF", "Predetermination Identification", sit, 1, "G3"),
+		seg("REF", "Claim Identifier For Transmission Intermediaries", sit, 1, "D9"),
+		seg("K3", "File Information", sit, 10),
+		seg("NTE", "Claim Note", sit, 1),
+		seg("HI", "Health Care Diagnosis Code", sit, 1, "ABK", "BK"),
+		seg("HCP", "Claim Pricing/Repricing Information", sit, 1),
+		loop("2310A", "Referring Provider Name", sit, 2,
+			seg("NM1", "Referring Provider Name", req, 1, "DN", "P3"),
+			seg("PRV", "Referring Provider Specialty Information", sit, 1, "RF"),
+			seg("REF", "Referring Provider Secondary Identification", sit, 3, "0B", "1G", "G2"),
+		),
+		withSpecialty("2310B", "Rendering Provider Name", "82"),
+		facility("2310C", "Service Facility Location Name"),
+		withSpecialty("2310D", "Assistant Surgeon Name", "DD"),
+		provider("2310E", "Supervising Provider Name", "DQ"),
+		loop("2320", "Other Subscriber Information", sit, 10,
+			seg("SBR", "Other Subscriber Information", req, 1),
+			seg("CAS", "Claim Level Adjustments", sit, 5),
+			seg("AMT", "Coordination of Benefits (COB) Payer Paid Amount", sit, 1, "D"),
+			seg("AMT", "Remaining Patient Liability", sit, 1, "EAF"),
+			seg("AMT", "Coordination of Benefits (COB) Total Non-Covered Amount", sit, 1, "A8"),
+			seg("OI", "Other Insurance Coverage Information", req, 1),
+			seg("MOA", "Outpatient Adjudication Information", sit, 1),
+			loop("2330A", "Other Subscriber Name", req, 1,
+				seg("NM1", "Other Subscriber Name", req, 1, "IL"),
+				seg("N3", "Other Subscriber Address", sit, 1),
+				seg("N4", "Other Subscriber City, State, ZIP Code", sit, 1),
+				seg("REF", "Other Subscriber Secondary Identification", sit, 1, "SY"),
+			),
+			loop("2330B", "Other Payer Name", req, 1,
+				seg("NM1", "Other Payer Name", req, 1, "PR"),
+				seg("N3", "Other Payer Address", sit, 1),
+				seg("N4", "Other Payer City, State, ZIP Code", sit, 1),
+				seg("DTP", "Claim Check or Remittance Date", sit, 1, "573"),
+				seg("REF", "Other Payer Secondary Identifier", sit, 2, "2U", "EI", "FY", "NF"),
+				seg("REF", "Other Payer Prior Authorization Number", sit, 1, "G1"),
+				seg("REF", "Other Payer Referral Number", sit, 1, "9F"),
+				seg("REF", "Other Payer Claim Adjustment Indicator", sit, 1, "T4"),
+				seg("REF", "Other Payer Predetermination Identification", sit, 1, "G3"),
+				seg("REF", "Other Payer Claim Control Number", sit, 1, "F8"),
+			),
+			provider("2330C", "Other Payer Referring Provider", "DN", "P3"),
+			provider("2330D", "Other Payer Rendering Provider", "82"),
+			provider("2330E", "Other Payer Supervising Provider", "DQ"),
+			provider("2330F", "Other Payer Billing Provider", "85"),
+			provider("2330G", "Other Payer Service Facility Location", "77"),
+			provider("2330H", "Other Payer Assistant Surgeon", "DD"),
+		),
+		loop("2400", "Service Line Number", req, 50,
+			seg("LX", "Service Line Number", req, 1),
+			seg("SV3", "Dental Service", req, 1),
+			seg("TOO", "Tooth Information", sit, 32, "JP"),
+			seg("DTP", "Date - Service Date", sit, 1, "472"),
+			seg("DTP", "Date - Prior Placement", sit, 1, "441"),
+			seg("DTP", "Date - Appliance Placement", sit, 1, "452"),
+			seg("DTP", "Date - Replacement", sit, 1, "446"),
+			seg("DTP", "Date - Treatment Start", sit, 1, "196"),
+			seg("DTP", "Date - Treatment Completion", sit, 1, "198"),
+			seg("QTY", "Anesthesia Quantity", sit, 5, "HM", "HO", "HP", "HS"),
+			seg("REF", "Service Predetermination Identification", sit, 1, "G3"),
+			seg("REF", "Prior Authorization", sit, 1, "G1"),
+			seg("REF", "Referral Number", sit, 1, "9F"),
+			seg("REF", "Line Item Control Number", sit, 1, "6R"),
+			seg("REF", "Repriced Line Item Reference Number", sit, 1, "9B"),
+			seg("REF", "Adjusted Repriced Line Item Reference Number", sit, 1, "9D"),
+			seg("AMT", "Sales Tax Amount", sit, 1, "T"),
+			seg("NTE", "Line Note", sit, 1, "TPO"),
+			seg("HCP", "Line Pricing/Repricing Information", sit, 1),
+			withSpecialty("2420A", "Rendering Provider Name", "82"),
+			withSpecialty("2420B", "Assistant Surgeon Name", "DD"),
+			provider("2420C", "Supervising Provider Name", "DQ"),
+			facility("2420D", "Service Facility Location Name"),
+			loop("2430", "Line Adjudication Information", sit, 15,
+				seg("SVD", "Line Adjudication Information", req, 1),
+				seg("CAS", "Line Adjustment", sit, 5),
+				seg("DTP", "Line Check or Remittance Date", req, 1, "573"),
+				seg("AMT", "Remaining Patient Liability", sit, 1, "EAF"),
+			),
+		),
+	)
+
+	patient := loopWithRule(loop("2000C", "Patient Hierarchical Level", sit, 0,
+		seg("HL", "Patient Hierarchical Level", req, 1, "23"),
+		seg("PAT", "Patient Information", req, 1),
+		loop("2010CA", "Patient Name", req, 1,
+			seg("NM1", "Patient Name", req, 1, "QC"),
+			seg("N3", "Patient Address", req, 1),
+			seg("N4", "Patient City, State, ZIP Code", req, 1),
+			seg("DMG", "Patient Demographic Information", req, 1),
+			seg("REF", "Property and Casualty Claim Number", sit, 1, "Y4"),
+		),
+		claim,
+	), &schema.Rule{
+		Text:      "Required when the patient is a different person than the subscriber. If not required by this implementation guide, do not send.",
+		Condition: CondDentalPatientNotSubscriber,
+		Exclusive: true,
+	})
+
+	subscriber := loop("2000B", "Subscriber Hierarchical Level", req, 0,
+		seg("HL", "Subscriber Hierarchical Level", req, 1, "22"),
+		seg("SBR", "Subscriber Information", req, 1),
+		loop("2010BA", "Subscriber Name", req, 1,
+			seg("NM1", "Subscriber Name", req, 1, "IL"),
+			withRule(seg("N3", "Subscriber Address", sit, 1), patientIsSubscriber),
+			withRule(seg("N4", "Subscriber City, State, ZIP Code", sit, 1), patientIsSubscriber),
+			withRule(seg("DMG", "Subscriber Demographic Information", sit, 1), patientIsSubscriber),
+			seg("REF", "Subscriber Secondary Identification", sit, 1, "SY"),
+			seg("REF", "Property and Casualty Claim Number", sit, 1, "Y4"),
+		),
+		loop("2010BB", "Payer Name", req, 1,
+			seg("NM1", "Payer Name", req, 1, "PR"),
+			seg("N3", "Payer Address", sit, 1),
+			seg("N4", "Payer City, State, ZIP Code", sit, 1),
+			seg("REF", "Payer Secondary Identification", sit, 0, "2U", "EI", "FY", "NF"),
+			seg("REF", "Billing Provider Secondary Identification", sit, 2, "G2", "LU"),
+		),
+		claim,
+		patient,
+	)
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BHT", "Beginning of Hierarchical Transaction", req, 1),
+		loop("1000A", "Submitter Name", req, 1,
+			seg("NM1", "Submitter Name", req, 1, "41"),
+			seg("PER", "Submitter EDI Contact Information", req, 2, "IC"),
+		),
+		loop("1000B", "Receiver Name", req, 1,
+			seg("NM1", "Receiver Name", req, 1, "40"),
+		),
+		loop("2000A", "Billing Provider Hierarchical Level", req, 0,
+			seg("HL", "Billing Provider Hierarchical Level", req, 1, "20"),
+			seg("PRV", "Billing Provider Specialty Information", sit, 1, "BI"),
+			seg("CUR", "Foreign Currency Information", sit, 1),
+			loop("2010AA", "Billing Provider Name", req, 1,
+				seg("NM1", "Billing Provider Name", req, 1, "85"),
+				seg("N3", "Billing Provider Address", req, 1),
+				seg("N4", "Billing Provider City, State, ZIP Code", req, 1),
+				seg("REF", "Billing Provider Tax Identification", req, 1, "EI", "SY"),
+				seg("REF", "Billing Provider UPIN/License Information", sit, 2, "0B", "1G"),
+				seg("PER", "Billing Provider Contact Information", sit, 2, "IC"),
+			),
+			loopWithRule(loop("2010AB", "Pay-to Address Name", sit, 1,
+				seg("NM1", "Pay-to Address Name", req, 1, "87"),
+				seg("N3", "Pay-to Address - ADDRESS", req, 1),
+				seg("N4", "Pay-To Address City, State, ZIP Code", req, 1),
+			), &schema.Rule{
+				Text:      "Required when the address for payment is different than that of the Billing Provider. If not required by this implementation guide, do not send.",
+				Exclusive: true,
+			}),
+			loopWithRule(loop("2010AC", "Pay-To Plan Name", sit, 1,
+				seg("NM1", "Pay-To Plan Name", req, 1, "PE"),
+				seg("N3", "Pay-to Plan Address", req, 1),
+				seg("N4", "Pay-To Plan City, State, ZIP Code", req, 1),
+				seg("REF", "Pay-to Plan Secondary Identification", sit, 1, "2U", "FY", "NF"),
+				seg("REF", "Pay-To Plan Tax Identification Number", req, 1, "EI"),
+			), &schema.Rule{
+				Text:      "Required when willing trading partners agree to use this implementation for their subrogation payment requests. If not required by this implementation guide, do not send.",
+				Exclusive: true,
+			}),
+			subscriber,
+		),
+	}}
+}
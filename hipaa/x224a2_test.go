@@ -0,0 +1,30 @@
+package hipaa_test
+
+import (
+	"testing"
+
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+)
+
+func TestX224A2Fixtures(t *testing.T) {
+	for name, tx := range decodeFixtures(t, "005010x224") {
+		t.Run(name, func(t *testing.T) {
+			for _, err := range schemaErrors(hipaa.X224A2, tx) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			root, _ := hipaa.X224A2.Parse(tx)
+			teeth := 0
+			root.Walk(func(n *schema.LoopNode) {
+				for _, s := range n.Segments {
+					if n.ID() == "2400" && s.Segment.ID == "TOO" {
+						teeth++
+					}
+				}
+			})
+			if teeth == 0 {
+				t.Error("no TOO segments found in 2400 service lines")
+			}
+		})
+	}
+}
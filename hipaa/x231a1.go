@@ -0,0 +1,31 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X231A1 is the Implementation Acknowledgment for Health Care Insurance
+// (999) implementation guide, 005010X231A1: the acknowledgment that
+// accepts or rejects each functional group and transaction set of an
+// interchange, reporting the segments and elements that fail the X12
+// syntax or their implementation guide's requirements.
+var X231A1 = &schema.TransactionSet{
+	ID:      "999",
+	Version: "005010X231A1",
+	Name:    "Implementation Acknowledgment for Health Care Insurance",
+	Loop: &schema.Loop{Children: []schema.Node{
+		seg("AK1", "Functional Group Response Header", req, 1),
+		loop("2000", "Transaction Set Response Header", sit, 0,
+			seg("AK2", "Transaction Set Response Header", req, 1),
+			loop("2100", "Error Identification", sit, 0,
+				seg("IK3", "Error Identification", req, 1),
+				seg("CTX", "Segment Context", sit, 9, "SITUATIONAL TRIGGER"),
+				seg("CTX", "Business Unit Identifier", sit, 1),
+				loop("2110", "Implementation Data Element Note", sit, 99,
+					seg("IK4", "Implementation Data Element Note", req, 1),
+					seg("CTX", "Element Context", sit, 10),
+				),
+			),
+			seg("IK5", "Transaction Set Response Trailer", req, 1),
+		),
+		seg("AK9", "Functional Group Response Trailer", req, 1, "A", "E", "P", "R"),
+	}},
+}
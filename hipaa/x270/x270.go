@@ -0,0 +1,144 @@
+// Package x270 is a typed model of the Health Care Eligibility Benefit
+// Inquiry (270) transaction, implementation guide 005010X279A1.
+//
+// FromTransaction arranges a transaction's segments with
+// hipaa.X279A1Request and maps them to a Transaction: the hierarchy of
+// information sources (payers), information receivers (providers),
+// subscribers, and dependents, and the eligibility or benefit inquiries
+// (EQ) made for each subscriber and dependent. ToTransaction writes the
+// segments back in guide order, so a transaction that conforms to the
+// guide's loop structure round-trips unchanged. Package x271 models the
+// response.
+package x270
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is a 270 eligibility inquiry transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X279A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT     segments.BHT `x12:"BHT"`
+	Sources []Source     `x12:"2000A"`
+}
+
+// A Source is the Information Source Level (loop 2000A): the payer
+// asked about eligibility, and the receivers asking it.
+type Source struct {
+	HL        segments.HL `x12:"HL"`
+	Name      Entity      `x12:"2100A"`
+	Receivers []Receiver  `x12:"2000B"`
+}
+
+// A Receiver is the Information Receiver Level (loop 2000B): the
+// provider asking about eligibility, and the subscribers it asks about.
+type Receiver struct {
+	HL          segments.HL  `x12:"HL"`
+	Name        Entity       `x12:"2100B"`
+	Subscribers []Subscriber `x12:"2000C"`
+}
+
+// A Subscriber is the Subscriber Level (loop 2000C). Dependents holds
+// the subscriber's dependents asked about in their own right.
+type Subscriber struct {
+	HL         segments.HL    `x12:"HL"`
+	Traces     []segments.TRN `x12:"TRN"`
+	Name       Member         `x12:"2100C"`
+	Dependents []Dependent    `x12:"2000D"`
+}
+
+// A Dependent is the Dependent Level (loop 2000D).
+type Dependent struct {
+	HL     segments.HL    `x12:"HL"`
+	Traces []segments.TRN `x12:"TRN"`
+	Name   Member         `x12:"2100D"`
+}
+
+// An Entity is the name loop of an information source or receiver
+// (2100A or 2100B).
+type Entity struct {
+	Name       segments.NM1   `x12:"NM1"`
+	References []segments.REF `x12:"REF"`
+	Address    *segments.N3   `x12:"N3"`
+	City       *segments.N4   `x12:"N4"`
+	Provider   *segments.PRV  `x12:"PRV"`
+}
+
+// A Member is the name loop of a subscriber or dependent (2100C or
+// 2100D) and the inquiries made about them.
+type Member struct {
+	Name         segments.NM1   `x12:"NM1"`
+	References   []segments.REF `x12:"REF"`
+	Address      *segments.N3   `x12:"N3"`
+	City         *segments.N4   `x12:"N4"`
+	Provider     *segments.PRV  `x12:"PRV"`
+	Demographics *segments.DMG  `x12:"DMG"`
+	Relationship *segments.INS  `x12:"INS"`
+	Diagnoses    *segments.HI   `x12:"HI"`
+	Dates        []segments.DTP `x12:"DTP"`
+	Inquiries    []Inquiry      `x12:"2110C,2110D"`
+}
+
+// An Inquiry is the Eligibility or Benefit Inquiry loop (2110C or
+// 2110D): one EQ segment and the information qualifying it.
+type Inquiry struct {
+	Inquiry        segments.EQ    `x12:"EQ"`
+	SpendDown      []segments.AMT `x12:"AMT"`
+	AdditionalInfo []segments.III `x12:"III"`
+	Reference      *segments.REF  `x12:"REF"`
+	Date           *segments.DTP  `x12:"DTP"`
+}
+
+// FromTransaction returns the model of tx, a 270 transaction of
+// 005010X279A1. Elements are split into components and repetitions
+// with d. It returns an error if a segment is out of place for the
+// guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x270", hipaa.X279A1Request, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 270 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged: levels without an HL01 are
+// numbered in order, and HL02, HL03, and HL04 follow from the model's
+// nesting.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	for i := range t.Sources {
+		src := &t.Sources[i]
+		srcID := levels.Number(&src.HL, "20", "", len(src.Receivers) > 0)
+		for j := range src.Receivers {
+			rcv := &src.Receivers[j]
+			rcvID := levels.Number(&rcv.HL, "21", srcID, len(rcv.Subscribers) > 0)
+			for k := range rcv.Subscribers {
+				sub := &rcv.Subscribers[k]
+				subID := levels.Number(&sub.HL, "22", rcvID, len(sub.Dependents) > 0)
+				for l := range sub.Dependents {
+					levels.Number(&sub.Dependents[l].HL, "23", subID, false)
+				}
+			}
+		}
+	}
+	return model.Marshal("x270", hipaa.X279A1Request, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to the 270 of
+// 005010X279A1: loop structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X279A1Request, tx)
+}
@@ -0,0 +1,98 @@
+package x270_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x270"
+	"github.com/tmc/x12/segments"
+)
+
+// delims are the delimiters of the X279 examples, which separate
+// repetitions with ">".
+var delims = segments.Delimiters{Component: ":", Repetition: ">"}
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, filepath.Join("..", "..", "testdata", "005010x279-example-?a-*.edi")), x270.FromTransaction, nil)
+}
+
+func TestDependentInquiry(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x279-example-2a-generic-request-physician-patients-dependent-eligibility.edi"))
+	m, err := x270.FromTransaction(tx, delims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcv := m.Sources[0].Receivers[0]
+	if rcv.Name.Name.LastName != "JONES" || rcv.Name.Name.ID != "0202034" {
+		t.Errorf("receiver = %+v", rcv.Name.Name)
+	}
+	sub := rcv.Subscribers[0]
+	if sub.Name.Name.ID != "11122333301" || len(sub.Name.Inquiries) != 0 {
+		t.Errorf("subscriber = %+v", sub.Name)
+	}
+	dep := sub.Dependents[0]
+	if diff := cmp.Diff([]segments.TRN{{TypeCode: "1", ReferenceID: "93175-012547", OriginatorID: "9877281234"}}, dep.Traces); diff != "" {
+		t.Errorf("dependent TRN mismatch (-want +got):\n%s", diff)
+	}
+	if dep.Name.Name.FirstName != "MARY" || dep.Name.Demographics.BirthDate != "19781014" {
+		t.Errorf("dependent = %+v", dep.Name)
+	}
+	if diff := cmp.Diff([]x270.Inquiry{{Inquiry: segments.EQ{ServiceTypes: []string{"30"}}}}, dep.Name.Inquiries); diff != "" {
+		t.Errorf("dependent inquiries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x270.Transaction{
+		ControlNumber: "0001",
+		BHT:           segments.BHT{StructureCode: "0022", PurposeCode: "13", ReferenceID: "REQ1", Date: "20240105", Time: "0900"},
+		Sources: []x270.Source{{
+			Name: x270.Entity{Name: segments.NM1{EntityIdentifierCode: "PR", EntityTypeQualifier: "2", LastName: "PAYER", IDQualifier: "PI", ID: "12345"}},
+			Receivers: []x270.Receiver{{
+				Name: x270.Entity{Name: segments.NM1{EntityIdentifierCode: "1P", EntityTypeQualifier: "2", LastName: "CLINIC", IDQualifier: "XX", ID: "1234567893"}},
+				Subscribers: []x270.Subscriber{{
+					Traces: []segments.TRN{{TypeCode: "1", ReferenceID: "TRACE1", OriginatorID: "9999999999"}},
+					Name: x270.Member{
+						Name: segments.NM1{EntityIdentifierCode: "IL", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JANE", IDQualifier: "MI", ID: "W123"},
+						Inquiries: []x270.Inquiry{
+							{Inquiry: segments.EQ{ServiceTypes: []string{"30"}}},
+							{Inquiry: segments.EQ{ServiceTypes: []string{"48", "50"}}},
+						},
+					},
+				}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BHT*0022*13*REQ1*20240105*0900",
+		"HL*1**20*1",
+		"NM1*PR*2*PAYER*****PI*12345",
+		"HL*2*1*21*1",
+		"NM1*1P*2*CLINIC*****XX*1234567893",
+		"HL*3*2*22*0",
+		"TRN*1*TRACE1*9999999999",
+		"NM1*IL*1*DOE*JANE****MI*W123",
+		"EQ*30",
+		"EQ*48^50",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
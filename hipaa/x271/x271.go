@@ -0,0 +1,242 @@
+// Package x271 is a typed model of the Health Care Eligibility Benefit
+// Response (271) transaction, implementation guide 005010X279A1.
+//
+// FromTransaction arranges a transaction's segments with
+// hipaa.X279A1Response and maps them to a Transaction: the hierarchy of
+// information sources (payers), information receivers (providers),
+// subscribers, and dependents, and the eligibility or benefit
+// information (EB) reported for each subscriber and dependent, with the
+// messages, additional information, and related entities qualifying
+// it. EB03 is split into its repeated service type codes. Request
+// validation errors (AAA) are kept at the level that reported them and
+// gathered by Transaction.Rejections. ToTransaction writes the segments
+// back in guide order, so a transaction that conforms to the guide's
+// loop structure round-trips unchanged. Package x270 models the
+// inquiry.
+package x271
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is a 271 eligibility response transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X279A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT     segments.BHT `x12:"BHT"`
+	Sources []Source     `x12:"2000A"`
+}
+
+// A Source is the Information Source Level (loop 2000A): the payer
+// reporting eligibility, and the receivers it reports to.
+type Source struct {
+	HL        segments.HL    `x12:"HL"`
+	Errors    []segments.AAA `x12:"AAA"`
+	Name      Entity         `x12:"2100A"`
+	Receivers []Receiver     `x12:"2000B"`
+}
+
+// A Receiver is the Information Receiver Level (loop 2000B): the
+// provider that asked about eligibility, and the subscribers reported
+// on.
+type Receiver struct {
+	HL          segments.HL  `x12:"HL"`
+	Name        Entity       `x12:"2100B"`
+	Subscribers []Subscriber `x12:"2000C"`
+}
+
+// A Subscriber is the Subscriber Level (loop 2000C). Dependents holds
+// the subscriber's dependents reported on in their own right.
+type Subscriber struct {
+	HL         segments.HL    `x12:"HL"`
+	Traces     []segments.TRN `x12:"TRN"`
+	Name       Member         `x12:"2100C"`
+	Dependents []Dependent    `x12:"2000D"`
+}
+
+// A Dependent is the Dependent Level (loop 2000D).
+type Dependent struct {
+	HL     segments.HL    `x12:"HL"`
+	Traces []segments.TRN `x12:"TRN"`
+	Name   Member         `x12:"2100D"`
+}
+
+// An Entity is the name loop of an information source or receiver
+// (2100A or 2100B), or of an entity related to a benefit (2120C or
+// 2120D), such as a primary care provider.
+type Entity struct {
+	Name       segments.NM1   `x12:"NM1"`
+	References []segments.REF `x12:"REF"`
+	Address    *segments.N3   `x12:"N3"`
+	City       *segments.N4   `x12:"N4"`
+	Contacts   []segments.PER `x12:"PER"`
+	Errors     []segments.AAA `x12:"AAA"`
+	Provider   *segments.PRV  `x12:"PRV"`
+}
+
+// A Member is the name loop of a subscriber or dependent (2100C or
+// 2100D) and the benefits reported for them.
+type Member struct {
+	Name         segments.NM1   `x12:"NM1"`
+	References   []segments.REF `x12:"REF"`
+	Address      *segments.N3   `x12:"N3"`
+	City         *segments.N4   `x12:"N4"`
+	Errors       []segments.AAA `x12:"AAA"`
+	Provider     *segments.PRV  `x12:"PRV"`
+	Demographics *segments.DMG  `x12:"DMG"`
+	Relationship *segments.INS  `x12:"INS"`
+	Diagnoses    *segments.HI   `x12:"HI"`
+	Dates        []segments.DTP `x12:"DTP"`
+	Military     *segments.MPI  `x12:"MPI"`
+	Benefits     []Benefit      `x12:"2110C,2110D"`
+}
+
+// A Benefit is the Eligibility or Benefit Information loop (2110C or
+// 2110D): one EB segment and the information qualifying it.
+//
+// The related entities are bounded by an LS and LE segment pair, which
+// ToTransaction adds when RelatedEntities is not empty and LoopHeader
+// is nil.
+type Benefit struct {
+	Benefit         segments.EB    `x12:"EB"`
+	Deliveries      []segments.HSD `x12:"HSD"`
+	References      []segments.REF `x12:"REF"`
+	Dates           []segments.DTP `x12:"DTP"`
+	Errors          []segments.AAA `x12:"AAA"`
+	Messages        []segments.MSG `x12:"MSG"`
+	AdditionalInfo  []Information  `x12:"2115C,2115D"`
+	LoopHeader      *segments.LS   `x12:"LS"`
+	RelatedEntities []Entity       `x12:"2120C,2120D"`
+	LoopTrailer     *segments.LE   `x12:"LE"`
+}
+
+// An Information is the Eligibility or Benefit Additional Information
+// loop (2115C or 2115D).
+type Information struct {
+	Info segments.III `x12:"III"`
+}
+
+// HasServiceType reports whether b applies to the service type code,
+// such as "30" for health benefit plan coverage.
+func (b *Benefit) HasServiceType(code string) bool {
+	for _, st := range b.Benefit.ServiceTypes {
+		if st == code {
+			return true
+		}
+	}
+	return false
+}
+
+// BenefitsFor returns the benefits reported for m that apply to the
+// service type code, in order.
+func (m *Member) BenefitsFor(code string) []*Benefit {
+	var bs []*Benefit
+	for i := range m.Benefits {
+		if m.Benefits[i].HasServiceType(code) {
+			bs = append(bs, &m.Benefits[i])
+		}
+	}
+	return bs
+}
+
+// A Rejection is a request validation error (AAA) and the loop that
+// reported it.
+type Rejection struct {
+	LoopID string
+	segments.AAA
+}
+
+// Rejections returns the request validation errors reported anywhere
+// in t, in transaction order.
+func (t *Transaction) Rejections() []Rejection {
+	var rs []Rejection
+	add := func(loopID string, errs []segments.AAA) {
+		for _, e := range errs {
+			rs = append(rs, Rejection{LoopID: loopID, AAA: e})
+		}
+	}
+	member := func(level string, m *Member) {
+		add("2100"+level, m.Errors)
+		for _, b := range m.Benefits {
+			add("2110"+level, b.Errors)
+		}
+	}
+	for _, src := range t.Sources {
+		add("2000A", src.Errors)
+		add("2100A", src.Name.Errors)
+		for _, rcv := range src.Receivers {
+			add("2100B", rcv.Name.Errors)
+			for _, sub := range rcv.Subscribers {
+				member("C", &sub.Name)
+				for _, dep := range sub.Dependents {
+					member("D", &dep.Name)
+				}
+			}
+		}
+	}
+	return rs
+}
+
+// FromTransaction returns the model of tx, a 271 transaction of
+// 005010X279A1. Elements are split into components and repetitions
+// with d. It returns an error if a segment is out of place for the
+// guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x271", hipaa.X279A1Response, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 271 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged, as x270's ToTransaction
+// does, and adds the LS and LE segments bounding each benefit's related
+// entities.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	bound := func(m *Member) {
+		for i := range m.Benefits {
+			b := &m.Benefits[i]
+			if len(b.RelatedEntities) > 0 && b.LoopHeader == nil {
+				b.LoopHeader = &segments.LS{LoopID: "2120"}
+				b.LoopTrailer = &segments.LE{LoopID: "2120"}
+			}
+		}
+	}
+	for i := range t.Sources {
+		src := &t.Sources[i]
+		srcID := levels.Number(&src.HL, "20", "", len(src.Receivers) > 0)
+		for j := range src.Receivers {
+			rcv := &src.Receivers[j]
+			rcvID := levels.Number(&rcv.HL, "21", srcID, len(rcv.Subscribers) > 0)
+			for k := range rcv.Subscribers {
+				sub := &rcv.Subscribers[k]
+				subID := levels.Number(&sub.HL, "22", rcvID, len(sub.Dependents) > 0)
+				bound(&sub.Name)
+				for l := range sub.Dependents {
+					levels.Number(&sub.Dependents[l].HL, "23", subID, false)
+					bound(&sub.Dependents[l].Name)
+				}
+			}
+		}
+	}
+	return model.Marshal("x271", hipaa.X279A1Response, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to the 271 of
+// 005010X279A1: loop structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X279A1Response, tx)
+}
@@ -0,0 +1,130 @@
+package x271_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x271"
+	"github.com/tmc/x12/segments"
+)
+
+// delims are the delimiters of the X279 examples, which separate
+// repetitions with ">".
+var delims = segments.Delimiters{Component: ":", Repetition: ">"}
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, filepath.Join("..", "..", "testdata", "005010x279-example-?[bc]-*.edi")), x271.FromTransaction, nil)
+}
+
+func TestBenefits(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x279-example-2b-response-generic-request-physician-patients-dependent-eligibility.edi"))
+	m, err := x271.FromTransaction(tx, delims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := m.Sources[0].Receivers[0].Subscribers[0]
+	if len(sub.Name.Benefits) != 0 {
+		t.Errorf("subscriber has %d benefits, want 0", len(sub.Name.Benefits))
+	}
+	dep := sub.Dependents[0].Name
+	if dep.Relationship == nil || dep.Relationship.RelationshipCode != "19" {
+		t.Errorf("INS = %+v, want relationship 19", dep.Relationship)
+	}
+	if len(dep.Benefits) != 5 {
+		t.Fatalf("dependent has %d benefits, want 5", len(dep.Benefits))
+	}
+	want := segments.EB{
+		InfoCode:      "B",
+		ServiceTypes:  []string{"1", "33", "35", "47", "86", "88", "98", "AL", "MH", "UC"},
+		InsuranceType: "HM",
+		PlanCoverage:  "GOLD 123 PLAN",
+		TimePeriod:    "27",
+		Amount:        "10",
+		InPlanNetwork: "Y",
+	}
+	if diff := cmp.Diff(want, dep.Benefits[3].Benefit); diff != "" {
+		t.Errorf("EB mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(dep.BenefitsFor("33")); got != 3 {
+		t.Errorf("BenefitsFor(33) returned %d benefits, want 3", got)
+	}
+	last := dep.Benefits[4]
+	if len(last.RelatedEntities) != 1 || last.RelatedEntities[0].Name.LastName != "JONES" || last.LoopHeader == nil || last.LoopTrailer == nil {
+		t.Errorf("related entities = %+v, LS %v, LE %v", last.RelatedEntities, last.LoopHeader, last.LoopTrailer)
+	}
+}
+
+func TestRejections(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x279-example-1c-error-response-payer-clinic-not-eligible-inquiries-payer.edi"))
+	m, err := x271.FromTransaction(tx, delims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []x271.Rejection{{LoopID: "2100B", AAA: segments.AAA{ValidRequest: "Y", RejectReason: "50", FollowUpAction: "N"}}}
+	if diff := cmp.Diff(want, m.Rejections()); diff != "" {
+		t.Errorf("Rejections() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x271.Transaction{
+		ControlNumber: "0002",
+		BHT:           segments.BHT{StructureCode: "0022", PurposeCode: "11", ReferenceID: "REQ1", Date: "20240105", Time: "0901"},
+		Sources: []x271.Source{{
+			Name: x271.Entity{Name: segments.NM1{EntityIdentifierCode: "PR", EntityTypeQualifier: "2", LastName: "PAYER", IDQualifier: "PI", ID: "12345"}},
+			Receivers: []x271.Receiver{{
+				Name: x271.Entity{Name: segments.NM1{EntityIdentifierCode: "1P", EntityTypeQualifier: "2", LastName: "CLINIC", IDQualifier: "XX", ID: "1234567893"}},
+				Subscribers: []x271.Subscriber{{
+					Traces: []segments.TRN{{TypeCode: "2", ReferenceID: "TRACE1", OriginatorID: "9999999999"}},
+					Name: x271.Member{
+						Name: segments.NM1{EntityIdentifierCode: "IL", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JANE", IDQualifier: "MI", ID: "W123"},
+						Benefits: []x271.Benefit{
+							{Benefit: segments.EB{InfoCode: "1", ServiceTypes: []string{"30"}}},
+							{
+								Benefit:         segments.EB{InfoCode: "A", ServiceTypes: []string{"48", "50"}, Percent: ".2"},
+								Messages:        []segments.MSG{{Text: "AFTER DEDUCTIBLE"}},
+								RelatedEntities: []x271.Entity{{Name: segments.NM1{EntityIdentifierCode: "P3", EntityTypeQualifier: "1", LastName: "SMITH", IDQualifier: "XX", ID: "1999999992"}}},
+							},
+						},
+					},
+				}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BHT*0022*11*REQ1*20240105*0901",
+		"HL*1**20*1",
+		"NM1*PR*2*PAYER*****PI*12345",
+		"HL*2*1*21*1",
+		"NM1*1P*2*CLINIC*****XX*1234567893",
+		"HL*3*2*22*0",
+		"TRN*2*TRACE1*9999999999",
+		"NM1*IL*1*DOE*JANE****MI*W123",
+		"EB*1**30",
+		"EB*A**48^50*****.2",
+		"MSG*AFTER DEDUCTIBLE",
+		"LS*2120",
+		"NM1*P3*1*SMITH*****XX*1999999992",
+		"LE*2120",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+// Package x275 is a typed model of the Additional Information to
+// Support a Health Care Claim or Encounter (275) transaction,
+// implementation guide 005010X210: claim attachments.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X210 and
+// maps them to a Transaction: the payer, submitter, provider, and
+// patient, and the attachments, each a document such as a CDA or PDF
+// file carried verbatim in a binary data segment (BIN). An attachment
+// is linked to its claim by the patient control number (REF*EJ, the
+// claim's CLM01) and by its attachment control number (TRN02, the
+// claim's PWK06). Payload reads an attachment's document, and
+// NewAttachment builds one around a file. ToTransaction writes the
+// segments back in guide order, so a transaction that conforms to the
+// guide's loop structure round-trips unchanged.
+package x275
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is a 275 additional information transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X210" unless read without one
+	read          bool   // made by FromTransaction
+
+	BGN         segments.BGN `x12:"BGN"`
+	Payer       Party        `x12:"1000A"`
+	Submitter   Party        `x12:"1000B"`
+	Provider    Party        `x12:"1000C"`
+	Patient     Patient      `x12:"1000D"`
+	Attachments []Attachment `x12:"2000A"`
+}
+
+// A Party is the Payer (1000A), Submitter (1000B), or Provider (1000C)
+// Name loop.
+type Party struct {
+	Name    segments.NM1  `x12:"NM1"`
+	Contact *segments.PER `x12:"PER"`
+}
+
+// A Patient is the Patient Name loop (1000D), which identifies the claim
+// the attachments support.
+type Patient struct {
+	Name          segments.NM1  `x12:"NM1"`
+	ControlNumber *segments.REF `x12:"REF,EJ"`
+	MedicalRecord *segments.REF `x12:"REF,EA"`
+	ServiceDate   *segments.DTP `x12:"DTP"`
+}
+
+// An Attachment is the Assigned Number loop (2000A): one document.
+type Attachment struct {
+	Number      segments.LX  `x12:"LX"`
+	Trace       segments.TRN `x12:"TRN"`
+	Information Information  `x12:"2100A"`
+}
+
+// An Information is the Additional Information loop (2100A): the kind
+// of document (CAT), its format (EFI), and its data (BIN).
+type Information struct {
+	Category x12.Segment  `x12:"CAT"`
+	Format   x12.Segment  `x12:"EFI"`
+	Data     segments.BIN `x12:"BIN"`
+}
+
+// PatientControlNumber returns the patient control number (REF02 of
+// REF*EJ) of the claim t supports, its CLM01, or "".
+func (t *Transaction) PatientControlNumber() string {
+	if t.Patient.ControlNumber == nil {
+		return ""
+	}
+	return t.Patient.ControlNumber.ID
+}
+
+// ControlNumber returns the attachment control number (TRN02), which the
+// claim it supports reports in a PWK06.
+func (a *Attachment) ControlNumber() string {
+	return a.Trace.ReferenceID
+}
+
+// Payload returns a reader of a's document, the binary data of its BIN
+// segment.
+func (a *Attachment) Payload() io.Reader {
+	return strings.NewReader(a.Information.Data.Data)
+}
+
+// NewAttachment returns an attachment of the document read from r,
+// identified by controlNumber, the attachment control number the
+// claim's PWK06 reports. The guide also requires the attachment's
+// Information.Category and Information.Format, which describe the
+// document's content and format.
+func NewAttachment(controlNumber string, r io.Reader) (*Attachment, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("x275: reading attachment: %w", err)
+	}
+	return &Attachment{
+		Trace: segments.TRN{TypeCode: "2", ReferenceID: controlNumber},
+		Information: Information{
+			Data: segments.BIN{Length: strconv.Itoa(len(data)), Data: string(data)},
+		},
+	}, nil
+}
+
+// FromTransaction returns the model of tx, a 275 transaction of
+// 005010X210. Elements are split into components and repetitions with d.
+// It returns an error if a segment is out of place for the guide's loop
+// structure, or if a BIN segment's data is not as long as its BIN01
+// says.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x275", hipaa.X210, tx, t, d); err != nil {
+		return nil, err
+	}
+	for i := range t.Attachments {
+		bin := &t.Attachments[i].Information.Data
+		if bin.Length != strconv.Itoa(len(bin.Data)) {
+			return nil, fmt.Errorf("%w: x275: attachment %d: BIN01 is %s, data is %d bytes", x12.ErrInvalidFormat, i+1, bin.Length, len(bin.Data))
+		}
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 275 transaction, with ST and SE segments
+// built from ControlNumber and Version. It numbers the attachments
+// without an LX01 in order, and sets each BIN01 to its data's length, in
+// a copy of t: t is left unchanged.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	for i := range t.Attachments {
+		a := &t.Attachments[i]
+		if a.Number.Number == "" {
+			a.Number.Number = strconv.Itoa(i + 1)
+		}
+		a.Information.Data.Length = strconv.Itoa(len(a.Information.Data.Data))
+	}
+	return model.Marshal("x275", hipaa.X210, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X210: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X210, tx)
+}
@@ -0,0 +1,134 @@
+package x275_test
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x275"
+	"github.com/tmc/x12/segments"
+)
+
+// document is an attachment whose data holds the default delimiters and
+// line breaks.
+const document = "%PDF-1.4\n1 0 obj*<<>>~\nendobj\n%%EOF\n"
+
+// attachment is an unsolicited 275 carrying document for claim 26463774.
+var attachment = `ST*275*0001*005010X210~
+BGN*02*0001*20240601~
+NM1*PR*2*ABC INSURANCE*****PI*12345~
+NM1*41*2*ACME BILLING*****46*999888777~
+NM1*85*2*BEN KILDARE SERVICE*****XX*9876543210~
+NM1*QC*1*SMITH*TED~
+REF*EJ*26463774~
+DTP*472*D8*20240515~
+LX*1~
+TRN*2*ATT0001~
+CAT*AE*IA*11506-3~
+EFI*05~
+BIN*` + strconv.Itoa(len(document)) + `*` + document + `~
+SE*14*0001~`
+
+func TestRoundTrip(t *testing.T) {
+	tx := hipaatest.Decode(t, attachment)
+	m, err := x275.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() error: %v", err)
+	}
+	got, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("ToTransaction() error: %v", err)
+	}
+	if diff := cmp.Diff(tx, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestAttachment(t *testing.T) {
+	m, err := x275.FromTransaction(hipaatest.Decode(t, attachment), segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.PatientControlNumber(); got != "26463774" {
+		t.Errorf("PatientControlNumber() = %q, want %q", got, "26463774")
+	}
+	if len(m.Attachments) != 1 {
+		t.Fatalf("got %d attachments, want 1", len(m.Attachments))
+	}
+	a := &m.Attachments[0]
+	if got := a.ControlNumber(); got != "ATT0001" {
+		t.Errorf("ControlNumber() = %q, want %q", got, "ATT0001")
+	}
+	data, err := io.ReadAll(a.Payload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != document {
+		t.Errorf("Payload() = %q, want %q", data, document)
+	}
+
+	tx := hipaatest.Decode(t, attachment)
+	bin := &tx.Segments[len(tx.Segments)-1]
+	bin.Elements[0].Value = strconv.Itoa(len(document) + 10)
+	if _, err := x275.FromTransaction(tx, segments.DefaultDelimiters); !errors.Is(err, x12.ErrInvalidFormat) {
+		t.Errorf("FromTransaction() of a short BIN = %v, want %v", err, x12.ErrInvalidFormat)
+	}
+}
+
+func TestNewAttachment(t *testing.T) {
+	a, err := x275.NewAttachment("ATT0002", strings.NewReader(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Information.Category = x12.Segment{ID: "CAT", Elements: []x12.Element{{Value: "AE"}, {Value: "IA"}, {Value: "11506-3"}}}
+	a.Information.Format = x12.Segment{ID: "EFI", Elements: []x12.Element{{Value: "05"}}}
+	m := &x275.Transaction{
+		ControlNumber: "0002",
+		BGN:           segments.BGN{PurposeCode: "02", ReferenceID: "0002", Date: "20240601"},
+		Payer:         x275.Party{Name: segments.NM1{EntityIdentifierCode: "PR", EntityTypeQualifier: "2", LastName: "ABC INSURANCE", IDQualifier: "PI", ID: "12345"}},
+		Submitter:     x275.Party{Name: segments.NM1{EntityIdentifierCode: "41", EntityTypeQualifier: "2", LastName: "ACME BILLING", IDQualifier: "46", ID: "999888777"}},
+		Provider:      x275.Party{Name: segments.NM1{EntityIdentifierCode: "85", EntityTypeQualifier: "2", LastName: "BEN KILDARE SERVICE", IDQualifier: "XX", ID: "9876543210"}},
+		Patient: x275.Patient{
+			Name:          segments.NM1{EntityIdentifierCode: "QC", EntityTypeQualifier: "1", LastName: "SMITH", FirstName: "TED"},
+			ControlNumber: &segments.REF{Qualifier: "EJ", ID: "26463774"},
+		},
+		Attachments: []x275.Attachment{*a},
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &x12.Document{
+		EnvelopeAutomaticallyAdded: true,
+		Interchange:                &x12.Interchange{FunctionGroups: []*x12.FunctionGroup{{Transactions: []*x12.Transaction{tx}}}},
+	}
+	out, err := x12.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := x275.FromTransaction(hipaatest.Decode(t, string(out)), segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() of the encoded transaction: %v", err)
+	}
+	if back.Attachments[0].Number.Number != "1" || back.Attachments[0].ControlNumber() != "ATT0002" {
+		t.Errorf("attachment = %+v", back.Attachments[0])
+	}
+	data, err := io.ReadAll(back.Attachments[0].Payload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != document {
+		t.Errorf("Payload() = %q, want %q", data, document)
+	}
+}
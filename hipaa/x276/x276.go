@@ -0,0 +1,171 @@
+// Package x276 is a typed model of the Health Care Claim Status Request
+// (276) transaction, implementation guide 005010X212.
+//
+// FromTransaction arranges a transaction's segments with
+// hipaa.X212Request and maps them to a Transaction: the hierarchy of
+// information sources (payers), information receivers, service
+// providers, subscribers, and dependents, and the claims asked about
+// for each subscriber and dependent. Each claim carries the trace number
+// (TRN) the payer echoes in its 277 response; package x277 models the
+// response and pairs its claims with the request's. ToTransaction writes
+// the segments back in guide order, so a transaction that conforms to
+// the guide's loop structure round-trips unchanged.
+package x276
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is a 276 claim status request transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X212" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT     segments.BHT `x12:"BHT"`
+	Sources []Source     `x12:"2000A"`
+}
+
+// A Source is the Information Source Level (loop 2000A): the payer
+// asked about the claims, and the receivers asking it.
+type Source struct {
+	HL        segments.HL    `x12:"HL"`
+	Name      segments.Party `x12:"2100A"`
+	Receivers []Receiver     `x12:"2000B"`
+}
+
+// A Receiver is the Information Receiver Level (loop 2000B): the
+// submitter of the request, and the providers it asks for.
+type Receiver struct {
+	HL        segments.HL    `x12:"HL"`
+	Name      segments.Party `x12:"2100B"`
+	Providers []Provider     `x12:"2000C"`
+}
+
+// A Provider is the Service Provider Level (loop 2000C): the provider
+// that billed the claims, and the subscribers they were billed for.
+type Provider struct {
+	HL          segments.HL    `x12:"HL"`
+	Name        segments.Party `x12:"2100C"`
+	Subscribers []Subscriber   `x12:"2000D"`
+}
+
+// A Subscriber is the Subscriber Level (loop 2000D). Claims holds the
+// subscriber's own claims, and Dependents the claims of the subscriber's
+// dependents.
+type Subscriber struct {
+	HL           segments.HL    `x12:"HL"`
+	Demographics *segments.DMG  `x12:"DMG"`
+	Name         segments.Party `x12:"2100D"`
+	Claims       []Claim        `x12:"2200D"`
+	Dependents   []Dependent    `x12:"2000E"`
+}
+
+// A Dependent is the Dependent Level (loop 2000E).
+type Dependent struct {
+	HL           segments.HL    `x12:"HL"`
+	Demographics *segments.DMG  `x12:"DMG"`
+	Name         segments.Party `x12:"2100E"`
+	Claims       []Claim        `x12:"2200E"`
+}
+
+// A Claim is the Claim Status Tracking Number loop (2200D or 2200E):
+// the claim asked about, identified by Trace and by the references and
+// amounts the payer matches it with, and the service lines asked about.
+type Claim struct {
+	Trace      segments.TRN   `x12:"TRN"`
+	References []segments.REF `x12:"REF"`
+	Charge     *segments.AMT  `x12:"AMT"`
+	Date       *segments.DTP  `x12:"DTP"`
+	Services   []Service      `x12:"2210D,2210E"`
+}
+
+// A Service is the Service Line Information loop (2210D or 2210E).
+// SVC07 holds the units of service billed.
+type Service struct {
+	Service   segments.SVC  `x12:"SVC"`
+	Reference *segments.REF `x12:"REF"`
+	Date      *segments.DTP `x12:"DTP"`
+}
+
+// Claims returns the claims asked about in t, subscribers' and
+// dependents' alike, in transaction order.
+func (t *Transaction) Claims() []*Claim {
+	var cs []*Claim
+	add := func(claims []Claim) {
+		for i := range claims {
+			cs = append(cs, &claims[i])
+		}
+	}
+	for i := range t.Sources {
+		for j := range t.Sources[i].Receivers {
+			for k := range t.Sources[i].Receivers[j].Providers {
+				prv := &t.Sources[i].Receivers[j].Providers[k]
+				for l := range prv.Subscribers {
+					sub := &prv.Subscribers[l]
+					add(sub.Claims)
+					for m := range sub.Dependents {
+						add(sub.Dependents[m].Claims)
+					}
+				}
+			}
+		}
+	}
+	return cs
+}
+
+// FromTransaction returns the model of tx, a 276 transaction of
+// 005010X212. Elements are split into components and repetitions with
+// d. It returns an error if a segment is out of place for the guide's
+// loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x276", hipaa.X212Request, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 276 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged: levels without an HL01 are
+// numbered in order, and HL02, HL03, and HL04 follow from the model's
+// nesting. Dependent levels are left without an HL04, which the guide
+// does not use.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	for i := range t.Sources {
+		src := &t.Sources[i]
+		srcID := levels.Number(&src.HL, "20", "", len(src.Receivers) > 0)
+		for j := range src.Receivers {
+			rcv := &src.Receivers[j]
+			rcvID := levels.Number(&rcv.HL, "21", srcID, len(rcv.Providers) > 0)
+			for k := range rcv.Providers {
+				prv := &rcv.Providers[k]
+				prvID := levels.Number(&prv.HL, "19", rcvID, len(prv.Subscribers) > 0)
+				for l := range prv.Subscribers {
+					sub := &prv.Subscribers[l]
+					subID := levels.Number(&sub.HL, "22", prvID, len(sub.Dependents) > 0)
+					for m := range sub.Dependents {
+						levels.Leaf(&sub.Dependents[m].HL, "23", subID)
+					}
+				}
+			}
+		}
+	}
+	return model.Marshal("x276", hipaa.X212Request, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to the 276 of
+// 005010X212: loop structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X212Request, tx)
+}
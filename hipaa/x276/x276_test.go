@@ -0,0 +1,103 @@
+package x276_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x276"
+	"github.com/tmc/x12/segments"
+)
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, filepath.Join("..", "..", "testdata", "005010x212-example-*-276-*.edi")), x276.FromTransaction, nil)
+}
+
+func TestClaims(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x212-example-1a-276-request-transmission.edi"))
+	m, err := x276.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var traces []string
+	for _, c := range m.Claims() {
+		traces = append(traces, c.Trace.ReferenceID)
+	}
+	if diff := cmp.Diff([]string{"ABCXYZ1", "ABCXYZ2", "ABCXYZ3"}, traces); diff != "" {
+		t.Errorf("claim traces mismatch (-want +got):\n%s", diff)
+	}
+	dep := m.Sources[0].Receivers[0].Providers[1].Subscribers[0].Dependents[0]
+	if dep.Name.Name.FirstName != "JOSEPH" || dep.Demographics.BirthDate != "19951101" {
+		t.Errorf("dependent = %+v, %+v", dep.Name.Name, dep.Demographics)
+	}
+	svc := dep.Claims[0].Services[0]
+	if svc.Service.Procedure.Code != "99203" || svc.Service.OriginalUnits != "1" || svc.Date.Period != "20050501" {
+		t.Errorf("service = %+v", svc)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x276.Transaction{
+		ControlNumber: "0001",
+		BHT:           segments.BHT{StructureCode: "0010", PurposeCode: "13", ReferenceID: "REQ1", Date: "20240105", Time: "0900"},
+		Sources: []x276.Source{{
+			Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "PR", EntityTypeQualifier: "2", LastName: "PAYER", IDQualifier: "PI", ID: "12345"}},
+			Receivers: []x276.Receiver{{
+				Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "41", EntityTypeQualifier: "2", LastName: "CLEARINGHOUSE", IDQualifier: "46", ID: "X1"}},
+				Providers: []x276.Provider{{
+					Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "1P", EntityTypeQualifier: "2", LastName: "CLINIC", IDQualifier: "XX", ID: "1234567893"}},
+					Subscribers: []x276.Subscriber{{
+						Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "IL", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JOHN", IDQualifier: "MI", ID: "W123"}},
+						Dependents: []x276.Dependent{{
+							Demographics: &segments.DMG{FormatQualifier: "D8", BirthDate: "20100101", Gender: "F"},
+							Name:         segments.Party{Name: segments.NM1{EntityIdentifierCode: "QC", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JANE"}},
+							Claims: []x276.Claim{{
+								Trace:      segments.TRN{TypeCode: "1", ReferenceID: "TRACE1"},
+								References: []segments.REF{{Qualifier: "EJ", ID: "PCN1"}},
+								Charge:     &segments.AMT{Qualifier: "T3", Amount: "100"},
+								Date:       &segments.DTP{Qualifier: "472", FormatQualifier: "D8", Period: "20240101"},
+							}},
+						}},
+					}},
+				}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BHT*0010*13*REQ1*20240105*0900",
+		"HL*1**20*1",
+		"NM1*PR*2*PAYER*****PI*12345",
+		"HL*2*1*21*1",
+		"NM1*41*2*CLEARINGHOUSE*****46*X1",
+		"HL*3*2*19*1",
+		"NM1*1P*2*CLINIC*****XX*1234567893",
+		"HL*4*3*22*1",
+		"NM1*IL*1*DOE*JOHN****MI*W123",
+		"HL*5*4*23",
+		"DMG*D8*20100101*F",
+		"NM1*QC*1*DOE*JANE",
+		"TRN*1*TRACE1",
+		"REF*EJ*PCN1",
+		"AMT*T3*100",
+		"DTP*472*D8*20240101",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Package x277 is a typed model of the Health Care Claim Status Response
+// (277) transaction, implementation guide 005010X212.
+//
+// FromTransaction arranges a transaction's segments with
+// hipaa.X212Response and maps them to a Transaction: the hierarchy of
+// information sources (payers), information receivers, service
+// providers, subscribers, and dependents, and the status of each claim
+// and service line reported on. Status information (STC) is split into
+// its claim status composites, each a category, status, and entity
+// code. Claims carry the trace number (TRN) of the 276 claim they
+// answer, by which Transaction.Match pairs them with the request.
+// ToTransaction writes the segments back in guide order, so a
+// transaction that conforms to the guide's loop structure round-trips
+// unchanged.
+package x277
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/hipaa/x276"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is a 277 claim status response transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X212" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT     segments.BHT `x12:"BHT"`
+	Sources []Source     `x12:"2000A"`
+}
+
+// A Source is the Information Source Level (loop 2000A): the payer
+// reporting claim status, and the receivers it reports to.
+type Source struct {
+	HL        segments.HL    `x12:"HL"`
+	Name      segments.Party `x12:"2100A"`
+	Receivers []Receiver     `x12:"2000B"`
+}
+
+// A Receiver is the Information Receiver Level (loop 2000B). Status is
+// set when the payer reports on the receiver's request as a whole, as
+// when rejecting it.
+type Receiver struct {
+	HL        segments.HL    `x12:"HL"`
+	Name      segments.Party `x12:"2100B"`
+	Status    *RequestStatus `x12:"2200B"`
+	Providers []Provider     `x12:"2000C"`
+}
+
+// A Provider is the Service Provider Level (loop 2000C). Status is set
+// when the payer reports on the provider as a whole, as when it does
+// not recognize the provider.
+type Provider struct {
+	HL          segments.HL    `x12:"HL"`
+	Name        segments.Party `x12:"2100C"`
+	Status      *RequestStatus `x12:"2200C"`
+	Subscribers []Subscriber   `x12:"2000D"`
+}
+
+// A RequestStatus is the trace identifier loop of a receiver or provider
+// (2200B or 2200C): a trace number and the statuses reported for it.
+type RequestStatus struct {
+	Trace    segments.TRN   `x12:"TRN"`
+	Statuses []segments.STC `x12:"STC"`
+}
+
+// A Subscriber is the Subscriber Level (loop 2000D). Claims holds the
+// subscriber's own claims, and Dependents the claims of the subscriber's
+// dependents.
+type Subscriber struct {
+	HL         segments.HL    `x12:"HL"`
+	Name       segments.Party `x12:"2100D"`
+	Claims     []Claim        `x12:"2200D"`
+	Dependents []Dependent    `x12:"2000E"`
+}
+
+// A Dependent is the Dependent Level (loop 2000E).
+type Dependent struct {
+	HL     segments.HL    `x12:"HL"`
+	Name   segments.Party `x12:"2100E"`
+	Claims []Claim        `x12:"2200E"`
+}
+
+// A Claim is the Claim Status Tracking Number loop (2200D or 2200E):
+// the status of one claim, with Trace echoing the request's trace
+// number, and the status of its service lines.
+type Claim struct {
+	Trace      segments.TRN   `x12:"TRN"`
+	Statuses   []segments.STC `x12:"STC"`
+	References []segments.REF `x12:"REF"`
+	Date       *segments.DTP  `x12:"DTP"`
+	Services   []Service      `x12:"2220D,2220E"`
+}
+
+// A Service is the Service Line Information loop (2220D or 2220E).
+type Service struct {
+	Service    segments.SVC   `x12:"SVC"`
+	Statuses   []segments.STC `x12:"STC"`
+	References []segments.REF `x12:"REF"`
+	Date       *segments.DTP  `x12:"DTP"`
+}
+
+// Claims returns the claims reported on in t, subscribers' and
+// dependents' alike, in transaction order.
+func (t *Transaction) Claims() []*Claim {
+	var cs []*Claim
+	add := func(claims []Claim) {
+		for i := range claims {
+			cs = append(cs, &claims[i])
+		}
+	}
+	for i := range t.Sources {
+		for j := range t.Sources[i].Receivers {
+			for k := range t.Sources[i].Receivers[j].Providers {
+				prv := &t.Sources[i].Receivers[j].Providers[k]
+				for l := range prv.Subscribers {
+					sub := &prv.Subscribers[l]
+					add(sub.Claims)
+					for m := range sub.Dependents {
+						add(sub.Dependents[m].Claims)
+					}
+				}
+			}
+		}
+	}
+	return cs
+}
+
+// ClaimByTrace returns the first claim whose trace number (TRN02) is id,
+// or nil if there is none.
+func (t *Transaction) ClaimByTrace(id string) *Claim {
+	for _, c := range t.Claims() {
+		if c.Trace.ReferenceID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// A Pair is a claim of a 276 request and the claim of a 277 answering
+// it. Either is nil when the other has no counterpart.
+type Pair struct {
+	Request  *x276.Claim
+	Response *Claim
+}
+
+// Match pairs the claims of req with the claims of t that answer them,
+// by trace number (TRN02). It returns a pair for each claim of req, in
+// request order, followed by a pair for each claim of t answering none
+// of them.
+func (t *Transaction) Match(req *x276.Transaction) []Pair {
+	byTrace := make(map[string]*Claim)
+	var responses []*Claim
+	for _, c := range t.Claims() {
+		if _, ok := byTrace[c.Trace.ReferenceID]; !ok {
+			byTrace[c.Trace.ReferenceID] = c
+		}
+		responses = append(responses, c)
+	}
+	var pairs []Pair
+	answered := make(map[*Claim]bool)
+	for _, rc := range req.Claims() {
+		c := byTrace[rc.Trace.ReferenceID]
+		if c != nil {
+			answered[c] = true
+		}
+		pairs = append(pairs, Pair{Request: rc, Response: c})
+	}
+	for _, c := range responses {
+		if !answered[c] {
+			pairs = append(pairs, Pair{Response: c})
+		}
+	}
+	return pairs
+}
+
+// FromTransaction returns the model of tx, a 277 transaction of
+// 005010X212. Elements are split into components and repetitions with
+// d. It returns an error if a segment is out of place for the guide's
+// loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x277", hipaa.X212Response, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 277 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged, as x276's ToTransaction
+// does.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	for i := range t.Sources {
+		src := &t.Sources[i]
+		srcID := levels.Number(&src.HL, "20", "", len(src.Receivers) > 0)
+		for j := range src.Receivers {
+			rcv := &src.Receivers[j]
+			rcvID := levels.Number(&rcv.HL, "21", srcID, len(rcv.Providers) > 0)
+			for k := range rcv.Providers {
+				prv := &rcv.Providers[k]
+				prvID := levels.Number(&prv.HL, "19", rcvID, len(prv.Subscribers) > 0)
+				for l := range prv.Subscribers {
+					sub := &prv.Subscribers[l]
+					subID := levels.Number(&sub.HL, "22", prvID, len(sub.Dependents) > 0)
+					for m := range sub.Dependents {
+						levels.Leaf(&sub.Dependents[m].HL, "23", subID)
+					}
+				}
+			}
+		}
+	}
+	return model.Marshal("x277", hipaa.X212Response, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to the 277 of
+// 005010X212: loop structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X212Response, tx)
+}
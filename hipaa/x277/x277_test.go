@@ -0,0 +1,146 @@
+package x277_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x276"
+	"github.com/tmc/x12/hipaa/x277"
+	"github.com/tmc/x12/segments"
+)
+
+func response(t *testing.T, name string) *x277.Transaction {
+	t.Helper()
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", name))
+	m, err := x277.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, filepath.Join("..", "..", "testdata", "005010x212-example-*-277-*.edi")), x277.FromTransaction, nil)
+}
+
+func TestStatus(t *testing.T) {
+	m := response(t, "005010x212-example-1b-277-response-transmission.edi")
+	c := m.ClaimByTrace("ABCXYZ2")
+	if c == nil {
+		t.Fatal("ClaimByTrace(ABCXYZ2) = nil")
+	}
+	want := []segments.STC{{Status: segments.ClaimStatus{Category: "F0", Status: "3"}, Date: "20050915", TotalCharge: "7599", Payment: "7599"}}
+	if diff := cmp.Diff(want, c.Statuses); diff != "" {
+		t.Errorf("claim statuses mismatch (-want +got):\n%s", diff)
+	}
+
+	dep := m.Sources[0].Receivers[0].Providers[1].Subscribers[0].Dependents[0]
+	svc := dep.Claims[0].Services[0]
+	if diff := cmp.Diff([]segments.ClaimStatus{{Category: "F2", Status: "88", EntityCode: "QC"}}, svc.Statuses[0].Statuses()); diff != "" {
+		t.Errorf("service statuses mismatch (-want +got):\n%s", diff)
+	}
+
+	m = response(t, "005010x212-example-2b-277-response-transmission.edi")
+	prv := m.Sources[0].Receivers[0].Providers[1]
+	want = []segments.STC{{Status: segments.ClaimStatus{Category: "E0", Status: "24", EntityCode: "1P"}, Date: "20050916"}}
+	if prv.Status == nil || prv.Status.Trace.ReferenceID != "0" {
+		t.Fatalf("provider status = %+v", prv.Status)
+	}
+	if diff := cmp.Diff(want, prv.Status.Statuses); diff != "" {
+		t.Errorf("provider statuses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x212-example-1a-276-request-transmission.edi"))
+	req, err := x276.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := response(t, "005010x212-example-1b-277-response-transmission.edi")
+	type pair struct{ Request, Response string }
+	var got []pair
+	for _, p := range res.Match(req) {
+		var q pair
+		if p.Request != nil {
+			q.Request = p.Request.Trace.ReferenceID
+		}
+		if p.Response != nil {
+			q.Response = p.Response.Trace.ReferenceID
+		}
+		got = append(got, q)
+	}
+	// The example answers ABCXYZ3 with the trace number ABCXYC3.
+	want := []pair{
+		{"ABCXYZ1", "ABCXYZ1"},
+		{"ABCXYZ2", "ABCXYZ2"},
+		{"ABCXYZ3", ""},
+		{"", "ABCXYC3"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Match() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x277.Transaction{
+		ControlNumber: "0001",
+		BHT:           segments.BHT{StructureCode: "0010", PurposeCode: "08", ReferenceID: "RES1", Date: "20240106", Time: "1000", TransactionType: "DG"},
+		Sources: []x277.Source{{
+			Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "PR", EntityTypeQualifier: "2", LastName: "PAYER", IDQualifier: "PI", ID: "12345"}},
+			Receivers: []x277.Receiver{{
+				Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "41", EntityTypeQualifier: "2", LastName: "CLEARINGHOUSE", IDQualifier: "46", ID: "X1"}},
+				Providers: []x277.Provider{{
+					Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "1P", EntityTypeQualifier: "2", LastName: "CLINIC", IDQualifier: "XX", ID: "1234567893"}},
+					Subscribers: []x277.Subscriber{{
+						Name: segments.Party{Name: segments.NM1{EntityIdentifierCode: "IL", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JOHN", IDQualifier: "MI", ID: "W123"}},
+						Claims: []x277.Claim{{
+							Trace: segments.TRN{TypeCode: "2", ReferenceID: "TRACE1"},
+							Statuses: []segments.STC{{
+								Status:      segments.ClaimStatus{Category: "F1", Status: "65"},
+								Date:        "20240106",
+								TotalCharge: "100",
+								Payment:     "80",
+							}},
+							References: []segments.REF{{Qualifier: "1K", ID: "PAYER1"}},
+						}},
+					}},
+				}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BHT*0010*08*RES1*20240106*1000*DG",
+		"HL*1**20*1",
+		"NM1*PR*2*PAYER*****PI*12345",
+		"HL*2*1*21*1",
+		"NM1*41*2*CLEARINGHOUSE*****46*X1",
+		"HL*3*2*19*1",
+		"NM1*1P*2*CLINIC*****XX*1234567893",
+		"HL*4*3*22*0",
+		"NM1*IL*1*DOE*JOHN****MI*W123",
+		"TRN*2*TRACE1",
+		"STC*F1:65*20240106**100*80",
+		"REF*1K*PAYER1",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+// Package x278 is a typed model of the Health Care Services Review (278)
+// request and response transactions, implementation guide 005010X217.
+//
+// The request and response share their loop structure, so one
+// Transaction models both: BHT02 tells them apart, and the segments
+// only a response carries, the review decision (HCR), request
+// validation errors (AAA), and messages (MSG), are empty in a request.
+// FromTransaction arranges a transaction's segments with
+// hipaa.X217Request or hipaa.X217Response and maps them to a
+// Transaction: the utilization management organization (UMO), the
+// requester, the subscriber and dependent, and the patient event (UM)
+// and services under review, each with the providers involved.
+// ToTransaction writes the segments back in guide order, so a
+// transaction that conforms to the guide's loop structure round-trips
+// unchanged.
+//
+// A response echoes the trace numbers (TRN) of the request it answers;
+// Match pairs responses with their requests by them.
+package x278
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+)
+
+// BHT02 values marking a request and a response.
+const (
+	PurposeRequest  = "13"
+	PurposeResponse = "11"
+)
+
+// A Transaction is a 278 services review request or response
+// transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X217" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT segments.BHT `x12:"BHT"`
+	UMO UMO          `x12:"2000A"`
+}
+
+// A UMO is the Utilization Management Organization (UMO) Level (loop
+// 2000A): the payer or its delegate reviewing the request.
+type UMO struct {
+	HL        segments.HL    `x12:"HL"`
+	Errors    []segments.AAA `x12:"AAA"`
+	Name      UMOName        `x12:"2010A"`
+	Requester Requester      `x12:"2000B"`
+}
+
+// A UMOName is the UMO Name loop (2010A).
+type UMOName struct {
+	Name     segments.NM1   `x12:"NM1"`
+	Contacts []segments.PER `x12:"PER"`
+	Errors   []segments.AAA `x12:"AAA"`
+}
+
+// A Requester is the Requester Level (loop 2000B): the provider asking
+// for the review, and the subscriber it concerns.
+type Requester struct {
+	HL         segments.HL `x12:"HL"`
+	Name       Entity      `x12:"2010B"`
+	Subscriber Subscriber  `x12:"2000C"`
+}
+
+// A Subscriber is the Subscriber Level (loop 2000C). Event is set when
+// the subscriber is the patient, and Dependent when a dependent is.
+type Subscriber struct {
+	HL        segments.HL    `x12:"HL"`
+	Errors    []segments.AAA `x12:"AAA"`
+	Name      Member         `x12:"2010C"`
+	Event     *Event         `x12:"2000E"`
+	Dependent *Dependent     `x12:"2000D"`
+}
+
+// A Dependent is the Dependent Level (loop 2000D).
+type Dependent struct {
+	HL     segments.HL    `x12:"HL"`
+	Errors []segments.AAA `x12:"AAA"`
+	Name   Member         `x12:"2010D"`
+	Event  *Event         `x12:"2000E"`
+}
+
+// A Member is the name loop of a subscriber or dependent (2010C or
+// 2010D).
+type Member struct {
+	Name         segments.NM1   `x12:"NM1"`
+	References   []segments.REF `x12:"REF"`
+	Address      *segments.N3   `x12:"N3"`
+	City         *segments.N4   `x12:"N4"`
+	Errors       []segments.AAA `x12:"AAA"`
+	Demographics *segments.DMG  `x12:"DMG"`
+	Relationship *segments.INS  `x12:"INS"`
+}
+
+// An Entity is a name loop of the requester (2010B), of a provider of a
+// patient event (2010EA) or service (2010F), of a contact for additional
+// patient information (2010EB), or of a transport location (2010EC).
+type Entity struct {
+	Name       segments.NM1   `x12:"NM1"`
+	References []segments.REF `x12:"REF"`
+	Address    *segments.N3   `x12:"N3"`
+	City       *segments.N4   `x12:"N4"`
+	Contact    *segments.PER  `x12:"PER"`
+	Errors     []segments.AAA `x12:"AAA"`
+	Provider   *segments.PRV  `x12:"PRV"`
+}
+
+// An Event is the Patient Event Level (loop 2000E): the admission,
+// referral, or other event under review (UM), and in a response the
+// decision on it (HCR).
+type Event struct {
+	HL                 segments.HL    `x12:"HL"`
+	Traces             []segments.TRN `x12:"TRN"`
+	Errors             []segments.AAA `x12:"AAA"`
+	Review             segments.UM    `x12:"UM"`
+	Decision           *segments.HCR  `x12:"HCR"`
+	References         []segments.REF `x12:"REF"`
+	Dates              []segments.DTP `x12:"DTP"`
+	Diagnoses          *segments.HI   `x12:"HI"`
+	Delivery           *segments.HSD  `x12:"HSD"`
+	Conditions         []segments.CRC `x12:"CRC"`
+	Institutional      *segments.CL1  `x12:"CL1"`
+	Ambulance          *segments.CR1  `x12:"CR1"`
+	SpinalManipulation *x12.Segment   `x12:"CR2"`
+	HomeOxygen         *x12.Segment   `x12:"CR5"`
+	HomeHealth         *x12.Segment   `x12:"CR6"`
+	Paperwork          []segments.PWK `x12:"PWK"`
+	Message            *segments.MSG  `x12:"MSG"`
+	Providers          []Entity       `x12:"2010EA"`
+	Contact            *Entity        `x12:"2010EB"`
+	Transport          []Entity       `x12:"2010EC"`
+	Services           []Service      `x12:"2000F"`
+}
+
+// A Service is the Service Level (loop 2000F): one service under review
+// as part of a patient event.
+type Service struct {
+	HL            segments.HL    `x12:"HL"`
+	Traces        []segments.TRN `x12:"TRN"`
+	Errors        []segments.AAA `x12:"AAA"`
+	Review        *segments.UM   `x12:"UM"`
+	Decision      *segments.HCR  `x12:"HCR"`
+	References    []segments.REF `x12:"REF"`
+	Date          *segments.DTP  `x12:"DTP"`
+	Professional  *segments.SV1  `x12:"SV1"`
+	Institutional *segments.SV2  `x12:"SV2"`
+	Dental        *segments.SV3  `x12:"SV3"`
+	Teeth         []segments.TOO `x12:"TOO"`
+	Delivery      *segments.HSD  `x12:"HSD"`
+	Paperwork     []segments.PWK `x12:"PWK"`
+	Message       *segments.MSG  `x12:"MSG"`
+	Providers     []Entity       `x12:"2010F"`
+}
+
+// IsResponse reports whether t is a response, by its BHT02.
+func (t *Transaction) IsResponse() bool {
+	return t.BHT.PurposeCode == PurposeResponse
+}
+
+// Event returns the patient event of t, the subscriber's or the
+// dependent's, or nil if there is none.
+func (t *Transaction) Event() *Event {
+	sub := &t.UMO.Requester.Subscriber
+	if sub.Event != nil || sub.Dependent == nil {
+		return sub.Event
+	}
+	return sub.Dependent.Event
+}
+
+// Traces returns the trace numbers of t's patient event and services,
+// in transaction order.
+func (t *Transaction) Traces() []segments.TRN {
+	ev := t.Event()
+	if ev == nil {
+		return nil
+	}
+	trns := append([]segments.TRN(nil), ev.Traces...)
+	for _, svc := range ev.Services {
+		trns = append(trns, svc.Traces...)
+	}
+	return trns
+}
+
+// traceKey identifies a trace number across a request and response,
+// which differ in TRN01.
+type traceKey struct{ id, originator string }
+
+// Answers reports whether t, a response, answers the request req: whether
+// it echoes one of req's trace numbers (TRN02 and TRN03), or, when req
+// has none, whether it repeats req's BHT03 reference.
+func (t *Transaction) Answers(req *Transaction) bool {
+	reqTraces := req.Traces()
+	if len(reqTraces) == 0 {
+		return req.BHT.ReferenceID != "" && t.BHT.ReferenceID == req.BHT.ReferenceID
+	}
+	keys := make(map[traceKey]bool)
+	for _, trn := range reqTraces {
+		keys[traceKey{trn.ReferenceID, trn.OriginatorID}] = true
+	}
+	for _, trn := range t.Traces() {
+		if keys[traceKey{trn.ReferenceID, trn.OriginatorID}] {
+			return true
+		}
+	}
+	return false
+}
+
+// A Pair is a 278 request and the response answering it. Either is nil
+// when the other has no counterpart.
+type Pair struct {
+	Request  *Transaction
+	Response *Transaction
+}
+
+// Match pairs each of requests with the first remaining response that
+// answers it, as Transaction.Answers decides. It returns a pair for
+// each request, in order, followed by a pair for each response
+// answering none of them.
+func Match(requests, responses []*Transaction) []Pair {
+	used := make([]bool, len(responses))
+	var pairs []Pair
+	for _, req := range requests {
+		p := Pair{Request: req}
+		for i, res := range responses {
+			if !used[i] && res.Answers(req) {
+				used[i], p.Response = true, res
+				break
+			}
+		}
+		pairs = append(pairs, p)
+	}
+	for i, res := range responses {
+		if !used[i] {
+			pairs = append(pairs, Pair{Response: res})
+		}
+	}
+	return pairs
+}
+
+// schemaFor returns the guide for a 278 with the given BHT02.
+func schemaFor(purpose string) *schema.TransactionSet {
+	if purpose == PurposeResponse {
+		return hipaa.X217Response
+	}
+	return hipaa.X217Request
+}
+
+// FromTransaction returns the model of tx, a 278 request or response
+// transaction of 005010X217, as its BHT02 says. Elements are split into
+// components and repetitions with d. It returns an error if a segment
+// is out of place for the guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	var purpose string
+	if tx != nil && len(tx.Segments) > 0 && tx.Segments[0].ID == "BHT" && len(tx.Segments[0].Elements) > 1 {
+		purpose = tx.Segments[0].Elements[1].Value
+	}
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x278", schemaFor(purpose), tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 278 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged: levels without an HL01 are
+// numbered in order, and HL02, HL03, and HL04 follow from the model's
+// nesting.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	event := func(ev *Event, parent string) {
+		evID := levels.Number(&ev.HL, "EV", parent, len(ev.Services) > 0)
+		for i := range ev.Services {
+			levels.Number(&ev.Services[i].HL, "SS", evID, false)
+		}
+	}
+	umo := &t.UMO
+	umoID := levels.Number(&umo.HL, "20", "", true)
+	rqID := levels.Number(&umo.Requester.HL, "21", umoID, true)
+	sub := &umo.Requester.Subscriber
+	subID := levels.Number(&sub.HL, "22", rqID, sub.Event != nil || sub.Dependent != nil)
+	if sub.Event != nil {
+		event(sub.Event, subID)
+	}
+	if dep := sub.Dependent; dep != nil {
+		depID := levels.Number(&dep.HL, "23", subID, dep.Event != nil)
+		if dep.Event != nil {
+			event(dep.Event, depID)
+		}
+	}
+	return model.Marshal("x278", schemaFor(t.BHT.PurposeCode), t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to the 278 request or
+// response of 005010X217: loop structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(schemaFor(t.BHT.PurposeCode), tx)
+}
@@ -0,0 +1,167 @@
+package x278_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x278"
+	"github.com/tmc/x12/segments"
+)
+
+func fixtures(t *testing.T) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("..", "..", "testdata", "005010x217-example-*.edi"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no fixtures: %v", err)
+	}
+	return paths
+}
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, fixtures(t), x278.FromTransaction, func(t *testing.T, path string, m *x278.Transaction, _ *x12.Transaction) {
+		if want := strings.Contains(path, "b-"); m.IsResponse() != want {
+			t.Errorf("IsResponse() = %v, want %v", m.IsResponse(), want)
+		}
+	})
+}
+
+func TestReview(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x217-example-2b-response-admission-request-review.edi"))
+	m, err := x278.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := m.Event()
+	if ev == nil {
+		t.Fatal("Event() = nil")
+	}
+	wantReview := segments.UM{RequestCategory: "AR", CertificationType: "I", ServiceType: "2", Location: segments.ServiceLocation{FacilityCode: "21", FacilityCodeQualifier: "B"}}
+	if diff := cmp.Diff(wantReview, ev.Review); diff != "" {
+		t.Errorf("event review mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&segments.HCR{ActionCode: "A6", CertificationNumber: "AUTH0002"}, ev.Decision); diff != "" {
+		t.Errorf("event decision mismatch (-want +got):\n%s", diff)
+	}
+	if len(ev.Providers) != 1 || ev.Providers[0].Name.LastName != "MONTGOMERY HOSPITAL" || ev.Providers[0].City.City != "ANYTOWN" {
+		t.Errorf("event providers = %+v", ev.Providers)
+	}
+	if len(ev.Services) != 1 {
+		t.Fatalf("got %d services, want 1", len(ev.Services))
+	}
+	svc := ev.Services[0]
+	if svc.Decision.ActionCode != "A1" || svc.Institutional.Procedure.Code != "33510" || svc.Providers[0].Provider.ReferenceID != "203BS0133X" {
+		t.Errorf("service = %+v", svc)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var requests, responses []*x278.Transaction
+	names := make(map[*x278.Transaction]string)
+	for _, path := range fixtures(t) {
+		tx, _ := hipaatest.ReadFile(t, path)
+		m, err := x278.FromTransaction(tx, segments.DefaultDelimiters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The example number, such as "1" of "example-1a".
+		names[m] = strings.TrimPrefix(filepath.Base(path), "005010x217-example-")[:1]
+		if m.IsResponse() {
+			responses = append(responses, m)
+		} else {
+			requests = append(requests, m)
+		}
+	}
+	// Answer the requests in reverse order, so that pairing cannot rely
+	// on it.
+	for i, j := 0, len(responses)-1; i < j; i, j = i+1, j-1 {
+		responses[i], responses[j] = responses[j], responses[i]
+	}
+	unanswered := &x278.Transaction{BHT: segments.BHT{PurposeCode: x278.PurposeRequest, ReferenceID: "NONE"}}
+	requests = append(requests, unanswered)
+
+	pairs := x278.Match(requests, responses)
+	if len(pairs) != len(requests) {
+		t.Fatalf("Match() returned %d pairs, want %d", len(pairs), len(requests))
+	}
+	for _, p := range pairs[:len(pairs)-1] {
+		if p.Response == nil || names[p.Request] != names[p.Response] {
+			t.Errorf("request %s paired with response %v", names[p.Request], names[p.Response])
+		}
+	}
+	if last := pairs[len(pairs)-1]; last.Request != unanswered || last.Response != nil {
+		t.Errorf("last pair = %+v, want the unanswered request alone", last)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x278.Transaction{
+		ControlNumber: "0001",
+		BHT:           segments.BHT{StructureCode: "0007", PurposeCode: x278.PurposeRequest, ReferenceID: "REQ1", Date: "20240105", Time: "0900"},
+		UMO: x278.UMO{
+			Name: x278.UMOName{Name: segments.NM1{EntityIdentifierCode: "X3", EntityTypeQualifier: "2", LastName: "PAYER", IDQualifier: "PI", ID: "12345"}},
+			Requester: x278.Requester{
+				Name: x278.Entity{Name: segments.NM1{EntityIdentifierCode: "1P", EntityTypeQualifier: "1", LastName: "SMITH", IDQualifier: "XX", ID: "1234567893"}},
+				Subscriber: x278.Subscriber{
+					Name: x278.Member{Name: segments.NM1{EntityIdentifierCode: "IL", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JANE", IDQualifier: "MI", ID: "W123"}},
+					Event: &x278.Event{
+						Traces: []segments.TRN{{TypeCode: "1", ReferenceID: "TRACE1", OriginatorID: "9999999999"}},
+						Review: segments.UM{RequestCategory: "HS", CertificationType: "I", ServiceType: "1"},
+						Services: []x278.Service{{
+							Professional: &segments.SV1{Procedure: segments.ProcedureIdentifier{Qualifier: "HC", Code: "99213"}, Unit: "UN", Quantity: "1"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BHT*0007*13*REQ1*20240105*0900",
+		"HL*1**20*1",
+		"NM1*X3*2*PAYER*****PI*12345",
+		"HL*2*1*21*1",
+		"NM1*1P*1*SMITH*****XX*1234567893",
+		"HL*3*2*22*1",
+		"NM1*IL*1*DOE*JANE****MI*W123",
+		"HL*4*3*EV*1",
+		"TRN*1*TRACE1*9999999999",
+		"UM*HS*I*1",
+		"HL*5*4*SS*0",
+		"SV1*HC:99213**UN*1",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+
+	m.Event().Decision = &segments.HCR{ActionCode: "A1"}
+	if errs := m.Validate(segments.DefaultDelimiters); len(errs) == 0 {
+		t.Error("Validate() of a request with a decision found no errors")
+	}
+}
+
+func TestFromTransactionNil(t *testing.T) {
+	for _, tx := range []*x12.Transaction{nil, {}} {
+		if _, err := x278.FromTransaction(tx, segments.DefaultDelimiters); !errors.Is(err, x12.ErrInvalidArgument) {
+			t.Errorf("FromTransaction(%v) error = %v, want %v", tx, err, x12.ErrInvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X279A1Request is the Health Care Eligibility Benefit Inquiry (270)
+// implementation guide, 005010X279A1.
+var X279A1Request = &schema.TransactionSet{
+	ID:      "270",
+	Version: "005010X279A1",
+	Name:    "Health Care Eligibility Benefit Inquiry",
+	Loop:    x279a1Request(),
+}
+
+// X279A1Response is the Health Care Eligibility Benefit Response (271)
+// implementation guide, 005010X279A1.
+var X279A1Response = &schema.TransactionSet{
+	ID:      "271",
+	Version: "005010X279A1",
+	Name:    "Health Care Eligibility Benefit Response",
+	Loop:    x279a1Response(),
+}
+
+// Qualifier codes the 270 and 271 share.
+var (
+	x279SourceCodes   = []string{"2B", "36", "GP", "P5", "PR"}
+	x279ReceiverCodes = []string{"1P", "2B", "36", "80", "FA", "GP", "P5", "PR"}
+	x279MemberIDs     = []string{"18", "1L", "1W", "3H", "49", "6P", "CE", "CT", "EA", "EJ", "F6", "GH", "HJ", "IF", "IG", "ML", "N6", "NQ", "Q4", "SY", "Y4"}
+)
+
+func x279a1Request() *schema.Loop {
+	// member returns the subscriber (C) or dependent (D) level of a 270.
+	member := func(level, who, hlCode, nm1Code string, usage schema.Usage) *schema.Loop {
+		return loop("2000"+level, who+" Level", usage, 0,
+			seg("HL", who+" Level", req, 1, hlCode),
+			seg("TRN", who+" Trace Number", sit, 2, "1"),
+			loop("2100"+level, who+" Name", req, 1,
+				seg("NM1", who+" Name", req, 1, nm1Code),
+				seg("REF", who+" Additional Identification", sit, 9, x279MemberIDs...),
+				seg("N3", who+" Address", sit, 1),
+				seg("N4", who+" City, State, ZIP Code", sit, 1),
+				seg("PRV", "Provider Information", sit, 1),
+				seg("DMG", who+" Demographic Information", sit, 1),
+				seg("INS", who+" Relationship", sit, 1),
+				seg("HI", who+" Health Care Diagnosis Code", sit, 1),
+				seg("DTP", who+" Date", sit, 2, "291", "307", "435", "472"),
+				loop("2110"+level, who+" Eligibility or Benefit Inquiry", sit, 99,
+					seg("EQ", who+" Eligibility or Benefit Inquiry", req, 1),
+					seg("AMT", who+" Spend Down Amount", sit, 1, "R"),
+					seg("AMT", who+" Spend Down Total Billed Amount", sit, 1, "PB"),
+					seg("III", who+" Eligibility or Benefit Additional Inquiry Information", sit, 10),
+					seg("REF", who+" Additional Information", sit, 1, "9F", "G1"),
+					seg("DTP", who+" Eligibility/Benefit Date", sit, 1, "291", "307", "435", "472"),
+				),
+			),
+		)
+	}
+	dependent := member("D", "Dependent", "23", "03", sit)
+	subscriber := member("C", "Subscriber", "22", "IL", req)
+	subscriber.Children = append(subscriber.Children, dependent)
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BHT", "Beginning of Hierarchical Transaction", req, 1),
+		loop("2000A", "Information Source Level", req, 0,
+			seg("HL", "Information Source Level", req, 1, "20"),
+			loop("2100A", "Information Source Name", req, 1,
+				seg("NM1", "Information Source Name", req, 1, x279SourceCodes...),
+			),
+			loop("2000B", "Information Receiver Level", req, 0,
+				seg("HL", "Information Receiver Level", req, 1, "21"),
+				loop("2100B", "Information Receiver Name", req, 1,
+					seg("NM1", "Information Receiver Name", req, 1, x279ReceiverCodes...),
+					seg("REF", "Information Receiver Additional Identification", sit, 9, "0B", "HPI", "SY", "TJ"),
+					seg("N3", "Information Receiver Address", sit, 1),
+					seg("N4", "Information Receiver City, State, ZIP Code", sit, 1),
+					seg("PRV", "Information Receiver Provider Information", sit, 1),
+				),
+				subscriber,
+			),
+		),
+	}}
+}
+
+func x279a1Response() *schema.Loop {
+	relatedEntities := []string{"13", "1I", "1P", "2B", "36", "73", "FA", "GP", "GW", "I3", "IL", "LR", "OC", "P3", "P4", "P5", "PR", "PRP", "SEP", "TTP", "VER", "VN", "VY", "X3", "Y2"}
+	memberDates := []string{"102", "152", "291", "307", "318", "340", "341", "342", "343", "346", "347", "356", "357", "382", "435", "442", "458", "472", "539", "540", "636", "771"}
+	benefitDates := []string{"096", "193", "194", "198", "290", "291", "292", "295", "304", "307", "318", "346", "348", "349", "356", "357", "435", "472", "636", "771"}
+
+	// member returns the subscriber (C) or dependent (D) level of a 271.
+	member := func(level, who, hlCode, nm1Code string) *schema.Loop {
+		return loop("2000"+level, who+" Level", sit, 0,
+			seg("HL", who+" Level", req, 1, hlCode),
+			seg("TRN", who+" Trace Number", sit, 3, "1", "2"),
+			loop("2100"+level, who+" Name", req, 1,
+				seg("NM1", who+" Name", req, 1, nm1Code),
+				seg("REF", who+" Additional Identification", sit, 9, x279MemberIDs...),
+				seg("N3", who+" Address", sit, 1),
+				seg("N4", who+" City, State, ZIP Code", sit, 1),
+				seg("AAA", who+" Request Validation", sit, 9),
+				seg("PRV", "Provider Information", sit, 1),
+				seg("DMG", who+" Demographic Information", sit, 1),
+				seg("INS", who+" Relationship", sit, 1),
+				seg("HI", who+" Health Care Diagnosis Code", sit, 1),
+				seg("DTP", who+" Date", sit, 9, memberDates...),
+				seg("MPI", who+" Military Personnel Information", sit, 1),
+				loop("2110"+level, who+" Eligibility or Benefit Information", sit, 0,
+					seg("EB", who+" Eligibility or Benefit Information", req, 1),
+					seg("HSD", "Health Care Services Delivery", sit, 9),
+					seg("REF", who+" Additional Identification", sit, 9, "18", "1L", "1W", "49", "6P", "9F", "ALS", "CLI", "F8", "FO", "G1", "IG", "N6", "NQ", "Y4"),
+					seg("DTP", who+" Eligibility/Benefit Date", sit, 20, benefitDates...),
+					seg("AAA", who+" Request Validation", sit, 9),
+					seg("MSG", "Message Text", sit, 10),
+					loop("2115"+level, who+" Eligibility or Benefit Additional Information", sit, 10,
+						seg("III", who+" Eligibility or Benefit Additional Information", req, 1),
+					),
+					seg("LS", "Loop Header", sit, 1, "2120"),
+					loop("2120"+level, who+" Benefit Related Entity Name", sit, 23,
+						seg("NM1", who+" Benefit Related Entity Name", req, 1, relatedEntities...),
+						seg("N3", who+" Benefit Related Entity Address", sit, 1),
+						seg("N4", who+" Benefit Related Entity City, State, ZIP Code", sit, 1),
+						seg("PER", who+" Benefit Related Entity Contact Information", sit, 3, "IC"),
+						seg("PRV", who+" Benefit Related Provider Information", sit, 1),
+					),
+					seg("LE", "Loop Trailer", sit, 1, "2120"),
+				),
+			),
+		)
+	}
+	subscriber := member("C", "Subscriber", "22", "IL")
+	subscriber.Children = append(subscriber.Children, member("D", "Dependent", "23", "03"))
+
+	return &schema.Loop{Children: []schema.Node{
+		seg("BHT", "Beginning of Hierarchical Transaction", req, 1),
+		loop("2000A", "Information Source Level", req, 0,
+			seg("HL", "Information Source Level", req, 1, "20"),
+			seg("AAA", "Request Validation", sit, 9),
+			loop("2100A", "Information Source Name", req, 1,
+				seg("NM1", "Information Source Name", req, 1, x279SourceCodes...),
+				seg("PER", "Information Source Contact Information", sit, 3, "IC"),
+				seg("AAA", "Request Validation", sit, 9),
+			),
+			loop("2000B", "Information Receiver Level", sit, 0,
+				seg("HL", "Information Receiver Level", req, 1, "21"),
+				loop("2100B", "Information Receiver Name", req, 1,
+					seg("NM1", "Information Receiver Name", req, 1, x279ReceiverCodes...),
+					seg("REF", "Information Receiver Additional Identification", sit, 9, "0B", "1C", "1D", "1J", "4A", "CT", "EL", "EO", "HPI", "JD", "N5", "N7", "Q4", "SY", "TJ"),
+					seg("AAA", "Information Receiver Request Validation", sit, 9),
+					seg("PRV", "Information Receiver Provider Information", sit, 1),
+				),
+				subscriber,
+			),
+		),
+	}}
+}
@@ -0,0 +1,182 @@
+// Package x820 is a typed model of the Payroll Deducted and Other Group
+// Premium Payment for Insurance Products (820) transaction,
+// implementation guide 005010X218.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X218 and
+// maps them to a Transaction: the payment's financial information and
+// trace number, the premium receiver and payer, and the remittances,
+// one per organization or individual (ENT), each with the amounts
+// remitted (RMR) and adjusted (ADX) for it. Totals sums the amounts per
+// remittance, and Balance checks them against the payment total (BPR02).
+// ToTransaction writes the segments back in guide order, so a
+// transaction that conforms to the guide's loop structure round-trips
+// unchanged.
+package x820
+
+import (
+	"fmt"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// A Transaction is an 820 premium payment transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X218" unless read without one
+	read          bool   // made by FromTransaction
+
+	Financial       segments.BPR   `x12:"BPR"`
+	Trace           segments.TRN   `x12:"TRN"`
+	Currency        *x12.Segment   `x12:"CUR"`
+	ReceiverKey     *segments.REF  `x12:"REF"`
+	Dates           []segments.DTM `x12:"DTM"`
+	PremiumReceiver Party          `x12:"1000A"`
+	PremiumPayer    Party          `x12:"1000B"`
+	Remittances     []Remittance   `x12:"2000"`
+}
+
+// A Party is the Premium Receiver's Name (1000A) or Premium Payer's Name
+// (1000B) loop.
+type Party struct {
+	Name           segments.N1   `x12:"N1"`
+	AdditionalName *x12.Segment  `x12:"N2"`
+	Address        *segments.N3  `x12:"N3"`
+	City           *segments.N4  `x12:"N4"`
+	Contact        *segments.PER `x12:"PER"`
+}
+
+// A Remittance is the Remittance loop (2000): the guide's Organization
+// Summary Remittance (2000A), or its Individual Remittance (2000B) when
+// Individual is set.
+type Remittance struct {
+	Entity     segments.ENT `x12:"ENT"`
+	Individual *Individual  `x12:"2100"`
+	Details    []Detail     `x12:"2300"`
+}
+
+// An Individual is the Individual Name loop (2100B).
+type Individual struct {
+	Name       segments.NM1   `x12:"NM1"`
+	References []segments.REF `x12:"REF"`
+}
+
+// A Detail is the Remittance Detail loop (2300A or 2300B): an amount
+// remitted for one policy, invoice, or account, and its adjustments.
+type Detail struct {
+	Remittance     segments.RMR  `x12:"RMR"`
+	Reference      *segments.REF `x12:"REF"`
+	CoveragePeriod *segments.DTM `x12:"DTM"`
+	Adjustments    []Adjustment  `x12:"2320"`
+}
+
+// An Adjustment is the Adjustment loop (2320A or 2320B).
+type Adjustment struct {
+	Adjustment segments.ADX `x12:"ADX"`
+}
+
+// A Total is the amount remitted for one remittance, in cents.
+type Total struct {
+	Remittance *Remittance
+	Remitted   int64 // the sum of its RMR04 amounts
+	Adjusted   int64 // the sum of its ADX01 amounts
+}
+
+// Net returns the amount paid for the remittance: the amounts remitted
+// plus the adjustments, which are negative when they reduce it.
+func (t Total) Net() int64 {
+	return t.Remitted + t.Adjusted
+}
+
+// Totals returns the amounts remitted and adjusted for each of t's
+// remittances, in order. It returns an error wrapping
+// x12.ErrInvalidFormat if an amount is malformed; empty amounts count as
+// zero.
+func (t *Transaction) Totals() ([]Total, error) {
+	totals := make([]Total, len(t.Remittances))
+	for i := range t.Remittances {
+		r := &t.Remittances[i]
+		totals[i].Remittance = r
+		for _, d := range r.Details {
+			c, err := cents(d.Remittance.Amount)
+			if err != nil {
+				return nil, err
+			}
+			totals[i].Remitted += c
+			for _, a := range d.Adjustments {
+				c, err := cents(a.Adjustment.Amount)
+				if err != nil {
+					return nil, err
+				}
+				totals[i].Adjusted += c
+			}
+		}
+	}
+	return totals, nil
+}
+
+// Balance reports whether t's payment total (BPR02) equals the sum of
+// its remittances' net amounts. It returns an error wrapping
+// snip.ErrOutOfBalance if not, or x12.ErrInvalidFormat if an amount is
+// malformed.
+func (t *Transaction) Balance() error {
+	totals, err := t.Totals()
+	if err != nil {
+		return err
+	}
+	payment, err := cents(t.Financial.Amount)
+	if err != nil {
+		return err
+	}
+	var net int64
+	for _, tot := range totals {
+		net += tot.Net()
+	}
+	if payment != net {
+		return fmt.Errorf("%w: x820: total payment %s, remittances total %s", snip.ErrOutOfBalance, model.Amount(payment), model.Amount(net))
+	}
+	return nil
+}
+
+// cents parses a monetary amount into cents. An empty amount is zero.
+func cents(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	c, err := model.Cents(s)
+	if err != nil {
+		return 0, fmt.Errorf("x820: %w", err)
+	}
+	return c, nil
+}
+
+// FromTransaction returns the model of tx, an 820 transaction of
+// 005010X218. Elements are split into components and repetitions with d.
+// It returns an error if a segment is out of place for the guide's loop
+// structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x820", hipaa.X218, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 820 transaction, with ST and SE segments
+// built from ControlNumber and Version.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	return model.Marshal("x820", hipaa.X218, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X218: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X218, tx)
+}
@@ -0,0 +1,126 @@
+package x820_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x820"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// fixture remits two employees' premiums, one reduced by an
+// adjustment.
+var fixture = filepath.Join("..", "testdata", "005010x218-premium-payment.edi")
+
+func TestRoundTrip(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, fixture)
+	m, err := x820.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() error: %v", err)
+	}
+	got, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("ToTransaction() error: %v", err)
+	}
+	if diff := cmp.Diff(tx, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestTotals(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, fixture)
+	m, err := x820.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.PremiumPayer.Name.Name != "ACME CORP" || m.Trace.ReferenceID != "78905" {
+		t.Errorf("payer = %+v, trace = %+v", m.PremiumPayer.Name, m.Trace)
+	}
+	totals, err := m.Totals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type total struct {
+		ID                 string
+		Remitted, Adjusted int64
+		Net                int64
+	}
+	var got []total
+	for _, tot := range totals {
+		got = append(got, total{tot.Remittance.Individual.Name.ID, tot.Remitted, tot.Adjusted, tot.Net()})
+	}
+	want := []total{
+		{"123456789", 80000, 0, 80000},
+		{"555667777", 80000, -5000, 75000},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Totals() mismatch (-want +got):\n%s", diff)
+	}
+	if err := m.Balance(); err != nil {
+		t.Errorf("Balance() error: %v", err)
+	}
+
+	m.Financial.Amount = "1600"
+	if err := m.Balance(); !errors.Is(err, snip.ErrOutOfBalance) {
+		t.Errorf("Balance() of an overpayment = %v, want %v", err, snip.ErrOutOfBalance)
+	}
+	m.Remittances[0].Details[0].Remittance.Amount = "8OO"
+	if err := m.Balance(); !errors.Is(err, x12.ErrInvalidFormat) {
+		t.Errorf("Balance() with a malformed amount = %v, want %v", err, x12.ErrInvalidFormat)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x820.Transaction{
+		ControlNumber:   "0001",
+		Financial:       segments.BPR{HandlingCode: "I", Amount: "300", CreditDebit: "C", PaymentMethod: "CHK", Date: "20240601"},
+		Trace:           segments.TRN{TypeCode: "3", ReferenceID: "CHK1001", OriginatorID: "1999888777"},
+		PremiumReceiver: x820.Party{Name: segments.N1{EntityIdentifierCode: "PE", Name: "ABC INSURANCE", IDQualifier: "FI", ID: "654456654"}},
+		PremiumPayer:    x820.Party{Name: segments.N1{EntityIdentifierCode: "PR", Name: "ACME CORP", IDQualifier: "FI", ID: "999888777"}},
+		Remittances: []x820.Remittance{{
+			Entity: segments.ENT{AssignedNumber: "1", EntityIdentifierCode: "2L", IDQualifier: "FI", ID: "999888777"},
+			Details: []x820.Detail{{
+				Remittance:  segments.RMR{Qualifier: "IK", ID: "INV1", Amount: "325"},
+				Adjustments: []x820.Adjustment{{Adjustment: segments.ADX{Amount: "-25", AdjustmentReason: "52"}}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BPR*I*300*C*CHK************20240601",
+		"TRN*3*CHK1001*1999888777",
+		"N1*PE*ABC INSURANCE*FI*654456654",
+		"N1*PR*ACME CORP*FI*999888777",
+		"ENT*1*2L*FI*999888777",
+		"RMR*IK*INV1**325",
+		"ADX*-25*52",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+	if err := m.Balance(); err != nil {
+		t.Errorf("Balance() error: %v", err)
+	}
+}
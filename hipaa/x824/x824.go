@@ -0,0 +1,219 @@
+// Package x824 is a typed model of the Application Advice (824)
+// transaction, implementation guide 005010X186A1 (Application Reporting
+// for Insurance).
+//
+// An 824 reports on transactions after they pass syntax checks: each
+// original transaction (OTI) is accepted, accepted with errors, or
+// rejected, and its errors and warnings (TED) name the segment and
+// element where each was found, with related data (RED).
+// FromTransaction arranges a transaction's segments with hipaa.X186A1
+// and maps them to a Transaction; Report adds the report on a received
+// transaction to one being built. ToTransaction writes the segments back
+// in guide order, so a transaction that conforms to the guide's loop
+// structure round-trips unchanged.
+package x824
+
+import (
+	"strconv"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// Application acknowledgment codes (OTI01).
+const (
+	Accepted           = "TA"
+	AcceptedWithErrors = "TE"
+	Rejected           = "TR"
+)
+
+// A Transaction is an 824 application advice transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X186A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	BGN       segments.BGN `x12:"BGN"`
+	Submitter Party        `x12:"1000A"`
+	Receiver  Party        `x12:"1000B"`
+	Originals []Original   `x12:"2000"`
+}
+
+// A Party is the Submitter Name (1000A) or Receiver Name (1000B) loop.
+// The submitter is the party reporting, and the receiver the party whose
+// transactions are reported on.
+type Party struct {
+	Name     segments.N1    `x12:"N1"`
+	Contacts []segments.PER `x12:"PER"`
+}
+
+// An Original is the Original Transaction Identification loop (2000):
+// the report on one transaction.
+type Original struct {
+	Identification segments.OTI   `x12:"OTI"`
+	References     []segments.REF `x12:"REF"`
+	Dates          []segments.DTM `x12:"DTM"`
+	Amounts        []segments.AMT `x12:"AMT"`
+	Quantities     []segments.QTY `x12:"QTY"`
+	Errors         []Error        `x12:"2100"`
+}
+
+// An Error is the Error or Warning loop (2100).
+type Error struct {
+	Error   segments.TED   `x12:"TED"`
+	Notes   []segments.NTE `x12:"NTE"`
+	Related []segments.RED `x12:"RED"`
+}
+
+// IsAccepted reports whether o's transaction was accepted, with or
+// without errors.
+func (o *Original) IsAccepted() bool {
+	code := o.Identification.AcknowledgmentCode
+	return code == Accepted || code == AcceptedWithErrors
+}
+
+// Original returns the report on the transaction with the group and
+// transaction set control numbers (GS06 and ST02), or nil.
+func (t *Transaction) Original(group, transaction string) *Original {
+	for i := range t.Originals {
+		oti := &t.Originals[i].Identification
+		if oti.GroupControlNumber == group && oti.TransactionControlNumber == transaction {
+			return &t.Originals[i]
+		}
+	}
+	return nil
+}
+
+// A Finding is an application-level error or warning found in a
+// received transaction, for Report.
+type Finding struct {
+	// Code is the application error condition code (TED01), such as
+	// "024" for an unlisted reason; empty means "024".
+	Code    string
+	Message string // TED02
+
+	// SegmentID and Position locate the segment, Position counting from
+	// 1 for the ST segment; Element and Component locate the element
+	// within it. Each is optional.
+	SegmentID string
+	Position  int
+	Element   int
+	Component int
+	// BadData is a copy of the element in error.
+	BadData string
+
+	// Warning marks a finding that does not reject the transaction.
+	Warning bool
+}
+
+// Report adds to t the report on tx, a transaction received in the group
+// gs: accepted if there are no findings, accepted with errors if all are
+// warnings, and rejected otherwise. The transaction is identified by its
+// BHT03 or BGN02 reference (or "NA"), the group's application sender
+// and receiver codes, date, and time, and the control numbers and
+// version of gs and tx. It returns the report, or nil, adding nothing,
+// if tx is nil.
+//
+// The report returned is the last element of t.Originals, and changes
+// made through it, such as adding notes to its Errors, reach
+// t.Originals only until the slice next grows: the next Report may move
+// the reports to a new array. Index t.Originals to change a report
+// after that.
+func (t *Transaction) Report(gs *x12.GS, tx *x12.Transaction, findings ...Finding) *Original {
+	if tx == nil {
+		return nil
+	}
+	o := Original{Identification: segments.OTI{
+		AcknowledgmentCode: Accepted,
+		Qualifier:          "TN",
+		ReferenceID:        reference(tx),
+	}}
+	oti := &o.Identification
+	if gs != nil {
+		oti.SenderCode, oti.ReceiverCode = gs.SenderCode, gs.ReceiverCode
+		oti.Date, oti.Time, oti.GroupControlNumber = gs.Date, gs.Time, gs.ControlNumber
+	}
+	if tx.Header != nil {
+		oti.TransactionControlNumber = tx.Header.ControlNumber
+		oti.TransactionSetID = tx.Header.IDCode
+		oti.Version = tx.Header.ImplementationConventionReference
+	}
+	if oti.Version == "" && gs != nil {
+		oti.Version = gs.Version
+	}
+	for _, f := range findings {
+		if f.Warning {
+			if oti.AcknowledgmentCode == Accepted {
+				oti.AcknowledgmentCode = AcceptedWithErrors
+			}
+		} else {
+			oti.AcknowledgmentCode = Rejected
+		}
+		o.Errors = append(o.Errors, Error{Error: f.ted()})
+	}
+	t.Originals = append(t.Originals, o)
+	return &t.Originals[len(t.Originals)-1]
+}
+
+// ted returns f as a TED segment.
+func (f Finding) ted() segments.TED {
+	ted := segments.TED{ErrorCode: f.Code, Message: f.Message, SegmentID: f.SegmentID, BadData: f.BadData}
+	if ted.ErrorCode == "" {
+		ted.ErrorCode = "024"
+	}
+	ted.SegmentPosition = itoa(f.Position)
+	ted.ElementPosition.Element = itoa(f.Element)
+	ted.ElementPosition.Component = itoa(f.Component)
+	return ted
+}
+
+// itoa formats a positive n, and returns "" otherwise.
+func itoa(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// reference returns the reference identification of tx's beginning
+// segment, or "NA".
+func reference(tx *x12.Transaction) string {
+	if len(tx.Segments) > 0 {
+		seg := tx.Segments[0]
+		pos := map[string]int{"BHT": 3, "BGN": 2}[seg.ID]
+		if pos > 0 && pos <= len(seg.Elements) && seg.Elements[pos-1].Value != "" {
+			return seg.Elements[pos-1].Value
+		}
+	}
+	return "NA"
+}
+
+// FromTransaction returns the model of tx, an 824 transaction of
+// 005010X186A1. Elements are split into components and repetitions with
+// d. It returns an error if a segment is out of place for the guide's
+// loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x824", hipaa.X186A1, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 824 transaction, with ST and SE segments
+// built from ControlNumber and Version.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	return model.Marshal("x824", hipaa.X186A1, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X186A1: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X186A1, tx)
+}
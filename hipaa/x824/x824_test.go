@@ -0,0 +1,150 @@
+package x824_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x824"
+	"github.com/tmc/x12/segments"
+)
+
+// advice rejects one enrollment for two errors and accepts another.
+const advice = `ST*824*021390001*005010X186A1~
+BGN*11*FFA.ABCDEF.123456*20020709*0932**123456789**WQ~
+N1*41*ABC INSURANCE*46*111111111~
+PER*IC*JOHN JOHNSON*TE*8005551212*EX*1439~
+N1*40*SMITHCO*46*A1234~
+OTI*TR*TN*12456***20020709*0902*2*0001*834*005010X220A1~
+TED*024*SUBSCRIBER NOT FOUND*REF*4*2**123456789~
+RED*EMPLOYEE ID NOT ON FILE~
+TED*008*INVALID DATE*DTP*7*3**20021301~
+OTI*TA*TN*12457***20020709*0902*2*0002*834*005010X220A1~
+SE*11*021390001~`
+
+func TestRoundTrip(t *testing.T) {
+	tx := hipaatest.Decode(t, advice)
+	m, err := x824.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() error: %v", err)
+	}
+	got, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("ToTransaction() error: %v", err)
+	}
+	if diff := cmp.Diff(tx, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestOriginals(t *testing.T) {
+	m, err := x824.FromTransaction(hipaatest.Decode(t, advice), segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected := m.Original("2", "0001")
+	if rejected == nil || rejected.IsAccepted() {
+		t.Fatalf("Original(2, 0001) = %+v, want a rejection", rejected)
+	}
+	want := []x824.Error{
+		{
+			Error:   segments.TED{ErrorCode: "024", Message: "SUBSCRIBER NOT FOUND", SegmentID: "REF", SegmentPosition: "4", ElementPosition: segments.ElementPosition{Element: "2"}, BadData: "123456789"},
+			Related: []segments.RED{{Description: "EMPLOYEE ID NOT ON FILE"}},
+		},
+		{
+			Error: segments.TED{ErrorCode: "008", Message: "INVALID DATE", SegmentID: "DTP", SegmentPosition: "7", ElementPosition: segments.ElementPosition{Element: "3"}, BadData: "20021301"},
+		},
+	}
+	if diff := cmp.Diff(want, rejected.Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	if accepted := m.Original("2", "0002"); accepted == nil || !accepted.IsAccepted() {
+		t.Errorf("Original(2, 0002) = %+v, want an acceptance", accepted)
+	}
+	if m.Original("2", "0003") != nil {
+		t.Error("Original(2, 0003) found a report on a transaction not reported on")
+	}
+}
+
+func TestReport(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "005010x222-example-3a-claim-billing-provider-payer.edi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := x12.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := doc.Interchange.FunctionGroups[0]
+	g.Header.SenderCode, g.Header.ReceiverCode = "TGJ23", "66783JJT"
+	g.Header.Date, g.Header.Time, g.Header.ControlNumber = "20051015", "1023", "17"
+	claims := g.Transactions[0]
+	pos := 0
+	for i, seg := range claims.Segments {
+		if seg.ID == "CLM" {
+			pos = i + 2
+		}
+	}
+
+	m := &x824.Transaction{
+		ControlNumber: "0001",
+		BGN:           segments.BGN{PurposeCode: "11", ReferenceID: "ACK0001", Date: "20051016", Time: "0800"},
+		Submitter:     x824.Party{Name: segments.N1{EntityIdentifierCode: "41", Name: "XYZ REPRICER", IDQualifier: "46", ID: "66783JJT"}},
+		Receiver:      x824.Party{Name: segments.N1{EntityIdentifierCode: "40", Name: "PREMIER BILLING SERVICE", IDQualifier: "46", ID: "TGJ23"}},
+	}
+	o := m.Report(g.Header, claims, x824.Finding{Message: "DUPLICATE CLAIM", SegmentID: "CLM", Position: pos, Element: 1, BadData: "26407789"})
+	if o.IsAccepted() {
+		t.Error("report with an error accepted the transaction")
+	}
+	o.Errors[0].Notes = append(o.Errors[0].Notes, segments.NTE{ReferenceCode: "ADD", Description: "SEE CLAIM 26407788"})
+	if got := m.Originals[0].Errors[0].Notes; len(got) != 1 {
+		t.Errorf("Originals[0] notes = %+v after a change through Report's result, want 1", got)
+	}
+	if o := m.Report(g.Header, claims, x824.Finding{Message: "LATE FILING", Warning: true}); o.Identification.AcknowledgmentCode != x824.AcceptedWithErrors {
+		t.Errorf("report with a warning has OTI01 %q, want %q", o.Identification.AcknowledgmentCode, x824.AcceptedWithErrors)
+	}
+	if o := m.Report(g.Header, claims); o.Identification.AcknowledgmentCode != x824.Accepted {
+		t.Errorf("report without findings has OTI01 %q, want %q", o.Identification.AcknowledgmentCode, x824.Accepted)
+	}
+	if o := m.Report(g.Header, nil); o != nil || len(m.Originals) != 3 {
+		t.Errorf("Report() of a nil transaction = %+v, and %d reports, want nil and 3", o, len(m.Originals))
+	}
+
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BGN*11*ACK0001*20051016*0800",
+		"N1*41*XYZ REPRICER*46*66783JJT",
+		"N1*40*PREMIER BILLING SERVICE*46*TGJ23",
+		"OTI*TR*TN*0123*TGJ23*66783JJT*20051015*1023*17*0021*837*005010X222",
+		"TED*024*DUPLICATE CLAIM*CLM*" + strconv.Itoa(pos) + "*1**26407789",
+		"NTE*ADD*SEE CLAIM 26407788",
+		"OTI*TE*TN*0123*TGJ23*66783JJT*20051015*1023*17*0021*837*005010X222",
+		"TED*024*LATE FILING",
+		"OTI*TA*TN*0123*TGJ23*66783JJT*20051015*1023*17*0021*837*005010X222",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package x834
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/segments"
+)
+
+// A ChangeKind is the kind of a MemberChange or CoverageChange.
+type ChangeKind int
+
+// Change kinds.
+const (
+	Added ChangeKind = iota + 1
+	Terminated
+	Changed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Terminated:
+		return "terminated"
+	case Changed:
+		return "changed"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// A MemberChange is a difference in one member between two full files.
+// Dependents are members in their own right, so a dependent added or
+// dropped is reported as an Added or Terminated change of its own.
+type MemberChange struct {
+	Kind ChangeKind
+	Old  *Member // nil for Added
+	New  *Member // nil for Terminated
+
+	// Fields lists the parts of a Changed member that differ, of
+	// "member" (INS other than its maintenance type and reason),
+	// "references", "dates", "name", "demographics", "address",
+	// "contact", and "coverage".
+	Fields []string
+	// Coverages lists a Changed member's coverage differences.
+	Coverages []CoverageChange
+}
+
+// A CoverageChange is a difference in one of a member's coverages,
+// matched by insurance line and plan (HD03 and HD04).
+type CoverageChange struct {
+	Kind ChangeKind
+	Old  *Coverage // nil for Added
+	New  *Coverage // nil for Terminated
+}
+
+// FromDocument returns the 834 transactions of doc as one Transaction:
+// the first transaction's header, sponsor, and payer, and the members of
+// them all. Full files are often split across several transactions.
+// Nil groups and transactions are skipped.
+func FromDocument(doc *x12.Document, d segments.Delimiters) (*Transaction, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("%w: x834: nil document", x12.ErrInvalidArgument)
+	}
+	var t *Transaction
+	if doc.Interchange != nil {
+		for _, g := range doc.Interchange.FunctionGroups {
+			if g == nil {
+				continue
+			}
+			for _, tx := range g.Transactions {
+				if tx == nil || tx.Header == nil || tx.Header.IDCode != "834" {
+					continue
+				}
+				m, err := FromTransaction(tx, d)
+				if err != nil {
+					return nil, err
+				}
+				if t == nil {
+					t = m
+					continue
+				}
+				t.Members = append(t.Members, m.Members...)
+			}
+		}
+	}
+	if t == nil {
+		return nil, fmt.Errorf("%w: x834: document has no 834 transaction", x12.ErrInvalidArgument)
+	}
+	return t, nil
+}
+
+// memberKey identifies a member across files: its subscriber
+// identifier and its member identifier, or, for a member without one,
+// its first name and birth date.
+type memberKey struct {
+	subscriber, member, first, birth string
+}
+
+func keyOf(m *Member) memberKey {
+	k := memberKey{subscriber: m.SubscriberID(), member: m.MemberID()}
+	if k.member == "" {
+		k.first = m.Name.Name.FirstName
+		if m.Name.Demographics != nil {
+			k.birth = m.Name.Demographics.BirthDate
+		}
+	}
+	return k
+}
+
+// Compare returns the differences between the members of old and new,
+// two full (audit) files of one sponsor: members only in new are Added,
+// members only in old are Terminated, and members in both that differ
+// other than in maintenance type and reason codes are Changed. Added
+// and Changed members are listed in new's order, followed by the
+// Terminated ones in old's order.
+func Compare(old, new *Transaction) []MemberChange {
+	olds := make(map[memberKey]*Member)
+	for i := range old.Members {
+		k := keyOf(&old.Members[i])
+		if olds[k] == nil {
+			olds[k] = &old.Members[i]
+		}
+	}
+	var changes []MemberChange
+	seen := make(map[memberKey]bool)
+	for i := range new.Members {
+		m := &new.Members[i]
+		k := keyOf(m)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		o := olds[k]
+		if o == nil {
+			changes = append(changes, MemberChange{Kind: Added, New: m})
+			continue
+		}
+		fields, covs := diffMember(o, m)
+		if len(fields) > 0 {
+			changes = append(changes, MemberChange{Kind: Changed, Old: o, New: m, Fields: fields, Coverages: covs})
+		}
+	}
+	for i := range old.Members {
+		m := &old.Members[i]
+		if k := keyOf(m); !seen[k] && olds[k] == m {
+			changes = append(changes, MemberChange{Kind: Terminated, Old: m})
+		}
+	}
+	return changes
+}
+
+// diffMember returns the parts of a member that differ between o and m,
+// and their coverage differences.
+func diffMember(o, m *Member) ([]string, []CoverageChange) {
+	var fields []string
+	add := func(name string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			fields = append(fields, name)
+		}
+	}
+	oi, mi := o.Detail, m.Detail
+	oi.MaintenanceType, oi.MaintenanceReason = "", ""
+	mi.MaintenanceType, mi.MaintenanceReason = "", ""
+	add("member", oi, mi)
+	add("references", o.References, m.References)
+	add("dates", o.Dates, m.Dates)
+	add("name", o.Name.Name, m.Name.Name)
+	add("demographics", o.Name.Demographics, m.Name.Demographics)
+	add("address", []any{o.Name.Address, o.Name.City, o.MailingAddress}, []any{m.Name.Address, m.Name.City, m.MailingAddress})
+	add("contact", o.Name.Contact, m.Name.Contact)
+
+	covs := diffCoverages(o.Coverages, m.Coverages)
+	if len(covs) > 0 {
+		fields = append(fields, "coverage")
+	}
+	return fields, covs
+}
+
+// coverageKey identifies a coverage within a member.
+type coverageKey struct{ line, plan string }
+
+func diffCoverages(olds, news []Coverage) []CoverageChange {
+	byKey := make(map[coverageKey]*Coverage)
+	for i := range olds {
+		k := coverageKey{olds[i].Coverage.InsuranceLine, olds[i].Coverage.PlanCoverage}
+		if byKey[k] == nil {
+			byKey[k] = &olds[i]
+		}
+	}
+	var changes []CoverageChange
+	seen := make(map[coverageKey]bool)
+	for i := range news {
+		c := &news[i]
+		k := coverageKey{c.Coverage.InsuranceLine, c.Coverage.PlanCoverage}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		o := byKey[k]
+		switch {
+		case o == nil:
+			changes = append(changes, CoverageChange{Kind: Added, New: c})
+		case !reflect.DeepEqual(unmaintained(o), unmaintained(c)):
+			changes = append(changes, CoverageChange{Kind: Changed, Old: o, New: c})
+		}
+	}
+	for i := range olds {
+		c := &olds[i]
+		if k := (coverageKey{c.Coverage.InsuranceLine, c.Coverage.PlanCoverage}); !seen[k] && byKey[k] == c {
+			changes = append(changes, CoverageChange{Kind: Terminated, Old: c})
+		}
+	}
+	return changes
+}
+
+// unmaintained returns c without its maintenance type and reason.
+func unmaintained(c *Coverage) Coverage {
+	cc := *c
+	cc.Coverage.MaintenanceType, cc.Coverage.MaintenanceReason = "", ""
+	return cc
+}
+
+// Maintenance reason codes (INS04) ChangeFile uses.
+const (
+	reasonInitialEnrollment = "28"
+	reasonTermination       = "07"
+	reasonNoReason          = "AI"
+)
+
+// ChangeFile returns a change-only 834 carrying changes, with header's
+// BGN, sponsor, payer, and brokers. Added members are sent as additions
+// (INS03 and HD01 "021") and Terminated ones as cancellations ("024")
+// ending on date, a CCYYMMDD date that becomes their eligibility and
+// benefit end dates where they have none. Changed members are sent as
+// changes ("001") carrying only their added, changed, and terminated
+// coverages. The members of changes are not modified.
+func ChangeFile(header *Transaction, changes []MemberChange, date string) *Transaction {
+	t := &Transaction{
+		ControlNumber: header.ControlNumber,
+		Version:       header.Version,
+		BGN:           header.BGN,
+		PolicyNumber:  header.PolicyNumber,
+		Sponsor:       header.Sponsor,
+		Payer:         header.Payer,
+		Brokers:       header.Brokers,
+	}
+	t.BGN.PurposeCode = "00"
+	t.BGN.ActionCode = "2"
+	for _, c := range changes {
+		var m Member
+		switch c.Kind {
+		case Added:
+			m = *c.New
+			m.Detail.MaintenanceType, m.Detail.MaintenanceReason = MaintenanceAddition, reasonInitialEnrollment
+			m.Coverages = nil
+			for i := range c.New.Coverages {
+				m.Coverages = append(m.Coverages, maintained(&c.New.Coverages[i], MaintenanceAddition, ""))
+			}
+		case Terminated:
+			m = *c.Old
+			m.Detail.MaintenanceType, m.Detail.MaintenanceReason = MaintenanceCancellation, reasonTermination
+			m.Dates = withDate(m.Dates, "357", date)
+			m.Coverages = nil
+			for i := range c.Old.Coverages {
+				m.Coverages = append(m.Coverages, maintained(&c.Old.Coverages[i], MaintenanceCancellation, date))
+			}
+		case Changed:
+			m = *c.New
+			m.Detail.MaintenanceType, m.Detail.MaintenanceReason = MaintenanceChange, reasonNoReason
+			m.Coverages = nil
+			for _, cc := range c.Coverages {
+				switch cc.Kind {
+				case Added:
+					m.Coverages = append(m.Coverages, maintained(cc.New, MaintenanceAddition, ""))
+				case Terminated:
+					m.Coverages = append(m.Coverages, maintained(cc.Old, MaintenanceCancellation, date))
+				default:
+					m.Coverages = append(m.Coverages, maintained(cc.New, MaintenanceChange, ""))
+				}
+			}
+		default:
+			continue
+		}
+		t.Members = append(t.Members, m)
+	}
+	return t
+}
+
+// maintained returns a copy of c with maintenance type typ and, if end
+// is not empty, a benefit end date (349) of end unless c has one.
+func maintained(c *Coverage, typ, end string) Coverage {
+	cc := *c
+	cc.Coverage.MaintenanceType, cc.Coverage.MaintenanceReason = typ, ""
+	if end != "" {
+		cc.Dates = withDate(cc.Dates, "349", end)
+	}
+	return cc
+}
+
+// withDate returns dates with a CCYYMMDD date of the qualifier added
+// unless dates has one. It does not modify dates' backing array.
+func withDate(dates []segments.DTP, qualifier, date string) []segments.DTP {
+	if findDate(dates, qualifier) != nil {
+		return dates
+	}
+	out := make([]segments.DTP, len(dates), len(dates)+1)
+	copy(out, dates)
+	return append(out, segments.DTP{Qualifier: qualifier, FormatQualifier: "D8", Period: date})
+}
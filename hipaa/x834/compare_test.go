@@ -0,0 +1,230 @@
+package x834_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/x834"
+	"github.com/tmc/x12/segments"
+)
+
+// lastMonth is a full file: a family with two dependents, one of them
+// without a member identifier, and a subscriber alone.
+const lastMonth = `ST*834*0001*005010X220A1~
+BGN*00*AUDIT1*20240501*1200****4~
+N1*P5*ACME CORP*FI*999888777~
+N1*IN*ABC INSURANCE*FI*654456654~
+INS*Y*18*030*XN*A***FT~
+REF*0F*123456789~
+NM1*IL*1*DOE*JOHN*P***34*123456789~
+N3*100 MARKET ST~
+N4*CAMP HILL*PA*17011~
+DMG*D8*19800816*M~
+HD*030**HLT**FAM~
+DTP*348*D8*20240101~
+HD*030**DEN**FAM~
+DTP*348*D8*20240101~
+INS*N*19*030*XN*A~
+REF*0F*123456789~
+NM1*IL*1*DOE*JAMES*E***34*234567890~
+DMG*D8*20111030*M~
+HD*030**HLT**FAM~
+DTP*348*D8*20240101~
+INS*N*19*030*XN*A~
+REF*0F*123456789~
+NM1*IL*1*DOE*MARY~
+DMG*D8*20130214*F~
+HD*030**HLT**FAM~
+DTP*348*D8*20240101~
+INS*Y*18*030*XN*A***FT~
+REF*0F*555667777~
+NM1*IL*1*ROE*RICHARD****34*555667777~
+HD*030**HLT**EMP~
+DTP*348*D8*20240101~
+SE*31*0001~`
+
+// thisMonth is the next full file: the family moved, dropped dental,
+// took vision, and swapped a dependent, and a subscriber joined.
+const thisMonth = `ST*834*0002*005010X220A1~
+BGN*00*AUDIT2*20240601*1200****4~
+N1*P5*ACME CORP*FI*999888777~
+N1*IN*ABC INSURANCE*FI*654456654~
+INS*Y*18*030*XN*A***FT~
+REF*0F*123456789~
+NM1*IL*1*DOE*JOHN*P***34*123456789~
+N3*200 MAIN ST~
+N4*CAMP HILL*PA*17011~
+DMG*D8*19800816*M~
+HD*030**HLT**FAM~
+DTP*348*D8*20240101~
+HD*030**VIS**FAM~
+DTP*348*D8*20240601~
+INS*N*19*030*XN*A~
+REF*0F*123456789~
+NM1*IL*1*DOE*JAMES*E***34*234567890~
+DMG*D8*20111030*M~
+HD*030**HLT**FAM~
+DTP*348*D8*20240101~
+INS*N*19*030*XN*A~
+REF*0F*123456789~
+NM1*IL*1*DOE*LUCY****34*345678901~
+DMG*D8*20240410*F~
+HD*030**HLT**FAM~
+DTP*348*D8*20240410~
+INS*Y*18*030*XN*A***FT~
+REF*0F*555667777~
+NM1*IL*1*ROE*RICHARD****34*555667777~
+HD*030**HLT**EMP~
+DTP*348*D8*20240101~
+INS*Y*18*030*XN*A***FT~
+REF*0F*777888999~
+NM1*IL*1*POE*ANNA****34*777888999~
+HD*030**HLT**EMP~
+DTP*348*D8*20240601~
+SE*37*0002~`
+
+func fromDocument(t *testing.T, s string) *x834.Transaction {
+	t.Helper()
+	doc, err := x12.Decode(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := x834.FromDocument(doc, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromDocument() error: %v", err)
+	}
+	return m
+}
+
+// summary describes a member change as its kind, the member's first
+// name, and its changed fields and coverages.
+func summary(c x834.MemberChange) string {
+	m := c.New
+	if m == nil {
+		m = c.Old
+	}
+	s := c.Kind.String() + " " + m.Name.Name.FirstName
+	if len(c.Fields) > 0 {
+		s += " " + strings.Join(c.Fields, ",")
+	}
+	for _, cc := range c.Coverages {
+		hd := cc.New
+		if hd == nil {
+			hd = cc.Old
+		}
+		s += " " + cc.Kind.String() + ":" + hd.Coverage.InsuranceLine
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	old, new := fromDocument(t, lastMonth), fromDocument(t, thisMonth)
+	var got []string
+	for _, c := range x834.Compare(old, new) {
+		got = append(got, summary(c))
+	}
+	want := []string{
+		"changed JOHN address,coverage added:VIS terminated:DEN",
+		"added LUCY",
+		"added ANNA",
+		"terminated MARY",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare() mismatch (-want +got):\n%s", diff)
+	}
+	if changes := x834.Compare(new, new); len(changes) != 0 {
+		t.Errorf("Compare() of a file with itself = %v", changes)
+	}
+}
+
+func TestChangeFile(t *testing.T) {
+	old, new := fromDocument(t, lastMonth), fromDocument(t, thisMonth)
+	m := x834.ChangeFile(new, x834.Compare(old, new), "20240531")
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BGN*00*AUDIT2*20240601*1200****2",
+		"N1*P5*ACME CORP*FI*999888777",
+		"N1*IN*ABC INSURANCE*FI*654456654",
+		"INS*Y*18*001*AI*A***FT",
+		"REF*0F*123456789",
+		"NM1*IL*1*DOE*JOHN*P***34*123456789",
+		"N3*200 MAIN ST",
+		"N4*CAMP HILL*PA*17011",
+		"DMG*D8*19800816*M",
+		"HD*021**VIS**FAM",
+		"DTP*348*D8*20240601",
+		"HD*024**DEN**FAM",
+		"DTP*348*D8*20240101",
+		"DTP*349*D8*20240531",
+		"INS*N*19*021*28*A",
+		"REF*0F*123456789",
+		"NM1*IL*1*DOE*LUCY****34*345678901",
+		"DMG*D8*20240410*F",
+		"HD*021**HLT**FAM",
+		"DTP*348*D8*20240410",
+		"INS*Y*18*021*28*A***FT",
+		"REF*0F*777888999",
+		"NM1*IL*1*POE*ANNA****34*777888999",
+		"HD*021**HLT**EMP",
+		"DTP*348*D8*20240601",
+		"INS*N*19*024*07*A",
+		"REF*0F*123456789",
+		"DTP*357*D8*20240531",
+		"NM1*IL*1*DOE*MARY",
+		"DMG*D8*20130214*F",
+		"HD*024**HLT**FAM",
+		"DTP*348*D8*20240101",
+		"DTP*349*D8*20240531",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+	if old.Members[2].Detail.MaintenanceType != x834.MaintenanceAudit || len(old.Members[2].Coverages[0].Dates) != 1 {
+		t.Error("ChangeFile() modified the compared members")
+	}
+}
+
+func TestFromDocumentNo834(t *testing.T) {
+	doc, err := x12.Decode(strings.NewReader("ST*835*0001*005010X221A1~BPR*I*1*C*ACH~SE*3*0001~"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := []*x12.Document{
+		nil,
+		{},
+		{Interchange: &x12.Interchange{FunctionGroups: []*x12.FunctionGroup{nil, {Transactions: []*x12.Transaction{nil}}}}},
+		doc,
+	}
+	for i, doc := range docs {
+		if _, err := x834.FromDocument(doc, segments.DefaultDelimiters); !errors.Is(err, x12.ErrInvalidArgument) {
+			t.Errorf("FromDocument(docs[%d]) error = %v, want %v", i, err, x12.ErrInvalidArgument)
+		}
+	}
+
+	// A nil group is skipped.
+	doc, err = x12.Decode(strings.NewReader(lastMonth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Interchange.FunctionGroups = append([]*x12.FunctionGroup{nil}, doc.Interchange.FunctionGroups...)
+	if m, err := x834.FromDocument(doc, segments.DefaultDelimiters); err != nil || len(m.Members) == 0 {
+		t.Errorf("FromDocument() with a nil group error = %v, want the members", err)
+	}
+}
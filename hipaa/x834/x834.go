@@ -0,0 +1,271 @@
+// Package x834 is a typed model of the Benefit Enrollment and
+// Maintenance (834) transaction, implementation guide 005010X220A1.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X220A1
+// and maps them to a Transaction: the sponsor (usually the employer),
+// the payer, any brokers, and the members enrolled, changed, or
+// terminated. Each member carries its maintenance type and reason
+// (INS03 and INS04), its dates, and its health coverages (HD), each
+// with its own maintenance type and dates. Members are listed flat, a
+// subscriber followed by its dependents; Transaction.Dependents
+// regroups them by subscriber identifier. ToTransaction writes the
+// segments back in guide order, so a transaction that conforms to the
+// guide's loop structure round-trips unchanged.
+//
+// Employers often send full (audit) files rather than changes. Compare
+// matches the members of two such files by subscriber and member
+// identifier and reports those added, terminated, and changed, with
+// their coverage changes; ChangeFile turns the result into a change-only
+// 834.
+package x834
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// Maintenance type codes (INS03 and HD01).
+const (
+	MaintenanceChange        = "001"
+	MaintenanceAddition      = "021"
+	MaintenanceCancellation  = "024"
+	MaintenanceReinstatement = "025"
+	MaintenanceAudit         = "030"
+)
+
+// A Transaction is an 834 benefit enrollment transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X220A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	BGN          segments.BGN   `x12:"BGN"`
+	PolicyNumber *segments.REF  `x12:"REF"`
+	Dates        []segments.DTP `x12:"DTP"`
+	Totals       []segments.QTY `x12:"QTY"`
+	Sponsor      Organization   `x12:"1000A"`
+	Payer        Organization   `x12:"1000B"`
+	Brokers      []Broker       `x12:"1000C"`
+	Members      []Member       `x12:"2000"`
+}
+
+// An Organization is the Sponsor Name (1000A) or Payer (1000B) loop.
+type Organization struct {
+	Name segments.N1 `x12:"N1"`
+}
+
+// A Broker is the TPA/Broker Name loop (1000C).
+type Broker struct {
+	Name    segments.N1    `x12:"N1"`
+	Account *BrokerAccount `x12:"1100C"`
+}
+
+// A BrokerAccount is the TPA/Broker Account Information loop (1100C).
+type BrokerAccount struct {
+	Account x12.Segment `x12:"ACT"`
+}
+
+// A Member is the Member Level Detail loop (2000): one subscriber or
+// dependent and the coverages maintained for them.
+//
+// The additional reporting categories are bounded by an LS and LE
+// segment pair, which ToTransaction adds when ReportingCategories is not
+// empty and ReportingHeader is nil.
+type Member struct {
+	Detail              segments.INS        `x12:"INS"`
+	Subscriber          segments.REF        `x12:"REF,0F"`
+	References          []segments.REF      `x12:"REF"`
+	Dates               []segments.DTP      `x12:"DTP"`
+	Name                MemberName          `x12:"2100A"`
+	IncorrectName       *MemberName         `x12:"2100B"`
+	MailingAddress      *Entity             `x12:"2100C"`
+	Employers           []Entity            `x12:"2100D"`
+	Schools             []Entity            `x12:"2100E"`
+	CustodialParent     *Entity             `x12:"2100F"`
+	ResponsiblePersons  []Entity            `x12:"2100G"`
+	DropOffLocation     *Entity             `x12:"2100H"`
+	Disabilities        []Disability        `x12:"2200"`
+	Coverages           []Coverage          `x12:"2300"`
+	ReportingHeader     *segments.LS        `x12:"LS"`
+	ReportingCategories []ReportingCategory `x12:"2700"`
+	ReportingTrailer    *segments.LE        `x12:"LE"`
+}
+
+// A MemberName is the Member Name loop (2100A), or the Incorrect Member
+// Name loop (2100B) naming the member as previously reported.
+type MemberName struct {
+	Name              segments.NM1   `x12:"NM1"`
+	Contact           *segments.PER  `x12:"PER"`
+	Address           *segments.N3   `x12:"N3"`
+	City              *segments.N4   `x12:"N4"`
+	Demographics      *segments.DMG  `x12:"DMG"`
+	EmploymentClasses []x12.Segment  `x12:"EC"`
+	Income            *x12.Segment   `x12:"ICM"`
+	Amounts           []segments.AMT `x12:"AMT"`
+	Health            *x12.Segment   `x12:"HLH"`
+	Languages         []x12.Segment  `x12:"LUI"`
+}
+
+// An Entity is a name loop of the member level other than the member's
+// own (2100C through 2100H): a mailing address, an employer, a school, a
+// custodial parent, a responsible person, or a drop off location.
+type Entity struct {
+	Name    segments.NM1  `x12:"NM1"`
+	Contact *segments.PER `x12:"PER"`
+	Address *segments.N3  `x12:"N3"`
+	City    *segments.N4  `x12:"N4"`
+}
+
+// A Disability is the Disability Information loop (2200).
+type Disability struct {
+	Disability x12.Segment    `x12:"DSB"`
+	Dates      []segments.DTP `x12:"DTP"`
+}
+
+// A Coverage is the Health Coverage loop (2300): one line of insurance
+// the member is enrolled in, changed in, or terminated from.
+type Coverage struct {
+	Coverage      segments.HD              `x12:"HD"`
+	Dates         []segments.DTP           `x12:"DTP"`
+	Amounts       []segments.AMT           `x12:"AMT"`
+	References    []segments.REF           `x12:"REF"`
+	IDCards       []x12.Segment            `x12:"IDC"`
+	Providers     []Provider               `x12:"2310"`
+	OtherCoverage []CoordinationOfBenefits `x12:"2320"`
+}
+
+// A Provider is the Provider Information loop (2310): a provider, such
+// as a primary care provider, the member chose for the coverage.
+type Provider struct {
+	Line         segments.LX    `x12:"LX"`
+	Name         segments.NM1   `x12:"NM1"`
+	Address      []segments.N3  `x12:"N3"`
+	City         *segments.N4   `x12:"N4"`
+	Contacts     []segments.PER `x12:"PER"`
+	ChangeReason *x12.Segment   `x12:"PLA"`
+}
+
+// A CoordinationOfBenefits is the Coordination of Benefits loop (2320):
+// other insurance the member has.
+type CoordinationOfBenefits struct {
+	COB        x12.Segment      `x12:"COB"`
+	References []segments.REF   `x12:"REF"`
+	Dates      []segments.DTP   `x12:"DTP"`
+	Entities   []segments.Party `x12:"2330"`
+}
+
+// A ReportingCategory is the Member Reporting Categories loop (2700).
+type ReportingCategory struct {
+	Line     segments.LX `x12:"LX"`
+	Category *Category   `x12:"2750"`
+}
+
+// A Category is the Reporting Category loop (2750).
+type Category struct {
+	Name      segments.N1   `x12:"N1"`
+	Reference segments.REF  `x12:"REF"`
+	Date      *segments.DTP `x12:"DTP"`
+}
+
+// IsSubscriber reports whether m is a subscriber (INS01 "Y") rather than
+// a dependent.
+func (m *Member) IsSubscriber() bool {
+	return m.Detail.SubscriberIndicator == "Y"
+}
+
+// SubscriberID returns the subscriber identifier (REF02 of REF*0F),
+// which a subscriber shares with its dependents.
+func (m *Member) SubscriberID() string {
+	return m.Subscriber.ID
+}
+
+// MemberID returns the member's identification code (NM109 of 2100A),
+// which may be empty for a dependent.
+func (m *Member) MemberID() string {
+	return m.Name.Name.ID
+}
+
+// Date returns the first of m's member level dates with the qualifier,
+// such as "356" for the eligibility begin date, or nil.
+func (m *Member) Date(qualifier string) *segments.DTP {
+	return findDate(m.Dates, qualifier)
+}
+
+// Date returns the first of c's coverage dates with the qualifier, such
+// as "348" for the benefit begin date, or nil.
+func (c *Coverage) Date(qualifier string) *segments.DTP {
+	return findDate(c.Dates, qualifier)
+}
+
+func findDate(dates []segments.DTP, qualifier string) *segments.DTP {
+	for i := range dates {
+		if dates[i].Qualifier == qualifier {
+			return &dates[i]
+		}
+	}
+	return nil
+}
+
+// Subscribers returns the subscribers of t, in transaction order.
+func (t *Transaction) Subscribers() []*Member {
+	var ms []*Member
+	for i := range t.Members {
+		if t.Members[i].IsSubscriber() {
+			ms = append(ms, &t.Members[i])
+		}
+	}
+	return ms
+}
+
+// Dependents returns the dependents of t that share sub's subscriber
+// identifier, in transaction order.
+func (t *Transaction) Dependents(sub *Member) []*Member {
+	var ms []*Member
+	for i := range t.Members {
+		m := &t.Members[i]
+		if !m.IsSubscriber() && m.SubscriberID() == sub.SubscriberID() {
+			ms = append(ms, m)
+		}
+	}
+	return ms
+}
+
+// FromTransaction returns the model of tx, an 834 transaction of
+// 005010X220A1. Elements are split into components and repetitions with
+// d. It returns an error if a segment is out of place for the guide's
+// loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x834", hipaa.X220A1, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 834 transaction, with ST and SE segments
+// built from ControlNumber and Version. It adds the LS and LE segments
+// bounding each member's reporting categories to a copy of t, leaving t
+// unchanged.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	for i := range t.Members {
+		m := &t.Members[i]
+		if len(m.ReportingCategories) > 0 && m.ReportingHeader == nil {
+			m.ReportingHeader = &segments.LS{LoopID: "2700"}
+			m.ReportingTrailer = &segments.LE{LoopID: "2700"}
+		}
+	}
+	return model.Marshal("x834", hipaa.X220A1, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X220A1: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X220A1, tx)
+}
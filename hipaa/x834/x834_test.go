@@ -0,0 +1,169 @@
+package x834_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x834"
+	"github.com/tmc/x12/segments"
+)
+
+// enrollment adds a subscriber and dependent and terminates another
+// subscriber's coverage.
+const enrollment = `ST*834*0001*005010X220A1~
+BGN*00*12456*20240520*1200****2~
+REF*38*ABCD012354~
+DTP*007*D8*20240601~
+N1*P5*ACME CORP*FI*999888777~
+N1*IN*ABC INSURANCE*FI*654456654~
+INS*Y*18*021*28*A***FT~
+REF*0F*123456789~
+REF*1L*123456001~
+DTP*356*D8*20240601~
+NM1*IL*1*DOE*JOHN*P***34*123456789~
+PER*IP**HP*7172343334~
+N3*100 MARKET ST*APT 3G~
+N4*CAMP HILL*PA*17011~
+DMG*D8*19800816*M~
+HD*021**HLT**FAM~
+DTP*348*D8*20240601~
+INS*N*19*021*28*A~
+REF*0F*123456789~
+REF*1L*123456001~
+DTP*356*D8*20240601~
+NM1*IL*1*DOE*JAMES*E***34*234567890~
+DMG*D8*20111030*M~
+HD*021**HLT**FAM~
+DTP*348*D8*20240601~
+INS*Y*18*024*07*A***TE~
+REF*0F*555667777~
+DTP*357*D8*20240531~
+NM1*IL*1*ROE*RICHARD****34*555667777~
+HD*024**HLT**EMP~
+DTP*349*D8*20240531~
+LS*2700~
+LX*1~
+N1*75*PLAN CODE~
+REF*17*A1~
+LE*2700~
+SE*37*0001~`
+
+func TestRoundTrip(t *testing.T) {
+	tx := hipaatest.Decode(t, enrollment)
+	m, err := x834.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() error: %v", err)
+	}
+	got, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("ToTransaction() error: %v", err)
+	}
+	if diff := cmp.Diff(tx, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	m, err := x834.FromTransaction(hipaatest.Decode(t, enrollment), segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Sponsor.Name.Name != "ACME CORP" || m.Payer.Name.ID != "654456654" {
+		t.Errorf("sponsor = %+v, payer = %+v", m.Sponsor.Name, m.Payer.Name)
+	}
+	subs := m.Subscribers()
+	if len(subs) != 2 {
+		t.Fatalf("got %d subscribers, want 2", len(subs))
+	}
+	sub := subs[0]
+	if sub.SubscriberID() != "123456789" || sub.MemberID() != "123456789" || sub.Detail.MaintenanceType != x834.MaintenanceAddition || sub.Detail.MaintenanceReason != "28" {
+		t.Errorf("subscriber = %+v", sub.Detail)
+	}
+	deps := m.Dependents(sub)
+	if len(deps) != 1 || deps[0].Name.Name.FirstName != "JAMES" || deps[0].Detail.RelationshipCode != "19" {
+		t.Fatalf("Dependents() = %+v", deps)
+	}
+	if d := deps[0].Date("356"); d == nil || d.Period != "20240601" {
+		t.Errorf("dependent eligibility begin = %+v", d)
+	}
+	if len(m.Dependents(subs[1])) != 0 {
+		t.Error("terminated subscriber has dependents")
+	}
+
+	term := subs[1]
+	if term.Detail.MaintenanceType != x834.MaintenanceCancellation {
+		t.Errorf("INS03 = %q, want %q", term.Detail.MaintenanceType, x834.MaintenanceCancellation)
+	}
+	want := []x834.Coverage{{
+		Coverage: segments.HD{MaintenanceType: x834.MaintenanceCancellation, InsuranceLine: "HLT", CoverageLevel: "EMP"},
+		Dates:    []segments.DTP{{Qualifier: "349", FormatQualifier: "D8", Period: "20240531"}},
+	}}
+	if diff := cmp.Diff(want, term.Coverages); diff != "" {
+		t.Errorf("coverages mismatch (-want +got):\n%s", diff)
+	}
+	if d := term.Coverages[0].Date("349"); d == nil || d.Period != "20240531" {
+		t.Errorf("benefit end = %+v", d)
+	}
+	if len(term.ReportingCategories) != 1 || term.ReportingCategories[0].Category.Reference.ID != "A1" {
+		t.Errorf("reporting categories = %+v", term.ReportingCategories)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x834.Transaction{
+		ControlNumber: "0001",
+		BGN:           segments.BGN{PurposeCode: "00", ReferenceID: "REF1", Date: "20240105", Time: "0900", ActionCode: "2"},
+		Sponsor:       x834.Organization{Name: segments.N1{EntityIdentifierCode: "P5", Name: "ACME CORP", IDQualifier: "FI", ID: "999888777"}},
+		Payer:         x834.Organization{Name: segments.N1{EntityIdentifierCode: "IN", Name: "PAYER", IDQualifier: "FI", ID: "654456654"}},
+		Members: []x834.Member{{
+			Detail:     segments.INS{SubscriberIndicator: "Y", RelationshipCode: "18", MaintenanceType: x834.MaintenanceAddition, MaintenanceReason: "28", BenefitStatus: "A"},
+			Subscriber: segments.REF{Qualifier: "0F", ID: "W123"},
+			Name:       x834.MemberName{Name: segments.NM1{EntityIdentifierCode: "IL", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JANE", IDQualifier: "34", ID: "W123"}},
+			Coverages: []x834.Coverage{{
+				Coverage: segments.HD{MaintenanceType: x834.MaintenanceAddition, InsuranceLine: "DEN"},
+				Dates:    []segments.DTP{{Qualifier: "348", FormatQualifier: "D8", Period: "20240201"}},
+			}},
+			ReportingCategories: []x834.ReportingCategory{{
+				Line:     segments.LX{Number: "1"},
+				Category: &x834.Category{Name: segments.N1{EntityIdentifierCode: "75", Name: "DIVISION"}, Reference: segments.REF{Qualifier: "ZZ", ID: "EAST"}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := []string{
+		"BGN*00*REF1*20240105*0900****2",
+		"N1*P5*ACME CORP*FI*999888777",
+		"N1*IN*PAYER*FI*654456654",
+		"INS*Y*18*021*28*A",
+		"REF*0F*W123",
+		"NM1*IL*1*DOE*JANE****34*W123",
+		"HD*021**DEN",
+		"DTP*348*D8*20240201",
+		"LS*2700",
+		"LX*1",
+		"N1*75*DIVISION",
+		"REF*ZZ*EAST",
+		"LE*2700",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
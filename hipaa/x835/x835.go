@@ -0,0 +1,171 @@
+// Package x835 is a typed model of the Health Care Claim Payment/Advice
+// (835) transaction, implementation guide 005010X221A1.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X221A1
+// and maps them to a Transaction: the payment's financial information
+// and trace number, the payer and payee, the claim payments grouped
+// under header numbers, the service payments of each claim, and the
+// provider level adjustments. Claim and service adjustments are
+// available grouped by their CAS group code. ToTransaction writes the
+// segments back in guide order, so a transaction that conforms to the
+// guide's loop structure round-trips unchanged, a missing ST03 included.
+// FromTransaction rejects one that does not, such as a payment with a
+// REF*TJ in a claim payment (loop 2100), where the guide has no place
+// for it.
+package x835
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is an 835 payment/advice transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X221A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	Financial           segments.BPR   `x12:"BPR"`
+	Trace               segments.TRN   `x12:"TRN"`
+	Currency            *x12.Segment   `x12:"CUR"`
+	References          []segments.REF `x12:"REF"`
+	ProductionDate      *segments.DTM  `x12:"DTM"`
+	Payer               Payer          `x12:"1000A"`
+	Payee               Payee          `x12:"1000B"`
+	Details             []Detail       `x12:"2000"`
+	ProviderAdjustments []segments.PLB `x12:"PLB"`
+}
+
+// A Payer is the Payer Identification loop (1000A).
+type Payer struct {
+	Name       segments.N1    `x12:"N1"`
+	Address    segments.N3    `x12:"N3"`
+	City       segments.N4    `x12:"N4"`
+	References []segments.REF `x12:"REF"`
+	Contacts   []segments.PER `x12:"PER"`
+}
+
+// A Payee is the Payee Identification loop (1000B).
+type Payee struct {
+	Name           segments.N1    `x12:"N1"`
+	Address        *segments.N3   `x12:"N3"`
+	City           *segments.N4   `x12:"N4"`
+	References     []segments.REF `x12:"REF"`
+	DeliveryMethod *x12.Segment   `x12:"RDM"`
+}
+
+// A Detail is the Header Number loop (2000): a group of claim payments,
+// such as those of one provider or bill type, with optional summary
+// information about them.
+type Detail struct {
+	Number              segments.LX  `x12:"LX"`
+	ProviderSummary     *x12.Segment `x12:"TS3"`
+	SupplementalSummary *x12.Segment `x12:"TS2"`
+	Claims              []Claim      `x12:"2100"`
+}
+
+// A Claim is the Claim Payment Information loop (2100): the payer's
+// adjudication of one claim.
+type Claim struct {
+	Payment                segments.CLP   `x12:"CLP"`
+	Adjustments            []segments.CAS `x12:"CAS"`
+	Patient                segments.NM1   `x12:"NM1,QC"`
+	Insured                *segments.NM1  `x12:"NM1,IL"`
+	CorrectedName          *segments.NM1  `x12:"NM1,74"`
+	ServiceProvider        *segments.NM1  `x12:"NM1,82"`
+	CrossoverCarrier       *segments.NM1  `x12:"NM1,TT"`
+	CorrectedPriorityPayer *segments.NM1  `x12:"NM1,PR"`
+	OtherSubscriber        *segments.NM1  `x12:"NM1,GB"`
+	InpatientAdjudication  *x12.Segment   `x12:"MIA"`
+	OutpatientAdjudication *segments.MOA  `x12:"MOA"`
+	References             []segments.REF `x12:"REF"`
+	Dates                  []segments.DTM `x12:"DTM"`
+	Contacts               []segments.PER `x12:"PER"`
+	Amounts                []segments.AMT `x12:"AMT"`
+	Quantities             []segments.QTY `x12:"QTY"`
+	Services               []Service      `x12:"2110"`
+}
+
+// A Service is the Service Payment Information loop (2110): the payer's
+// adjudication of one service line of a claim.
+type Service struct {
+	Payment     segments.SVC   `x12:"SVC"`
+	Dates       []segments.DTM `x12:"DTM"`
+	Adjustments []segments.CAS `x12:"CAS"`
+	References  []segments.REF `x12:"REF"`
+	Amounts     []segments.AMT `x12:"AMT"`
+	Quantities  []segments.QTY `x12:"QTY"`
+	RemarkCodes []segments.LQ  `x12:"LQ"`
+}
+
+// Claims returns the claim payments of all of t's header numbers, in
+// order.
+func (t *Transaction) Claims() []*Claim {
+	var cs []*Claim
+	for i := range t.Details {
+		for j := range t.Details[i].Claims {
+			cs = append(cs, &t.Details[i].Claims[j])
+		}
+	}
+	return cs
+}
+
+// AdjustmentsByGroup returns the claim's adjustments keyed by their
+// group code, such as "CO" for contractual obligations or "PR" for
+// patient responsibility, in order within each group.
+func (c *Claim) AdjustmentsByGroup() map[string][]segments.Adjustment {
+	return byGroup(c.Adjustments)
+}
+
+// AdjustmentsByGroup returns the service's adjustments keyed by their
+// group code, in order within each group.
+func (s *Service) AdjustmentsByGroup() map[string][]segments.Adjustment {
+	return byGroup(s.Adjustments)
+}
+
+// byGroup gathers the adjustments of cas by group code. A group may
+// span several CAS segments when it has more than six adjustments.
+func byGroup(cas []segments.CAS) map[string][]segments.Adjustment {
+	if len(cas) == 0 {
+		return nil
+	}
+	groups := make(map[string][]segments.Adjustment)
+	for _, c := range cas {
+		for _, a := range c.Adjustments {
+			if a != (segments.Adjustment{}) {
+				groups[c.GroupCode] = append(groups[c.GroupCode], a)
+			}
+		}
+	}
+	return groups
+}
+
+// FromTransaction returns the model of tx, an 835 transaction of
+// 005010X221A1. Elements are split into components and repetitions
+// with d. It returns an error if a segment is out of place for the
+// guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x835", hipaa.X221A1, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 835 transaction, with ST and SE
+// segments built from ControlNumber and Version.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	return model.Marshal("x835", hipaa.X221A1, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X221A1: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X221A1, tx)
+}
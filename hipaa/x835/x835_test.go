@@ -0,0 +1,146 @@
+package x835_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x835"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+)
+
+func fixture(name string) string {
+	return filepath.Join("..", "..", "testdata", name)
+}
+
+// example8a sends a REF*TJ in loop 2100, where the guide does not allow
+// it: it is a known failure, which FromTransaction rejects.
+const example8a = "005010x221-example-8a-claim-submitted-incorrect-subscriber-patient-and-incorrect-id.edi"
+
+func TestRoundTripFixtures(t *testing.T) {
+	var paths []string
+	for _, path := range hipaatest.Glob(t, fixture("005010x221*.edi")) {
+		if filepath.Base(path) != example8a {
+			paths = append(paths, path)
+		}
+	}
+	hipaatest.RoundTrip(t, paths, x835.FromTransaction, nil)
+
+	tx, d := hipaatest.ReadFile(t, fixture(example8a))
+	if _, err := x835.FromTransaction(tx, d); !errors.Is(err, schema.ErrUnexpectedSegment) {
+		t.Errorf("FromTransaction(%s) error = %v, want %v", example8a, err, schema.ErrUnexpectedSegment)
+	}
+}
+
+func TestPayment(t *testing.T) {
+	tx, d := hipaatest.ReadFile(t, fixture("005010x221-example-5b.edi"))
+	m, err := x835.FromTransaction(tx, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Financial.Amount != "12.00" || m.Financial.PaymentMethod != "CHK" || m.Financial.Date != "20190816" {
+		t.Errorf("BPR = %+v, want a 12.00 check dated 20190816", m.Financial)
+	}
+	if m.Trace.ReferenceID != "CK NUMBER 1" {
+		t.Errorf("TRN02 = %q, want %q", m.Trace.ReferenceID, "CK NUMBER 1")
+	}
+	if m.Payer.Name.Name != "ANY PLAN USA" || m.Payee.Name.ID != "1123454567" {
+		t.Errorf("payer %+v, payee %+v", m.Payer.Name, m.Payee.Name)
+	}
+	claims := m.Claims()
+	if len(claims) != 1 {
+		t.Fatalf("Claims() returned %d claims, want 1", len(claims))
+	}
+	c := claims[0]
+	if c.Payment.ClaimID != "PCN" || c.Payment.Payment != "12" || c.Payment.PatientResponsibility != "10" {
+		t.Errorf("CLP = %+v", c.Payment)
+	}
+	if c.OutpatientAdjudication == nil || !cmp.Equal(c.OutpatientAdjudication.RemarkCodes, []string{"N25"}) {
+		t.Errorf("MOA = %+v, want remark code N25", c.OutpatientAdjudication)
+	}
+	s := c.Services[0]
+	if s.Payment.Procedure.Code != "99214" || s.Payment.Charge != "25" || s.Payment.Payment != "12" {
+		t.Errorf("SVC = %+v", s.Payment)
+	}
+	want := map[string][]segments.Adjustment{
+		"CO": {{ReasonCode: "45", Amount: "5"}, {ReasonCode: "161", Amount: "-2"}},
+		"PR": {{ReasonCode: "3", Amount: "10"}},
+	}
+	if diff := cmp.Diff(want, s.AdjustmentsByGroup()); diff != "" {
+		t.Errorf("AdjustmentsByGroup() mismatch (-want +got):\n%s", diff)
+	}
+	if got := c.AdjustmentsByGroup(); got != nil {
+		t.Errorf("claim AdjustmentsByGroup() = %v, want nil", got)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	m := &x835.Transaction{
+		ControlNumber: "0001",
+		Financial:     segments.BPR{HandlingCode: "I", Amount: "75", CreditDebit: "C", PaymentMethod: "ACH", PaymentFormat: "CCP", Date: "20240105"},
+		Trace:         segments.TRN{TypeCode: "1", ReferenceID: "EFT123", OriginatorID: "1999999999"},
+		Payer: x835.Payer{
+			Name:     segments.N1{EntityIdentifierCode: "PR", Name: "PAYER"},
+			Address:  segments.N3{Address1: "1 MAIN ST"},
+			City:     segments.N4{City: "TOWN", State: "OH", PostalCode: "45209"},
+			Contacts: []segments.PER{{FunctionCode: "BL", Communications: []segments.Communication{{Qualifier: "TE", Number: "8005551212"}}}},
+		},
+		Payee: x835.Payee{Name: segments.N1{EntityIdentifierCode: "PE", Name: "CLINIC", IDQualifier: "XX", ID: "1234567893"}},
+		Details: []x835.Detail{{
+			Number: segments.LX{Number: "1"},
+			Claims: []x835.Claim{{
+				Payment:     segments.CLP{ClaimID: "C1", StatusCode: "1", TotalCharge: "120", Payment: "100", PatientResponsibility: "20", ClaimFilingIndicator: "12"},
+				Adjustments: []segments.CAS{{GroupCode: "PR", Adjustments: []segments.Adjustment{{ReasonCode: "2", Amount: "20"}}}},
+				Patient:     segments.NM1{EntityIdentifierCode: "QC", EntityTypeQualifier: "1", LastName: "DOE", FirstName: "JANE"},
+				Services: []x835.Service{{
+					Payment:     segments.SVC{Procedure: segments.ProcedureIdentifier{Qualifier: "HC", Code: "99213"}, Charge: "120", Payment: "100"},
+					Adjustments: []segments.CAS{{GroupCode: "PR", Adjustments: []segments.Adjustment{{ReasonCode: "2", Amount: "20"}}}},
+					RemarkCodes: []segments.LQ{{CodeListQualifier: "HE", Code: "M15"}},
+				}},
+			}},
+		}},
+		ProviderAdjustments: []segments.PLB{{ProviderID: "1234567893", FiscalDate: "20241231", Adjustments: []segments.ProviderAdjustment{
+			{Identifier: segments.AdjustmentIdentifier{ReasonCode: "WO", ReferenceID: "OVERPAY1"}, Amount: "25"},
+		}}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, seg := range tx.Segments {
+		s := seg.ID
+		for _, e := range seg.Elements {
+			s += "*" + e.Value
+		}
+		lines = append(lines, s)
+	}
+	want := `BPR*I*75*C*ACH*CCP***********20240105
+TRN*1*EFT123*1999999999
+N1*PR*PAYER
+N3*1 MAIN ST
+N4*TOWN*OH*45209
+PER*BL**TE*8005551212
+N1*PE*CLINIC*XX*1234567893
+LX*1
+CLP*C1*1*120*100*20*12
+CAS*PR*2*20
+NM1*QC*1*DOE*JANE
+SVC*HC:99213*120*100
+CAS*PR*2*20
+LQ*HE*M15
+PLB*1234567893*20241231*WO:OVERPAY1*25`
+	if diff := cmp.Diff(strings.Split(want, "\n"), lines); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	if tx.Header.ImplementationConventionReference != "005010X221A1" || tx.Trailer.SegmentCount != "17" {
+		t.Errorf("ST/SE = %+v %+v", tx.Header, tx.Trailer)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+// Package x837d is a typed model of the Health Care Claim: Dental (837)
+// transaction, implementation guide 005010X224A2.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X224A2
+// and maps them to a Transaction: billing providers, their subscribers
+// and patients, the claims of each, and the claims' service lines. The
+// dental composites are decoded into fields: the oral cavity
+// designation of SV3 (segments.OralCavity), and the tooth number and
+// surfaces of TOO (segments.ToothSurface). ToTransaction writes the
+// segments back in guide order, so a transaction that conforms to the
+// guide's loop structure round-trips unchanged.
+package x837d
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is an 837 dental claim transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X224A2" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT              segments.BHT      `x12:"BHT"`
+	Submitter        segments.Party    `x12:"1000A"`
+	Receiver         segments.Party    `x12:"1000B"`
+	BillingProviders []BillingProvider `x12:"2000A"`
+}
+
+// A BillingProvider is the Billing Provider Hierarchical Level (loop
+// 2000A) and the subscribers billed under it.
+type BillingProvider struct {
+	HL           segments.HL     `x12:"HL"`
+	Specialty    *segments.PRV   `x12:"PRV"`
+	Currency     *x12.Segment    `x12:"CUR"`
+	Name         segments.Party  `x12:"2010AA"`
+	PayToAddress *segments.Party `x12:"2010AB"`
+	PayToPlan    *segments.Party `x12:"2010AC"`
+	Subscribers  []Subscriber    `x12:"2000B"`
+}
+
+// A Subscriber is the Subscriber Hierarchical Level (loop 2000B). Claims
+// holds the subscriber's own claims, when the subscriber is the patient;
+// Patients holds the dependents with claims of their own.
+type Subscriber struct {
+	HL       segments.HL    `x12:"HL"`
+	Info     segments.SBR   `x12:"SBR"`
+	Name     segments.Party `x12:"2010BA"`
+	Payer    segments.Party `x12:"2010BB"`
+	Claims   []Claim        `x12:"2300"`
+	Patients []Patient      `x12:"2000C"`
+}
+
+// A Patient is the Patient Hierarchical Level (loop 2000C) of a patient
+// other than the subscriber.
+type Patient struct {
+	HL     segments.HL    `x12:"HL"`
+	Info   segments.PAT   `x12:"PAT"`
+	Name   segments.Party `x12:"2010CA"`
+	Claims []Claim        `x12:"2300"`
+}
+
+// A Claim is the Claim Information loop (2300).
+type Claim struct {
+	Claim               segments.CLM      `x12:"CLM"`
+	Dates               []segments.DTP    `x12:"DTP"`
+	Orthodontics        *segments.DN1     `x12:"DN1"`
+	ToothStatus         []segments.DN2    `x12:"DN2"`
+	Paperwork           []segments.PWK    `x12:"PWK"`
+	Contract            *segments.CN1     `x12:"CN1"`
+	PatientAmountPaid   *segments.AMT     `x12:"AMT"`
+	References          []segments.REF    `x12:"REF"`
+	FileInformation     []segments.K3     `x12:"K3"`
+	Note                *segments.NTE     `x12:"NTE"`
+	Diagnoses           *segments.HI      `x12:"HI"`
+	Pricing             *segments.HCP     `x12:"HCP"`
+	ReferringProviders  []segments.Party  `x12:"2310A"`
+	RenderingProvider   *segments.Party   `x12:"2310B"`
+	ServiceFacility     *segments.Party   `x12:"2310C"`
+	AssistantSurgeon    *segments.Party   `x12:"2310D"`
+	SupervisingProvider *segments.Party   `x12:"2310E"`
+	OtherSubscribers    []OtherSubscriber `x12:"2320"`
+	ServiceLines        []ServiceLine     `x12:"2400"`
+}
+
+// An OtherSubscriber is the Other Subscriber Information loop (2320):
+// another payer responsible for the claim and how it adjudicated it.
+type OtherSubscriber struct {
+	Info                   segments.SBR     `x12:"SBR"`
+	Adjustments            []segments.CAS   `x12:"CAS"`
+	Amounts                []segments.AMT   `x12:"AMT"`
+	OtherInsurance         x12.Segment      `x12:"OI"`
+	OutpatientAdjudication *x12.Segment     `x12:"MOA"`
+	Subscriber             segments.Party   `x12:"2330A"`
+	Payer                  segments.Party   `x12:"2330B"`
+	ReferringProviders     []segments.Party `x12:"2330C"`
+	RenderingProvider      *segments.Party  `x12:"2330D"`
+	SupervisingProvider    *segments.Party  `x12:"2330E"`
+	BillingProvider        *segments.Party  `x12:"2330F"`
+	ServiceFacility        *segments.Party  `x12:"2330G"`
+	AssistantSurgeon       *segments.Party  `x12:"2330H"`
+}
+
+// A ServiceLine is the Service Line Number loop (2400).
+type ServiceLine struct {
+	Number              segments.LX     `x12:"LX"`
+	Service             segments.SV3    `x12:"SV3"`
+	Teeth               []segments.TOO  `x12:"TOO"`
+	Dates               []segments.DTP  `x12:"DTP"`
+	Anesthesia          []segments.QTY  `x12:"QTY"`
+	References          []segments.REF  `x12:"REF"`
+	SalesTax            *segments.AMT   `x12:"AMT"`
+	Note                *segments.NTE   `x12:"NTE"`
+	Pricing             *segments.HCP   `x12:"HCP"`
+	RenderingProvider   *segments.Party `x12:"2420A"`
+	AssistantSurgeon    *segments.Party `x12:"2420B"`
+	SupervisingProvider *segments.Party `x12:"2420C"`
+	ServiceFacility     *segments.Party `x12:"2420D"`
+	Adjudications       []Adjudication  `x12:"2430"`
+}
+
+// An Adjudication is the Line Adjudication Information loop (2430): how
+// another payer adjudicated the line.
+type Adjudication struct {
+	Adjudication       segments.SVD   `x12:"SVD"`
+	Adjustments        []segments.CAS `x12:"CAS"`
+	Date               segments.DTP   `x12:"DTP"`
+	RemainingLiability *segments.AMT  `x12:"AMT"`
+}
+
+// FromTransaction returns the model of tx, an 837 transaction of
+// 005010X224A2. Elements are split into components and repetitions
+// with d. It returns an error if a segment is out of place for the
+// guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x837d", hipaa.X224A2, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 837 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged: levels without an HL01 are
+// numbered in order, and HL02, HL03, and HL04 follow from the model's
+// nesting.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	for i := range t.BillingProviders {
+		bp := &t.BillingProviders[i]
+		bpID := levels.Number(&bp.HL, "20", "", len(bp.Subscribers) > 0)
+		for j := range bp.Subscribers {
+			sub := &bp.Subscribers[j]
+			subID := levels.Number(&sub.HL, "22", bpID, len(sub.Patients) > 0)
+			for k := range sub.Patients {
+				levels.Number(&sub.Patients[k].HL, "23", subID, false)
+			}
+		}
+	}
+	return model.Marshal("x837d", hipaa.X224A2, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X224A2: loop
+// structure, segment usage and repeats, and the guide's situational
+// rules.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X224A2, tx)
+}
@@ -0,0 +1,199 @@
+package x837d_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x837d"
+	"github.com/tmc/x12/segments"
+)
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, filepath.Join("..", "..", "testdata", "005010x224*.edi")), x837d.FromTransaction, nil)
+}
+
+func TestDentalComposites(t *testing.T) {
+	const input = `ST*837*0002*005010X224A2~
+BHT*0019*00*0123*20061123*1023*CH~
+NM1*41*2*PREMIER BILLING SERVICE*****46*567890~
+PER*IC*JERRY*TE*7176149999~
+NM1*40*2*KEY INSURANCE COMPANY*****46*999996666~
+HL*1**20*1~
+NM1*85*2*DENTAL ASSOCIATES*****XX*4567890123~
+N3*234 SEAWAY ST~
+N4*MIAMI*FL*33111~
+REF*EI*587654321~
+HL*2*1*22*0~
+SBR*P*18*******CI~
+NM1*IL*1*SMITH*JANE****MI*JS00111223333~
+N3*236 N MAIN ST~
+N4*MIAMI*FL*33413~
+DMG*D8*19620501*F~
+NM1*PR*2*KEY INSURANCE COMPANY*****PI*999996666~
+CLM*26403774*350***11:B:1*Y*A*Y*I~
+DN2*3*M~
+LX*1~
+SV3*AD:D2392*150**10:20****1~
+TOO*JP*3*M:O:D~
+DTP*472*D8*20061109~
+LX*2~
+SV3*AD:D4341*200*11*01~
+SE*26*0002~`
+	doc, err := x12.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := doc.Interchange.FunctionGroups[0].Transactions[0]
+	m, err := x837d.FromTransaction(tx, segments.DelimitersOf(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := m.BillingProviders[0].Subscribers[0].Claims[0]
+	if diff := cmp.Diff([]segments.DN2{{ToothNumber: "3", StatusCode: "M"}}, c.ToothStatus); diff != "" {
+		t.Errorf("ToothStatus mismatch (-want +got):\n%s", diff)
+	}
+	l := c.ServiceLines[0]
+	if got := l.Service.OralCavity.Areas(); !cmp.Equal(got, []string{"10", "20"}) {
+		t.Errorf("line 1 oral cavity = %v, want [10 20]", got)
+	}
+	want := []segments.TOO{{CodeListQualifier: "JP", ToothNumber: "3", Surface: segments.ToothSurface{Surface1: "M", Surface2: "O", Surface3: "D"}}}
+	if diff := cmp.Diff(want, l.Teeth); diff != "" {
+		t.Errorf("Teeth mismatch (-want +got):\n%s", diff)
+	}
+	if got := l.Teeth[0].Surface.Surfaces(); !cmp.Equal(got, []string{"M", "O", "D"}) {
+		t.Errorf("Surfaces() = %v", got)
+	}
+	if got := c.ServiceLines[1].Service; got.PlaceOfService != "11" || got.OralCavity.Area1 != "01" || got.OralCavity.Areas()[0] != "01" {
+		t.Errorf("line 2 SV3 = %+v, want place of service 11 in area 01", got)
+	}
+
+	out, err := m.ToTransaction(segments.DelimitersOf(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(tx, out); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DelimitersOf(doc)) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	party := func(code, typ, name, idq, id string) segments.Party {
+		return segments.Party{Name: segments.NM1{EntityIdentifierCode: code, EntityTypeQualifier: typ, LastName: name, IDQualifier: idq, ID: id}}
+	}
+	billing := party("85", "2", "DENTAL ASSOCIATES", "XX", "4567890123")
+	billing.Address = &segments.N3{Address1: "234 SEAWAY ST"}
+	billing.City = &segments.N4{City: "MIAMI", State: "FL", PostalCode: "33111"}
+	billing.References = []segments.REF{{Qualifier: "EI", ID: "587654321"}}
+	subscriber := party("IL", "1", "SMITH", "MI", "JS00111223333")
+	subscriber.Name.FirstName = "JANE"
+	patient := party("QC", "1", "SMITH", "", "")
+	patient.Name.FirstName = "TED"
+	patient.Address = &segments.N3{Address1: "236 N MAIN ST"}
+	patient.City = &segments.N4{City: "MIAMI", State: "FL", PostalCode: "33413"}
+	patient.Demographics = &segments.DMG{FormatQualifier: "D8", BirthDate: "19920501", Gender: "M"}
+	submitter := party("41", "2", "PREMIER BILLING SERVICE", "46", "567890")
+	submitter.Contacts = []segments.PER{{FunctionCode: "IC", Name: "JERRY", Communications: []segments.Communication{{Qualifier: "TE", Number: "7176149999"}}}}
+
+	m := &x837d.Transaction{
+		ControlNumber: "0002",
+		BHT:           segments.BHT{StructureCode: "0019", PurposeCode: "00", ReferenceID: "0123", Date: "20061123", Time: "1023", TransactionType: "CH"},
+		Submitter:     submitter,
+		Receiver:      party("40", "2", "KEY INSURANCE COMPANY", "46", "999996666"),
+		BillingProviders: []x837d.BillingProvider{{
+			Name: billing,
+			Subscribers: []x837d.Subscriber{{
+				Info:  segments.SBR{PayerResponsibility: "P", ClaimFilingIndicator: "CI"},
+				Name:  subscriber,
+				Payer: party("PR", "2", "KEY INSURANCE COMPANY", "PI", "999996666"),
+				Patients: []x837d.Patient{{
+					Info: segments.PAT{RelationshipCode: "19"},
+					Name: patient,
+					Claims: []x837d.Claim{{
+						Claim: segments.CLM{
+							ClaimID:                 "26403774",
+							TotalCharge:             "150",
+							Location:                segments.ServiceLocation{FacilityCode: "11", FacilityCodeQualifier: "B", FrequencyCode: "1"},
+							ProviderSignature:       "Y",
+							AssignmentParticipation: "A",
+							BenefitsAssignment:      "Y",
+							ReleaseOfInformation:    "I",
+						},
+						ServiceLines: []x837d.ServiceLine{{
+							Number:  segments.LX{Number: "1"},
+							Service: segments.SV3{Procedure: segments.ProcedureIdentifier{Qualifier: "AD", Code: "D2392"}, Charge: "150", Quantity: "1"},
+							Teeth:   []segments.TOO{{CodeListQualifier: "JP", ToothNumber: "3", Surface: segments.ToothSurface{Surface1: "M", Surface2: "O"}}},
+							Dates:   []segments.DTP{{Qualifier: "472", FormatQualifier: "D8", Period: "20061109"}},
+						}},
+					}},
+				}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	for _, seg := range tx.Segments {
+		b.WriteString(seg.ID)
+		for _, e := range seg.Elements {
+			b.WriteString("*" + e.Value)
+		}
+		b.WriteString("~\n")
+	}
+	want := `BHT*0019*00*0123*20061123*1023*CH~
+NM1*41*2*PREMIER BILLING SERVICE*****46*567890~
+PER*IC*JERRY*TE*7176149999~
+NM1*40*2*KEY INSURANCE COMPANY*****46*999996666~
+HL*1**20*1~
+NM1*85*2*DENTAL ASSOCIATES*****XX*4567890123~
+N3*234 SEAWAY ST~
+N4*MIAMI*FL*33111~
+REF*EI*587654321~
+HL*2*1*22*1~
+SBR*P********CI~
+NM1*IL*1*SMITH*JANE****MI*JS00111223333~
+NM1*PR*2*KEY INSURANCE COMPANY*****PI*999996666~
+HL*3*2*23*0~
+PAT*19~
+NM1*QC*1*SMITH*TED~
+N3*236 N MAIN ST~
+N4*MIAMI*FL*33413~
+DMG*D8*19920501*M~
+CLM*26403774*150***11:B:1*Y*A*Y*I~
+LX*1~
+SV3*AD:D2392*150****1~
+TOO*JP*3*M:O~
+DTP*472*D8*20061109~
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	if tx.Header.ImplementationConventionReference != "005010X224A2" || tx.Trailer.SegmentCount != "26" {
+		t.Errorf("ST/SE = %+v %+v", tx.Header, tx.Trailer)
+	}
+	// The HL values are filled in on a copy.
+	if hl := m.BillingProviders[0].Subscribers[0].Patients[0].HL; hl != (segments.HL{}) {
+		t.Errorf("ToTransaction() set the model's patient HL to %+v", hl)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+
+	// A claim read without ST03 is written back without one.
+	tx.Header.ImplementationConventionReference = ""
+	read, err := x837d.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := read.ToTransaction(segments.DefaultDelimiters); err != nil || out.Header.ImplementationConventionReference != "" {
+		t.Errorf("ToTransaction() of a claim read without ST03 = %+v, %v", out.Header, err)
+	}
+}
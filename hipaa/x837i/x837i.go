@@ -0,0 +1,281 @@
+// Package x837i is a typed model of the Health Care Claim: Institutional
+// (837) transaction, implementation guide 005010X223A2.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X223A2
+// and maps them to a Transaction: billing providers, their subscribers
+// and patients, the claims of each, and the claims' service lines. A
+// claim's HI segments are kept by type, and the UB-04 value, occurrence,
+// occurrence span, and condition codes they carry are available through
+// the Claim's methods. ToTransaction writes the segments back in guide
+// order, so a transaction that conforms to the guide's loop structure
+// round-trips unchanged.
+package x837i
+
+import (
+	"strings"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is an 837 institutional claim transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X223A2" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT              segments.BHT      `x12:"BHT"`
+	Submitter        segments.Party    `x12:"1000A"`
+	Receiver         segments.Party    `x12:"1000B"`
+	BillingProviders []BillingProvider `x12:"2000A"`
+}
+
+// A BillingProvider is the Billing Provider Hierarchical Level (loop
+// 2000A) and the subscribers billed under it.
+type BillingProvider struct {
+	HL           segments.HL     `x12:"HL"`
+	Specialty    *segments.PRV   `x12:"PRV"`
+	Currency     *x12.Segment    `x12:"CUR"`
+	Name         segments.Party  `x12:"2010AA"`
+	PayToAddress *segments.Party `x12:"2010AB"`
+	PayToPlan    *segments.Party `x12:"2010AC"`
+	Subscribers  []Subscriber    `x12:"2000B"`
+}
+
+// A Subscriber is the Subscriber Hierarchical Level (loop 2000B). Claims
+// holds the subscriber's own claims, when the subscriber is the patient;
+// Patients holds the dependents with claims of their own.
+type Subscriber struct {
+	HL       segments.HL    `x12:"HL"`
+	Info     segments.SBR   `x12:"SBR"`
+	Name     segments.Party `x12:"2010BA"`
+	Payer    segments.Party `x12:"2010BB"`
+	Claims   []Claim        `x12:"2300"`
+	Patients []Patient      `x12:"2000C"`
+}
+
+// A Patient is the Patient Hierarchical Level (loop 2000C) of a patient
+// other than the subscriber.
+type Patient struct {
+	HL     segments.HL    `x12:"HL"`
+	Info   segments.PAT   `x12:"PAT"`
+	Name   segments.Party `x12:"2010CA"`
+	Claims []Claim        `x12:"2300"`
+}
+
+// A Claim is the Claim Information loop (2300).
+type Claim struct {
+	Claim              segments.CLM   `x12:"CLM"`
+	Dates              []segments.DTP `x12:"DTP"`
+	InstitutionalCodes *segments.CL1  `x12:"CL1"`
+	Paperwork          []segments.PWK `x12:"PWK"`
+	Contract           *segments.CN1  `x12:"CN1"`
+	EstimatedAmountDue *segments.AMT  `x12:"AMT"`
+	References         []segments.REF `x12:"REF"`
+	FileInformation    []segments.K3  `x12:"K3"`
+	Notes              []segments.NTE `x12:"NTE"`
+	EPSDTReferral      *segments.CRC  `x12:"CRC"`
+
+	PrincipalDiagnosis segments.HI   `x12:"HI,ABK,BK"`
+	AdmittingDiagnosis *segments.HI  `x12:"HI,ABJ,BJ"`
+	ReasonsForVisit    *segments.HI  `x12:"HI,APR,PR"`
+	ExternalCauses     *segments.HI  `x12:"HI,ABN,BN"`
+	DRG                *segments.HI  `x12:"HI,DR"`
+	OtherDiagnoses     []segments.HI `x12:"HI,ABF,BF"`
+	PrincipalProcedure *segments.HI  `x12:"HI,BBR,BR,CAH"`
+	OtherProcedures    []segments.HI `x12:"HI,BBQ,BQ"`
+	OccurrenceSpans    []segments.HI `x12:"HI,BI"`
+	Occurrences        []segments.HI `x12:"HI,BH"`
+	Values             []segments.HI `x12:"HI,BE"`
+	Conditions         []segments.HI `x12:"HI,BG"`
+	TreatmentCodes     []segments.HI `x12:"HI,TC"`
+
+	Pricing                 *segments.HCP     `x12:"HCP"`
+	AttendingProvider       *segments.Party   `x12:"2310A"`
+	OperatingPhysician      *segments.Party   `x12:"2310B"`
+	OtherOperatingPhysician *segments.Party   `x12:"2310C"`
+	RenderingProvider       *segments.Party   `x12:"2310D"`
+	ServiceFacility         *segments.Party   `x12:"2310E"`
+	ReferringProvider       *segments.Party   `x12:"2310F"`
+	OtherSubscribers        []OtherSubscriber `x12:"2320"`
+	ServiceLines            []ServiceLine     `x12:"2400"`
+}
+
+// A ValueCode is a UB-04 value code and its amount, from an HI segment
+// with the BE qualifier.
+type ValueCode struct {
+	Code   string
+	Amount string
+}
+
+// An Occurrence is a UB-04 occurrence code and its date, from an HI
+// segment with the BH qualifier.
+type Occurrence struct {
+	Code string
+	Date string // CCYYMMDD
+}
+
+// An OccurrenceSpan is a UB-04 occurrence span code and its dates, from
+// an HI segment with the BI qualifier.
+type OccurrenceSpan struct {
+	Code    string
+	From    string // CCYYMMDD
+	Through string // CCYYMMDD
+}
+
+// ValueCodes returns the claim's value codes, in order.
+func (c *Claim) ValueCodes() []ValueCode {
+	var vs []ValueCode
+	for _, code := range codes(c.Values) {
+		vs = append(vs, ValueCode{Code: code.Code, Amount: code.Amount})
+	}
+	return vs
+}
+
+// OccurrenceCodes returns the claim's occurrence codes, in order.
+func (c *Claim) OccurrenceCodes() []Occurrence {
+	var os []Occurrence
+	for _, code := range codes(c.Occurrences) {
+		os = append(os, Occurrence{Code: code.Code, Date: code.Date})
+	}
+	return os
+}
+
+// OccurrenceSpanCodes returns the claim's occurrence span codes, in
+// order. A span given as a single date (D8) begins and ends on it.
+func (c *Claim) OccurrenceSpanCodes() []OccurrenceSpan {
+	var ss []OccurrenceSpan
+	for _, code := range codes(c.OccurrenceSpans) {
+		from, through, ok := strings.Cut(code.Date, "-")
+		if !ok {
+			through = from
+		}
+		ss = append(ss, OccurrenceSpan{Code: code.Code, From: from, Through: through})
+	}
+	return ss
+}
+
+// ConditionCodes returns the claim's condition codes, in order.
+func (c *Claim) ConditionCodes() []string {
+	var cs []string
+	for _, code := range codes(c.Conditions) {
+		cs = append(cs, code.Code)
+	}
+	return cs
+}
+
+// Diagnoses returns the claim's principal and other diagnosis codes, in
+// order.
+func (c *Claim) Diagnoses() []segments.HealthCareCode {
+	return append(codes([]segments.HI{c.PrincipalDiagnosis}), codes(c.OtherDiagnoses)...)
+}
+
+// codes returns the codes of his, in order.
+func codes(his []segments.HI) []segments.HealthCareCode {
+	var cs []segments.HealthCareCode
+	for _, hi := range his {
+		cs = append(cs, hi.Codes...)
+	}
+	return cs
+}
+
+// An OtherSubscriber is the Other Subscriber Information loop (2320):
+// another payer responsible for the claim and how it adjudicated it.
+type OtherSubscriber struct {
+	Info                    segments.SBR    `x12:"SBR"`
+	Adjustments             []segments.CAS  `x12:"CAS"`
+	Amounts                 []segments.AMT  `x12:"AMT"`
+	OtherInsurance          x12.Segment     `x12:"OI"`
+	InpatientAdjudication   *x12.Segment    `x12:"MIA"`
+	OutpatientAdjudication  *x12.Segment    `x12:"MOA"`
+	Subscriber              segments.Party  `x12:"2330A"`
+	Payer                   segments.Party  `x12:"2330B"`
+	AttendingProvider       *segments.Party `x12:"2330C"`
+	OperatingPhysician      *segments.Party `x12:"2330D"`
+	OtherOperatingPhysician *segments.Party `x12:"2330E"`
+	ServiceFacility         *segments.Party `x12:"2330F"`
+	RenderingProvider       *segments.Party `x12:"2330G"`
+	ReferringProvider       *segments.Party `x12:"2330H"`
+	BillingProvider         *segments.Party `x12:"2330I"`
+}
+
+// A ServiceLine is the Service Line Number loop (2400).
+type ServiceLine struct {
+	Number                  segments.LX     `x12:"LX"`
+	Service                 segments.SV2    `x12:"SV2"`
+	Paperwork               []segments.PWK  `x12:"PWK"`
+	Date                    *segments.DTP   `x12:"DTP"`
+	References              []segments.REF  `x12:"REF"`
+	Amounts                 []segments.AMT  `x12:"AMT"`
+	Note                    *segments.NTE   `x12:"NTE"`
+	Pricing                 *segments.HCP   `x12:"HCP"`
+	Drug                    *Drug           `x12:"2410"`
+	OperatingPhysician      *segments.Party `x12:"2420A"`
+	OtherOperatingPhysician *segments.Party `x12:"2420B"`
+	RenderingProvider       *segments.Party `x12:"2420C"`
+	ReferringProvider       *segments.Party `x12:"2420D"`
+	Adjudications           []Adjudication  `x12:"2430"`
+}
+
+// A Drug is the Drug Identification loop (2410).
+type Drug struct {
+	Identification segments.LIN  `x12:"LIN"`
+	Quantity       segments.CTP  `x12:"CTP"`
+	Reference      *segments.REF `x12:"REF"`
+}
+
+// An Adjudication is the Line Adjudication Information loop (2430): how
+// another payer adjudicated the line.
+type Adjudication struct {
+	Adjudication       segments.SVD   `x12:"SVD"`
+	Adjustments        []segments.CAS `x12:"CAS"`
+	Date               segments.DTP   `x12:"DTP"`
+	RemainingLiability *segments.AMT  `x12:"AMT"`
+}
+
+// FromTransaction returns the model of tx, an 837 transaction of
+// 005010X223A2. Elements are split into components and repetitions
+// with d. It returns an error if a segment is out of place for the
+// guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x837i", hipaa.X223A2, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 837 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged: levels without an HL01 are
+// numbered in order, and HL02, HL03, and HL04 follow from the model's
+// nesting.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	for i := range t.BillingProviders {
+		bp := &t.BillingProviders[i]
+		bpID := levels.Number(&bp.HL, "20", "", len(bp.Subscribers) > 0)
+		for j := range bp.Subscribers {
+			sub := &bp.Subscribers[j]
+			subID := levels.Number(&sub.HL, "22", bpID, len(sub.Patients) > 0)
+			for k := range sub.Patients {
+				levels.Number(&sub.Patients[k].HL, "23", subID, false)
+			}
+		}
+	}
+	return model.Marshal("x837i", hipaa.X223A2, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X223A2: loop
+// structure, segment usage and repeats, and the guide's situational
+// rules.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X223A2, tx)
+}
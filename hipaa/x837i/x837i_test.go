@@ -0,0 +1,104 @@
+package x837i_test
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x837i"
+	"github.com/tmc/x12/segments"
+)
+
+func fixture(name string) string {
+	return filepath.Join("..", "..", "testdata", name)
+}
+
+func TestRoundTripFixtures(t *testing.T) {
+	// Example 1a miscounts its segments, so SE01 is checked against the
+	// count rather than the fixture.
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, fixture("005010x223*.edi")), x837i.FromTransaction, func(t *testing.T, _ string, _ *x837i.Transaction, got *x12.Transaction) {
+		if want := strconv.Itoa(len(got.Segments) + 2); got.Trailer.SegmentCount != want {
+			t.Errorf("SE01 = %s, want %s", got.Trailer.SegmentCount, want)
+		}
+	}, cmpopts.IgnoreFields(x12.SE{}, "SegmentCount"))
+}
+
+// firstClaim returns the first claim of m's first subscriber, or of its
+// first patient.
+func firstClaim(t *testing.T, m *x837i.Transaction) *x837i.Claim {
+	t.Helper()
+	sub := &m.BillingProviders[0].Subscribers[0]
+	if len(sub.Claims) > 0 {
+		return &sub.Claims[0]
+	}
+	return &sub.Patients[0].Claims[0]
+}
+
+func TestUBCodes(t *testing.T) {
+	tx, d := hipaatest.ReadFile(t, fixture("005010x223-example-1a-institutional-claim.edi"))
+	m, err := x837i.FromTransaction(tx, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := firstClaim(t, m)
+	if diff := cmp.Diff([]x837i.ValueCode{{Code: "A2", Amount: "15.31"}}, c.ValueCodes()); diff != "" {
+		t.Errorf("ValueCodes() mismatch (-want +got):\n%s", diff)
+	}
+	wantOccurrences := []x837i.Occurrence{{"A1", "19261111"}, {"A2", "19911101"}, {"B1", "19261111"}, {"B2", "19870101"}}
+	if diff := cmp.Diff(wantOccurrences, c.OccurrenceCodes()); diff != "" {
+		t.Errorf("OccurrenceCodes() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"09"}, c.ConditionCodes()); diff != "" {
+		t.Errorf("ConditionCodes() mismatch (-want +got):\n%s", diff)
+	}
+	var diags []string
+	for _, code := range c.Diagnoses() {
+		diags = append(diags, code.Code)
+	}
+	if diff := cmp.Diff([]string{"3669", "4019", "79431"}, diags); diff != "" {
+		t.Errorf("Diagnoses() mismatch (-want +got):\n%s", diff)
+	}
+	if c.InstitutionalCodes == nil || c.InstitutionalCodes.AdmissionType != "3" || c.InstitutionalCodes.PatientStatus != "01" {
+		t.Errorf("CL1 = %+v, want admission type 3, status 01", c.InstitutionalCodes)
+	}
+	if got := c.ServiceLines[1].Service; got.RevenueCode != "0730" || got.Procedure.Code != "93005" || got.Quantity != "3" {
+		t.Errorf("SV2 = %+v, want revenue code 0730 for 93005 x 3", got)
+	}
+	if c.AttendingProvider == nil || c.AttendingProvider.Name.LastName != "JONES" {
+		t.Errorf("AttendingProvider = %+v", c.AttendingProvider)
+	}
+
+	c.OccurrenceSpans = []segments.HI{{Codes: []segments.HealthCareCode{
+		{Qualifier: "BI", Code: "70", DateFormat: "RD8", Date: "20050101-20050105"},
+		{Qualifier: "BI", Code: "74", DateFormat: "D8", Date: "20050110"},
+	}}}
+	want := []x837i.OccurrenceSpan{{"70", "20050101", "20050105"}, {"74", "20050110", "20050110"}}
+	if diff := cmp.Diff(want, c.OccurrenceSpanCodes()); diff != "" {
+		t.Errorf("OccurrenceSpanCodes() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepricing(t *testing.T) {
+	tx, d := hipaatest.ReadFile(t, fixture("005010x223-example-1c-ppo-repriced-claim.edi"))
+	m, err := x837i.FromTransaction(tx, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := firstClaim(t, m)
+	want := &segments.HCP{PricingMethodology: "03", RepricedAllowed: "182.88", SavingAmount: "54.62", RepricingOrgID: "123456789"}
+	if diff := cmp.Diff(want, c.Pricing); diff != "" {
+		t.Errorf("claim HCP mismatch (-want +got):\n%s", diff)
+	}
+	for _, l := range c.ServiceLines {
+		if l.Pricing == nil || l.Pricing.RepricedAllowed == "" {
+			t.Errorf("line %s has no repriced amount", l.Number.Number)
+		}
+	}
+	if len(c.OtherSubscribers) != 1 || c.OtherSubscribers[0].Payer.Name.LastName != "OTHER COVERAGE COMPANY" {
+		t.Errorf("OtherSubscribers = %+v", c.OtherSubscribers)
+	}
+}
@@ -0,0 +1,232 @@
+// Package x837p is a typed model of the Health Care Claim: Professional
+// (837) transaction, implementation guide 005010X222A1.
+//
+// FromTransaction arranges a transaction's segments with hipaa.X222A1
+// and maps them to a Transaction: billing providers, their subscribers
+// and patients, the claims of each, and the claims' service lines. Its
+// ToTransaction method writes the segments back in guide order, so a
+// transaction that conforms to the guide's loop structure round-trips
+// unchanged. Segments the model does not break into fields, such as
+// CR1 and MEA, are kept as x12.Segment values.
+package x837p
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// A Transaction is an 837 professional claim transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X222A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	BHT              segments.BHT      `x12:"BHT"`
+	Submitter        segments.Party    `x12:"1000A"`
+	Receiver         segments.Party    `x12:"1000B"`
+	BillingProviders []BillingProvider `x12:"2000A"`
+}
+
+// A BillingProvider is the Billing Provider Hierarchical Level (loop
+// 2000A) and the subscribers billed under it.
+type BillingProvider struct {
+	HL           segments.HL     `x12:"HL"`
+	Specialty    *segments.PRV   `x12:"PRV"`
+	Currency     *x12.Segment    `x12:"CUR"`
+	Name         segments.Party  `x12:"2010AA"`
+	PayToAddress *segments.Party `x12:"2010AB"`
+	PayToPlan    *segments.Party `x12:"2010AC"`
+	Subscribers  []Subscriber    `x12:"2000B"`
+}
+
+// A Subscriber is the Subscriber Hierarchical Level (loop 2000B). Claims
+// holds the subscriber's own claims, when the subscriber is the patient;
+// Patients holds the dependents with claims of their own.
+type Subscriber struct {
+	HL       segments.HL    `x12:"HL"`
+	Info     segments.SBR   `x12:"SBR"`
+	Patient  *segments.PAT  `x12:"PAT"`
+	Name     segments.Party `x12:"2010BA"`
+	Payer    segments.Party `x12:"2010BB"`
+	Claims   []Claim        `x12:"2300"`
+	Patients []Patient      `x12:"2000C"`
+}
+
+// A Patient is the Patient Hierarchical Level (loop 2000C) of a patient
+// other than the subscriber.
+type Patient struct {
+	HL     segments.HL    `x12:"HL"`
+	Info   segments.PAT   `x12:"PAT"`
+	Name   segments.Party `x12:"2010CA"`
+	Claims []Claim        `x12:"2300"`
+}
+
+// A Claim is the Claim Information loop (2300).
+type Claim struct {
+	Claim               segments.CLM      `x12:"CLM"`
+	Dates               []segments.DTP    `x12:"DTP"`
+	Paperwork           []segments.PWK    `x12:"PWK"`
+	Contract            *segments.CN1     `x12:"CN1"`
+	PatientAmountPaid   *segments.AMT     `x12:"AMT"`
+	References          []segments.REF    `x12:"REF"`
+	FileInformation     *segments.K3      `x12:"K3"`
+	Note                *segments.NTE     `x12:"NTE"`
+	Ambulance           *x12.Segment      `x12:"CR1"`
+	SpinalManipulation  *x12.Segment      `x12:"CR2"`
+	Conditions          []segments.CRC    `x12:"CRC"`
+	HealthCareCodes     []segments.HI     `x12:"HI"`
+	Pricing             *segments.HCP     `x12:"HCP"`
+	ReferringProviders  []segments.Party  `x12:"2310A"`
+	RenderingProvider   *segments.Party   `x12:"2310B"`
+	ServiceFacility     *segments.Party   `x12:"2310C"`
+	SupervisingProvider *segments.Party   `x12:"2310D"`
+	PickUpLocation      *segments.Party   `x12:"2310E"`
+	DropOffLocation     *segments.Party   `x12:"2310F"`
+	OtherSubscribers    []OtherSubscriber `x12:"2320"`
+	ServiceLines        []ServiceLine     `x12:"2400"`
+}
+
+// Diagnoses returns the claim's diagnosis codes, from its HI segments
+// with principal (ABK or BK) or other (ABF or BF) diagnosis qualifiers,
+// in order.
+func (c *Claim) Diagnoses() []segments.HealthCareCode {
+	var codes []segments.HealthCareCode
+	for _, hi := range c.HealthCareCodes {
+		for _, code := range hi.Codes {
+			switch code.Qualifier {
+			case "ABK", "BK", "ABF", "BF":
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
+// An OtherSubscriber is the Other Subscriber Information loop (2320):
+// another payer responsible for the claim and how it adjudicated it.
+type OtherSubscriber struct {
+	Info                   segments.SBR     `x12:"SBR"`
+	Adjustments            []segments.CAS   `x12:"CAS"`
+	Amounts                []segments.AMT   `x12:"AMT"`
+	OtherInsurance         x12.Segment      `x12:"OI"`
+	OutpatientAdjudication *x12.Segment     `x12:"MOA"`
+	Subscriber             segments.Party   `x12:"2330A"`
+	Payer                  segments.Party   `x12:"2330B"`
+	ReferringProviders     []segments.Party `x12:"2330C"`
+	RenderingProvider      *segments.Party  `x12:"2330D"`
+	ServiceFacility        *segments.Party  `x12:"2330E"`
+	SupervisingProvider    *segments.Party  `x12:"2330F"`
+	BillingProvider        *segments.Party  `x12:"2330G"`
+}
+
+// A ServiceLine is the Service Line Number loop (2400).
+type ServiceLine struct {
+	Number                   segments.LX      `x12:"LX"`
+	Service                  segments.SV1     `x12:"SV1"`
+	DurableMedicalEquipment  *x12.Segment     `x12:"SV5"`
+	Paperwork                []segments.PWK   `x12:"PWK"`
+	Ambulance                *x12.Segment     `x12:"CR1"`
+	DMECertification         *x12.Segment     `x12:"CR3"`
+	Conditions               []segments.CRC   `x12:"CRC"`
+	Dates                    []segments.DTP   `x12:"DTP"`
+	Quantities               []segments.QTY   `x12:"QTY"`
+	TestResults              []x12.Segment    `x12:"MEA"`
+	Contract                 *segments.CN1    `x12:"CN1"`
+	References               []segments.REF   `x12:"REF"`
+	Amounts                  []segments.AMT   `x12:"AMT"`
+	FileInformation          *segments.K3     `x12:"K3"`
+	Notes                    []segments.NTE   `x12:"NTE"`
+	PurchasedService         *x12.Segment     `x12:"PS1"`
+	Pricing                  *segments.HCP    `x12:"HCP"`
+	Drug                     *Drug            `x12:"2410"`
+	RenderingProvider        *segments.Party  `x12:"2420A"`
+	PurchasedServiceProvider *segments.Party  `x12:"2420B"`
+	ServiceFacility          *segments.Party  `x12:"2420C"`
+	SupervisingProvider      *segments.Party  `x12:"2420D"`
+	OrderingProvider         *segments.Party  `x12:"2420E"`
+	ReferringProviders       []segments.Party `x12:"2420F"`
+	PickUpLocation           *segments.Party  `x12:"2420G"`
+	DropOffLocation          *segments.Party  `x12:"2420H"`
+	Adjudications            []Adjudication   `x12:"2430"`
+	Forms                    []Form           `x12:"2440"`
+}
+
+// ServiceDate returns the line's service date or date range (DTP*472),
+// or "" if it has none.
+func (l *ServiceLine) ServiceDate() string {
+	for _, d := range l.Dates {
+		if d.Qualifier == "472" {
+			return d.Period
+		}
+	}
+	return ""
+}
+
+// A Drug is the Drug Identification loop (2410).
+type Drug struct {
+	Identification segments.LIN  `x12:"LIN"`
+	Quantity       segments.CTP  `x12:"CTP"`
+	Reference      *segments.REF `x12:"REF"`
+}
+
+// An Adjudication is the Line Adjudication Information loop (2430): how
+// another payer adjudicated the line.
+type Adjudication struct {
+	Adjudication       segments.SVD   `x12:"SVD"`
+	Adjustments        []segments.CAS `x12:"CAS"`
+	Date               segments.DTP   `x12:"DTP"`
+	RemainingLiability *segments.AMT  `x12:"AMT"`
+}
+
+// A Form is the Form Identification Code loop (2440).
+type Form struct {
+	Form          x12.Segment   `x12:"LQ"`
+	Documentation []x12.Segment `x12:"FRM"`
+}
+
+// FromTransaction returns the model of tx, an 837 transaction of
+// 005010X222A1. Elements are split into components and repetitions
+// with d. It returns an error if a segment is out of place for the
+// guide's loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x837p", hipaa.X222A1, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as an 837 transaction, with ST and SE segments
+// built from ControlNumber and Version. It first fills in the empty HL
+// values of a copy of t, leaving t unchanged: levels without an HL01 are
+// numbered in order, and HL02, HL03, and HL04 follow from the model's
+// nesting.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	t = model.Clone(t)
+	var levels model.Levels
+	for i := range t.BillingProviders {
+		bp := &t.BillingProviders[i]
+		bpID := levels.Number(&bp.HL, "20", "", len(bp.Subscribers) > 0)
+		for j := range bp.Subscribers {
+			sub := &bp.Subscribers[j]
+			subID := levels.Number(&sub.HL, "22", bpID, len(sub.Patients) > 0)
+			for k := range sub.Patients {
+				levels.Number(&sub.Patients[k].HL, "23", subID, false)
+			}
+		}
+	}
+	return model.Marshal("x837p", hipaa.X222A1, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X222A1: loop
+// structure, segment usage and repeats, and the guide's situational
+// rules.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X222A1, tx)
+}
@@ -0,0 +1,164 @@
+package x837p_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x837p"
+	"github.com/tmc/x12/segments"
+)
+
+func TestRoundTripFixtures(t *testing.T) {
+	hipaatest.RoundTrip(t, hipaatest.Glob(t, filepath.Join("..", "..", "testdata", "005010x222*.edi")), x837p.FromTransaction, nil)
+}
+
+func TestFromTransaction(t *testing.T) {
+	tx, d := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x222-example-3a-claim-billing-provider-payer.edi"))
+	m, err := x837p.FromTransaction(tx, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.BillingProviders) != 1 || len(m.BillingProviders[0].Subscribers) != 1 {
+		t.Fatalf("got %d billing providers, want 1 with 1 subscriber", len(m.BillingProviders))
+	}
+	sub := m.BillingProviders[0].Subscribers[0]
+	var claims []x837p.Claim
+	claims = append(claims, sub.Claims...)
+	for _, p := range sub.Patients {
+		claims = append(claims, p.Claims...)
+	}
+	if len(claims) == 0 {
+		t.Fatal("no claims")
+	}
+	c := claims[0]
+	if c.Claim.ClaimID == "" || c.Claim.Location.FacilityCode == "" {
+		t.Errorf("CLM = %+v, want claim ID and facility code", c.Claim)
+	}
+	if len(c.Diagnoses()) == 0 {
+		t.Error("Diagnoses() is empty")
+	}
+	if len(c.ServiceLines) == 0 || c.ServiceLines[0].Service.Procedure.Qualifier != "HC" || c.ServiceLines[0].ServiceDate() == "" {
+		t.Errorf("service lines = %+v, want HC procedure with a service date", c.ServiceLines)
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	party := func(code, typ, name, idq, id string) segments.Party {
+		return segments.Party{Name: segments.NM1{EntityIdentifierCode: code, EntityTypeQualifier: typ, LastName: name, IDQualifier: idq, ID: id}}
+	}
+	billing := party("85", "2", "BEN KILDARE SERVICE", "XX", "9876543210")
+	billing.Address = &segments.N3{Address1: "234 SEAWAY ST"}
+	billing.City = &segments.N4{City: "MIAMI", State: "FL", PostalCode: "33111"}
+	billing.References = []segments.REF{{Qualifier: "EI", ID: "587654321"}}
+	subscriber := party("IL", "1", "SMITH", "MI", "123456789")
+	subscriber.Name.FirstName = "JANE"
+	subscriber.Address = &segments.N3{Address1: "236 N MAIN ST"}
+	subscriber.City = &segments.N4{City: "MIAMI", State: "FL", PostalCode: "33413"}
+	subscriber.Demographics = &segments.DMG{FormatQualifier: "D8", BirthDate: "19430501", Gender: "F"}
+	submitter := party("41", "2", "PREMIER BILLING SERVICE", "46", "TGJ23")
+	submitter.Contacts = []segments.PER{{FunctionCode: "IC", Name: "JERRY", Communications: []segments.Communication{{Qualifier: "TE", Number: "3055552222"}}}}
+
+	m := &x837p.Transaction{
+		ControlNumber: "0021",
+		BHT:           segments.BHT{StructureCode: "0019", PurposeCode: "00", ReferenceID: "244579", Date: "20061015", Time: "1023", TransactionType: "CH"},
+		Submitter:     submitter,
+		Receiver:      party("40", "2", "KEY INSURANCE COMPANY", "46", "66783JJT"),
+		BillingProviders: []x837p.BillingProvider{{
+			Name: billing,
+			Subscribers: []x837p.Subscriber{{
+				Info:  segments.SBR{PayerResponsibility: "P", RelationshipCode: "18", ClaimFilingIndicator: "CI"},
+				Name:  subscriber,
+				Payer: party("PR", "2", "KEY INSURANCE COMPANY", "PI", "999996666"),
+				Claims: []x837p.Claim{{
+					Claim: segments.CLM{
+						ClaimID:                 "26463774",
+						TotalCharge:             "100",
+						Location:                segments.ServiceLocation{FacilityCode: "11", FacilityCodeQualifier: "B", FrequencyCode: "1"},
+						ProviderSignature:       "Y",
+						AssignmentParticipation: "A",
+						BenefitsAssignment:      "Y",
+						ReleaseOfInformation:    "I",
+					},
+					HealthCareCodes: []segments.HI{{Codes: []segments.HealthCareCode{{Qualifier: "ABK", Code: "J020"}, {Qualifier: "ABF", Code: "Z1159"}}}},
+					ServiceLines: []x837p.ServiceLine{{
+						Number:  segments.LX{Number: "1"},
+						Service: segments.SV1{Procedure: segments.ProcedureIdentifier{Qualifier: "HC", Code: "99213"}, Charge: "100", Unit: "UN", Quantity: "1", DiagnosisPointers: []string{"1", "2"}},
+						Dates:   []segments.DTP{{Qualifier: "472", FormatQualifier: "D8", Period: "20061003"}},
+					}},
+				}},
+			}},
+		}},
+	}
+	tx, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	for _, seg := range tx.Segments {
+		b.WriteString(seg.ID)
+		for _, e := range seg.Elements {
+			b.WriteString("*" + e.Value)
+		}
+		b.WriteString("~\n")
+	}
+	want := `BHT*0019*00*244579*20061015*1023*CH~
+NM1*41*2*PREMIER BILLING SERVICE*****46*TGJ23~
+PER*IC*JERRY*TE*3055552222~
+NM1*40*2*KEY INSURANCE COMPANY*****46*66783JJT~
+HL*1**20*1~
+NM1*85*2*BEN KILDARE SERVICE*****XX*9876543210~
+N3*234 SEAWAY ST~
+N4*MIAMI*FL*33111~
+REF*EI*587654321~
+HL*2*1*22*0~
+SBR*P*18*******CI~
+NM1*IL*1*SMITH*JANE****MI*123456789~
+N3*236 N MAIN ST~
+N4*MIAMI*FL*33413~
+DMG*D8*19430501*F~
+NM1*PR*2*KEY INSURANCE COMPANY*****PI*999996666~
+CLM*26463774*100***11:B:1*Y*A*Y*I~
+HI*ABK:J020*ABF:Z1159~
+LX*1~
+SV1*HC:99213*100*UN*1***1:2~
+DTP*472*D8*20061003~
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	if tx.Header.ImplementationConventionReference != "005010X222A1" || tx.Trailer.SegmentCount != "23" {
+		t.Errorf("ST/SE = %+v %+v", tx.Header, tx.Trailer)
+	}
+	// The HL values are filled in on a copy.
+	if hl := m.BillingProviders[0].Subscribers[0].HL; hl != (segments.HL{}) {
+		t.Errorf("ToTransaction() set the model's subscriber HL to %+v", hl)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+
+	// A missing subscriber name is reported against the guide.
+	m.BillingProviders[0].Subscribers[0].Name = segments.Party{}
+	if errs := m.Validate(segments.DefaultDelimiters); len(errs) == 0 {
+		t.Error("Validate() = no errors for a subscriber without a name")
+	}
+}
+
+func TestFromTransactionErrors(t *testing.T) {
+	tx, d := hipaatest.ReadFile(t, filepath.Join("..", "..", "testdata", "005010x222-example-3a-claim-billing-provider-payer.edi"))
+	// A segment the guide has no place for.
+	tx.Segments = append(tx.Segments[:3:3], append([]x12.Segment{{ID: "ZZZ"}}, tx.Segments[3:]...)...)
+	if _, err := x837p.FromTransaction(tx, d); err == nil {
+		t.Error("FromTransaction() = nil error for an unexpected segment")
+	}
+	for _, tx := range []*x12.Transaction{nil, {}} {
+		if _, err := x837p.FromTransaction(tx, d); !errors.Is(err, x12.ErrInvalidArgument) {
+			t.Errorf("FromTransaction(%v) error = %v, want %v", tx, err, x12.ErrInvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package hipaa
+
+import "github.com/tmc/x12/schema"
+
+// X997 is the Functional Acknowledgment (997) of the X12 005010
+// standard. HIPAA adopts the 999 in its place (see X231A1), and the 997
+// has no implementation guide, but some trading partners still exchange
+// it: it accepts or rejects each functional group and transaction set
+// of an interchange, reporting the segments and elements that fail the
+// X12 syntax. The loop identifiers are those the 999 uses for the same
+// structure.
+var X997 = &schema.TransactionSet{
+	ID:      "997",
+	Version: "005010",
+	Name:    "Functional Acknowledgment",
+	Loop: &schema.Loop{Children: []schema.Node{
+		seg("AK1", "Functional Group Response Header", req, 1),
+		loop("2000", "Transaction Set Response Header", sit, 0,
+			seg("AK2", "Transaction Set Response Header", req, 1),
+			loop("2100", "Data Segment Note", sit, 0,
+				seg("AK3", "Data Segment Note", req, 1),
+				seg("AK4", "Data Element Note", sit, 99),
+			),
+			seg("AK5", "Transaction Set Response Trailer", req, 1, "A", "E", "M", "R", "W", "X"),
+		),
+		seg("AK9", "Functional Group Response Trailer", req, 1, "A", "E", "M", "P", "R", "W", "X"),
+	}},
+}
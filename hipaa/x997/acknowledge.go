@@ -0,0 +1,136 @@
+package x997
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/hipaa/x999"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// Acknowledge returns the 997 interchange acknowledging doc, a received
+// interchange, with findings, typically those of a snip.Validator's
+// Report: one 997 transaction, numbered from "0001", for each of doc's
+// functional groups, as AcknowledgeGroup builds it. The interchange's
+// sender and receiver are doc's receiver and sender; it is dated now and
+// numbered control, 1 to 9 digits, as its ISA13 (padded with zeros) and
+// GS06.
+//
+// Findings about the envelope as a whole, without a group and
+// transaction, are not reported: an interchange whose ISA or IEA is in
+// error is answered with a TA1 (see x12.AcknowledgeInterchange).
+func Acknowledge(doc *x12.Document, findings []snip.Finding, control string, now time.Time) (*x12.Document, error) {
+	if doc == nil || doc.Interchange == nil {
+		return nil, fmt.Errorf("%w: x997: document has no interchange", x12.ErrInvalidArgument)
+	}
+	d := segments.DelimitersOf(doc)
+	var txs []*x12.Transaction
+	for _, g := range doc.Interchange.FunctionGroups {
+		if g == nil || g.Header == nil {
+			continue
+		}
+		t := AcknowledgeGroup(g, findings)
+		t.ControlNumber = fmt.Sprintf("%04d", len(txs)+1)
+		tx, err := t.ToTransaction(d)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return model.Reply("x997", doc, x12.FunctionalIDCode("997"), hipaa.X997.Version, control, now, txs)
+}
+
+// AcknowledgeGroup returns the 997 acknowledging the functional group g
+// with the findings about its transaction sets, those with g's control
+// number (GS06); other findings are ignored.
+//
+// Transaction sets and the group are accepted or rejected, and their
+// segments and elements in error reported, as x999.AcknowledgeGroup
+// does, but without the claim each error falls within, which a 997
+// cannot carry. The 997 has no implementation codes either: an error
+// the 999 reports with one is reported with the X12 code nearest it. A
+// segment the guide does not use is unexpected (AK304 "2"), and one its
+// situational rules require is missing ("3"); an element the guide's
+// rules require is a conditional element missing (AK403 "2"), one it
+// does not use violates an exclusion ("10"), and one that fails its
+// pattern has an invalid character ("6"). A transaction set with
+// findings beyond the X12 syntax has segments in error (AK502 "5"), and
+// one whose implementation guide is unknown is not supported ("1").
+func AcknowledgeGroup(g *x12.FunctionGroup, findings []snip.Finding) *Transaction {
+	ack := x999.AcknowledgeGroup(g, findings)
+	t := &Transaction{Group: ack.Group, Trailer: ack.Trailer}
+	for _, r := range ack.Responses {
+		resp := Response{
+			Header:  r.Header,
+			Trailer: segments.AK5{AcknowledgmentCode: r.Trailer.AcknowledgmentCode, ErrorCodes: codes(r.Trailer.ErrorCodes, transactionCodes)},
+		}
+		for _, e := range r.Errors {
+			se := SegmentError{Error: segments.AK3{
+				SegmentID:       e.Error.SegmentID,
+				SegmentPosition: e.Error.SegmentPosition,
+				LoopID:          e.Error.LoopID,
+				ErrorCode:       code(e.Error.ErrorCode, segmentCodes),
+			}}
+			for _, el := range e.Elements {
+				se.Elements = append(se.Elements, segments.AK4{
+					ElementPosition:  el.Error.ElementPosition,
+					ElementReference: el.Error.ElementReference,
+					ErrorCode:        code(el.Error.ErrorCode, elementCodes),
+					BadData:          el.Error.BadData,
+				})
+			}
+			resp.Errors = append(resp.Errors, se)
+		}
+		t.Responses = append(t.Responses, resp)
+	}
+	return t
+}
+
+// The X12 codes standing for the implementation codes of a 999's IK5,
+// IK3, and IK4.
+var (
+	transactionCodes = map[string]string{
+		"I5": "5", // one or more segments in error
+		"I6": "1", // transaction set not supported
+	}
+	segmentCodes = map[string]string{
+		"I4": "2", // unexpected segment
+		"I6": "3", // mandatory segment missing
+		"I9": "2", // unexpected segment
+	}
+	elementCodes = map[string]string{
+		"I9":  "2",  // conditional required data element missing
+		"I10": "10", // exclusion condition violated
+		"I12": "6",  // invalid character in data element
+		"I13": "10", // exclusion condition violated
+	}
+)
+
+// code returns the X12 code standing for c in m, or c.
+func code(c string, m map[string]string) string {
+	if x, ok := m[c]; ok {
+		return x
+	}
+	return c
+}
+
+// codes returns cs with each code replaced as code does, without
+// repeats.
+func codes(cs []string, m map[string]string) []string {
+	var out []string
+	for _, c := range cs {
+		c = code(c, m)
+		seen := false
+		for _, have := range out {
+			seen = seen || have == c
+		}
+		if !seen {
+			out = append(out, c)
+		}
+	}
+	return out
+}
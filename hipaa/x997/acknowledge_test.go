@@ -0,0 +1,141 @@
+package x997_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/dict"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/x997"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// interchange returns the guide's example claim in an interchange twice:
+// as transaction 0021 after edit, and unchanged as transaction 0022.
+func interchange(t *testing.T, edit func(string) string) *x12.Document {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("..", "..", "testdata", "005010x222-example-3a-claim-billing-provider-payer.edi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim := strings.TrimSpace(string(b))
+	doc, err := x12.Decode(strings.NewReader(
+		"ISA*00*          *00*          *ZZ*SUBMITTERID    *ZZ*RECEIVERID     *051015*1023*^*00501*000000905*1*T*:~" +
+			"GS*HC*SUBMITTER*RECEIVER*20051015*1023*17*X*005010X222A1~" +
+			edit(claim) + strings.ReplaceAll(claim, "0021", "0022") +
+			"GE*2*17~IEA*1*000000905~"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestAcknowledge(t *testing.T) {
+	doc := interchange(t, func(claim string) string {
+		claim = strings.Replace(claim, "PAT*19~", "", 1)              // required segment missing
+		claim = strings.Replace(claim, "LX*1~", "LX*1~ZZZ*1~", 1)     // unrecognized segment
+		claim = strings.Replace(claim, "DTP*472*D8*", "DTP*472**", 1) // required element missing
+		return strings.Replace(claim, "SE*", "SE*X", 1)               // bad segment count
+	})
+	v := &snip.Validator{Schemas: hipaa.Schemas, Segments: x12.SegmentDefs(dict.Default)}
+	ack, err := x997.Acknowledge(doc, v.Validate(doc).Findings, "1", time.Date(2005, 10, 16, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Acknowledge() error: %v", err)
+	}
+	out, err := x12.Marshal(ack, x12.WithNewlines())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ISA*00*          *00*          *ZZ*RECEIVERID     *ZZ*SUBMITTERID    *051016*0800*^*00501*000000001*0*T*:~",
+		"GS*FA*RECEIVER*SUBMITTER*20051016*0800*1*X*005010~",
+		"ST*997*0001~",
+		"AK1*HC*17*005010X222A1~",
+		"AK2*837*0021*005010X222~",
+		"AK3*PAT*23*2000C*3~",
+		"AK3*ZZZ*43*2400*1~",
+		"AK3*DTP*45**8~",
+		"AK4*2*1250*1~",
+		"AK5*R*4*5~",
+		"AK2*837*0022*005010X222~",
+		"AK5*A~",
+		"AK9*P*2*2*1~",
+		"SE*12*0001~",
+		"GE*1*1~",
+		"IEA*1*000000001~",
+	}
+	if diff := cmp.Diff(want, strings.Split(strings.TrimSpace(string(out)), "\n")); diff != "" {
+		t.Errorf("997 mismatch (-want +got):\n%s", diff)
+	}
+	if err := ack.Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+	m, err := x997.FromTransaction(ack.Interchange.FunctionGroups[0].Transactions[0], segments.DelimitersOf(ack))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range m.Validate(segments.DelimitersOf(ack)) {
+		t.Errorf("997 Validate() error: %v", err)
+	}
+}
+
+// Implementation codes a 999 would report are replaced by X12 codes.
+func TestAcknowledgeGroupCodes(t *testing.T) {
+	g := interchange(t, func(claim string) string { return claim }).Interchange.FunctionGroups[0]
+	rule := &schema.Rule{}
+	finding := func(pos int, err *schema.Error) snip.Finding {
+		err.Position = pos
+		return snip.Finding{Level: snip.Requirement, Group: "17", Transaction: "0021", Position: pos, Err: err}
+	}
+	findings := []snip.Finding{
+		finding(10, &schema.Error{SegmentID: "NM1", Rule: rule, Err: schema.ErrNotUsed}),
+		finding(11, &schema.Error{SegmentID: "N3", Element: 2, Rule: rule, Err: x12.ErrMissingElement}),
+		finding(12, &schema.Error{SegmentID: "N4", Element: 7, Err: schema.ErrNotUsed}),
+		{Level: snip.Requirement, Group: "17", Transaction: "0022", Err: snip.ErrNoSchema},
+	}
+	got := x997.AcknowledgeGroup(g, findings)
+	want := []x997.Response{
+		{
+			Header: segments.AK2{TransactionSetID: "837", ControlNumber: "0021", Version: "005010X222"},
+			Errors: []x997.SegmentError{
+				{Error: segments.AK3{SegmentID: "NM1", SegmentPosition: "10", ErrorCode: "2"}},
+				{
+					Error:    segments.AK3{SegmentID: "N3", SegmentPosition: "11", ErrorCode: "8"},
+					Elements: []segments.AK4{{ElementPosition: segments.ElementPosition{Element: "2"}, ElementReference: "166", ErrorCode: "2"}},
+				},
+				{
+					Error:    segments.AK3{SegmentID: "N4", SegmentPosition: "12", ErrorCode: "8"},
+					Elements: []segments.AK4{{ElementPosition: segments.ElementPosition{Element: "7"}, ElementReference: "1715", ErrorCode: "10"}},
+				},
+			},
+			Trailer: segments.AK5{AcknowledgmentCode: "R", ErrorCodes: []string{"5"}},
+		},
+		{
+			Header:  segments.AK2{TransactionSetID: "837", ControlNumber: "0022", Version: "005010X222"},
+			Trailer: segments.AK5{AcknowledgmentCode: "R", ErrorCodes: []string{"1"}},
+		},
+	}
+	if diff := cmp.Diff(want, got.Responses); diff != "" {
+		t.Errorf("responses mismatch (-want +got):\n%s", diff)
+	}
+	if got.Trailer.AcknowledgmentCode != x997.Rejected {
+		t.Errorf("AK901 = %q, want %q", got.Trailer.AcknowledgmentCode, x997.Rejected)
+	}
+}
+
+func TestAcknowledgeControlNumber(t *testing.T) {
+	doc := interchange(t, func(claim string) string { return claim })
+	for _, control := range []string{"", "1234567890", "12A"} {
+		if _, err := x997.Acknowledge(doc, nil, control, time.Now()); !errors.Is(err, x12.ErrInvalidArgument) {
+			t.Errorf("Acknowledge(%q) error = %v, want %v", control, err, x12.ErrInvalidArgument)
+		}
+	}
+}
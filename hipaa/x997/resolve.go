@@ -0,0 +1,28 @@
+package x997
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa/x999"
+	"github.com/tmc/x12/segments"
+)
+
+// Resolve returns the errors t reports against doc, the interchange t
+// acknowledges, each resolved to the segment and element it points at,
+// as x999.Transaction.Resolve resolves those of a 999: by AK102, AK202,
+// AK302, and AK401. A 997 carries no business unit context, so each
+// Location's Claim is read from the segments sent alone.
+func (t *Transaction) Resolve(doc *x12.Document) ([]x999.Location, error) {
+	ack := &x999.Transaction{Group: t.Group, Trailer: t.Trailer}
+	for _, r := range t.Responses {
+		resp := x999.Response{Header: r.Header}
+		for _, e := range r.Errors {
+			se := x999.SegmentError{Error: segments.IK3(e.Error)}
+			for _, el := range e.Elements {
+				se.Elements = append(se.Elements, x999.ElementError{Error: segments.IK4(el)})
+			}
+			resp.Errors = append(resp.Errors, se)
+		}
+		ack.Responses = append(ack.Responses, resp)
+	}
+	return ack.Resolve(doc)
+}
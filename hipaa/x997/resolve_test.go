@@ -0,0 +1,47 @@
+package x997_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/dict"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/x997"
+	"github.com/tmc/x12/hipaa/x999"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// The errors of a 997 generated for a claim resolve to the segments and
+// elements of the claim, with the claim read from the segments sent.
+func TestResolve(t *testing.T) {
+	doc := interchange(t, func(claim string) string {
+		claim = strings.Replace(claim, "LX*1~", "LX*1~ZZZ*1~", 1)
+		return strings.Replace(claim, "DTP*472*D8*", "DTP*472**", 1)
+	})
+	v := &snip.Validator{Schemas: hipaa.Schemas, Segments: x12.SegmentDefs(dict.Default)}
+	out, err := x997.Acknowledge(doc, v.Validate(doc).Findings, "1", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ack, err := x997.FromTransaction(out.Interchange.FunctionGroups[0].Transactions[0], segments.DelimitersOf(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ack.Resolve(doc)
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	tx := doc.Interchange.FunctionGroups[0].Transactions[0]
+	zzz, dtp := &tx.Segments[42], &tx.Segments[44]
+	want := []x999.Location{
+		{Group: "17", Transaction: "0021", SegmentID: "ZZZ", Position: 44, LoopID: "2400", Code: "1", Segment: zzz, Claim: "26407789"},
+		{Group: "17", Transaction: "0021", SegmentID: "DTP", Position: 46, Element: 2, Code: "1", Segment: dtp, Value: &dtp.Elements[1], Claim: "26407789"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Resolve() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,106 @@
+// Package x997 is a typed model of the Functional Acknowledgment (997)
+// transaction of the X12 005010 standard, which some trading partners
+// exchange in place of the 999.
+//
+// A 997 acknowledges one functional group (AK1): each of its
+// transaction sets (AK2) is accepted or rejected (AK5), with the
+// segments in error (AK3) and the elements in error within them (AK4),
+// and the group as a whole is accepted, partially accepted, or rejected
+// (AK9). FromTransaction arranges a transaction's segments with
+// hipaa.X997 and maps them to a Transaction; ToTransaction writes the
+// segments back in order, so a transaction that conforms to the loop
+// structure round-trips unchanged.
+//
+// Acknowledge builds the 997 interchange answering a received one from
+// the findings of a snip.Validator, and AcknowledgeGroup the 997 of one
+// of its functional groups. Transaction.Resolve resolves each error a
+// 997 received reports to the segment, element, and claim of the
+// interchange it acknowledges.
+package x997
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// Acknowledgment codes (AK501 and AK901).
+const (
+	Accepted           = "A"
+	AcceptedWithErrors = "E"
+	PartiallyAccepted  = "P" // AK901 only
+	Rejected           = "R"
+)
+
+// A Transaction is a 997 functional acknowledgment transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03, normally empty
+
+	Group     segments.AK1 `x12:"AK1"`
+	Responses []Response   `x12:"2000"`
+	Trailer   segments.AK9 `x12:"AK9"`
+}
+
+// A Response is the Transaction Set Response Header loop (2000): the
+// acknowledgment of one transaction set.
+type Response struct {
+	Header  segments.AK2   `x12:"AK2"`
+	Errors  []SegmentError `x12:"2100"`
+	Trailer segments.AK5   `x12:"AK5"`
+}
+
+// A SegmentError is the Data Segment Note loop (2100): a segment in
+// error and the elements in error within it.
+type SegmentError struct {
+	Error    segments.AK3   `x12:"AK3"`
+	Elements []segments.AK4 `x12:"AK4"`
+}
+
+// IsAccepted reports whether r's transaction set was accepted, with or
+// without errors.
+func (r *Response) IsAccepted() bool {
+	code := r.Trailer.AcknowledgmentCode
+	return code == Accepted || code == AcceptedWithErrors
+}
+
+// Response returns the acknowledgment of the transaction set with the
+// control number (ST02), or nil.
+func (t *Transaction) Response(transaction string) *Response {
+	for i := range t.Responses {
+		if t.Responses[i].Header.ControlNumber == transaction {
+			return &t.Responses[i]
+		}
+	}
+	return nil
+}
+
+// FromTransaction returns the model of tx, a 997 transaction. Elements
+// are split into components and repetitions with d. It returns an error
+// if a segment is out of place for the loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := new(Transaction)
+	if err := model.Unmarshal("x997", hipaa.X997, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 997 transaction, with ST and SE segments
+// built from ControlNumber and Version.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	// A 997 follows no implementation convention: ST03 is left out
+	// unless t names one.
+	return model.Marshal("x997", hipaa.X997, t.ControlNumber, t.Version, true, t, d)
+}
+
+// Validate reports the ways t fails to conform to hipaa.X997: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X997, tx)
+}
@@ -0,0 +1,66 @@
+package x997_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x997"
+	"github.com/tmc/x12/segments"
+)
+
+// fixture accepts one claim transaction and rejects another for a
+// missing element in a claim's segment.
+var fixture = filepath.Join("..", "testdata", "997-accept-and-reject.edi")
+
+func TestRoundTrip(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, fixture)
+	m, err := x997.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() error: %v", err)
+	}
+	got, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("ToTransaction() error: %v", err)
+	}
+	if diff := cmp.Diff(tx, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestResponses(t *testing.T) {
+	tx, _ := hipaatest.ReadFile(t, fixture)
+	m, err := x997.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (segments.AK1{FunctionalIDCode: "HC", GroupControlNumber: "17456", Version: "005010X222A1"}); m.Group != want {
+		t.Errorf("AK1 = %+v, want %+v", m.Group, want)
+	}
+	if m.Trailer.AcknowledgmentCode != x997.PartiallyAccepted {
+		t.Errorf("AK901 = %q, want %q", m.Trailer.AcknowledgmentCode, x997.PartiallyAccepted)
+	}
+	if r := m.Response("0001"); r == nil || !r.IsAccepted() {
+		t.Errorf("Response(0001) = %+v, want an acceptance", r)
+	}
+	rejected := m.Response("0002")
+	if rejected == nil || rejected.IsAccepted() {
+		t.Fatalf("Response(0002) = %+v, want a rejection", rejected)
+	}
+	want := []x997.SegmentError{{
+		Error: segments.AK3{SegmentID: "CLM", SegmentPosition: "22", ErrorCode: "8"},
+		Elements: []segments.AK4{
+			{ElementPosition: segments.ElementPosition{Element: "5", Component: "3"}, ElementReference: "1331", ErrorCode: "1"},
+		},
+	}}
+	if diff := cmp.Diff(want, rejected.Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	if m.Response("0003") != nil {
+		t.Error("Response(0003) != nil for a transaction set not acknowledged")
+	}
+}
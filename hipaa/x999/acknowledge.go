@@ -0,0 +1,381 @@
+package x999
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/dict"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// Acknowledge returns the 999 interchange acknowledging doc, a received
+// interchange, with findings, typically those of a snip.Validator's
+// Report: one 999 transaction, numbered from "0001", for each of doc's
+// functional groups, as AcknowledgeGroup builds it. The interchange's
+// sender and receiver are doc's receiver and sender; it is dated now and
+// numbered control, 1 to 9 digits, as its ISA13 (padded with zeros) and
+// GS06.
+//
+// Findings about the envelope as a whole, without a group and
+// transaction, are not reported: an interchange whose ISA or IEA is in
+// error is answered with a TA1 (see x12.AcknowledgeInterchange). So is
+// one that Decode could not read: a *x12.ParseError leaves no document
+// to acknowledge, and its segment count runs across the interchange
+// rather than within a transaction set, so x12.NewTA1 reports it.
+func Acknowledge(doc *x12.Document, findings []snip.Finding, control string, now time.Time) (*x12.Document, error) {
+	if doc == nil || doc.Interchange == nil {
+		return nil, fmt.Errorf("%w: x999: document has no interchange", x12.ErrInvalidArgument)
+	}
+	d := segments.DelimitersOf(doc)
+	var txs []*x12.Transaction
+	for _, g := range doc.Interchange.FunctionGroups {
+		if g == nil || g.Header == nil {
+			continue
+		}
+		t := AcknowledgeGroup(g, findings)
+		t.ControlNumber = fmt.Sprintf("%04d", len(txs)+1)
+		tx, err := t.ToTransaction(d)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return model.Reply("x999", doc, x12.FunctionalIDCode("999"), hipaa.X231A1.Version, control, now, txs)
+}
+
+// AcknowledgeGroup returns the 999 acknowledging the functional group g
+// with the findings about its transaction sets, those with g's control
+// number (GS06); other findings are ignored.
+//
+// Each transaction set is rejected if it has findings or if its SE
+// disagrees with its ST, and accepted otherwise. A finding at a segment
+// position is reported as an IK3 and, if it concerns an element, an IK4,
+// with the error codes the guide gives for it: an unexpected segment
+// (IK304 "2"), a missing segment or loop ("3"), a segment or loop over
+// its maximum use ("5", "4"), a segment or element present that the
+// guide does not use ("I4", "I10"), an element that is missing ("1"),
+// has an invalid code ("7"), or is too short or long ("4", "5"), and,
+// for those the guide's situational rules decide, their implementation
+// dependent codes ("I6", "I9", "I13"). Findings within a claim carry its
+// identifier in a business unit context (CTX*CLM01, or CTX*CLP01 for a
+// payment/advice). Findings of situational rules whose condition is not
+// registered say nothing about the transaction set and are ignored.
+//
+// The group is accepted if all of its transaction sets are, partially
+// accepted if some are, and rejected if none are or if its GE disagrees
+// with its GS.
+func AcknowledgeGroup(g *x12.FunctionGroup, findings []snip.Finding) *Transaction {
+	gs := g.Header
+	t := &Transaction{Group: segments.AK1{FunctionalIDCode: gs.FunctionalIDCode, GroupControlNumber: gs.ControlNumber, Version: gs.Version}}
+	accepted := 0
+	for _, tx := range g.Transactions {
+		if tx == nil || tx.Header == nil {
+			continue
+		}
+		var fs []snip.Finding
+		for _, f := range findings {
+			if f.Group == gs.ControlNumber && f.Transaction == tx.Header.ControlNumber {
+				fs = append(fs, f)
+			}
+		}
+		r := respond(g, tx, fs)
+		if r.IsAccepted() {
+			accepted++
+		}
+		t.Responses = append(t.Responses, r)
+	}
+
+	ak9 := &t.Trailer
+	ak9.Included = strconv.Itoa(len(t.Responses))
+	ak9.Received = ak9.Included
+	ak9.Accepted = strconv.Itoa(accepted)
+	var codes codeList
+	if g.Trailer == nil {
+		codes.add("3") // functional group trailer missing
+	} else {
+		if strings.TrimSpace(g.Trailer.ControlNumber) != strings.TrimSpace(gs.ControlNumber) {
+			codes.add("4") // group control numbers do not agree
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(g.Trailer.TransactionSetCount)); err != nil || n != len(g.Transactions) {
+			codes.add("5") // included transaction sets do not match the count
+			if err == nil {
+				ak9.Included = strconv.Itoa(n)
+			}
+		}
+	}
+	ak9.ErrorCodes = codes
+	switch {
+	case len(codes) > 0 || accepted == 0 && len(t.Responses) > 0:
+		ak9.AcknowledgmentCode = Rejected
+	case accepted < len(t.Responses):
+		ak9.AcknowledgmentCode = PartiallyAccepted
+	default:
+		ak9.AcknowledgmentCode = Accepted
+	}
+	return t
+}
+
+// respond returns the acknowledgment of tx, a transaction set of g,
+// with its findings.
+func respond(g *x12.FunctionGroup, tx *x12.Transaction, findings []snip.Finding) Response {
+	r := Response{Header: segments.AK2{
+		TransactionSetID: tx.Header.IDCode,
+		ControlNumber:    tx.Header.ControlNumber,
+		Version:          tx.Header.ImplementationConventionReference,
+	}}
+	var codes codeList
+	if tx.Trailer == nil {
+		codes.add("2") // trailer missing
+	} else {
+		if strings.TrimSpace(tx.Trailer.ControlNumber) != strings.TrimSpace(tx.Header.ControlNumber) {
+			codes.add("3") // control numbers do not match
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(tx.Trailer.SegmentCount)); err != nil || n != len(tx.Segments)+2 {
+			codes.add("4") // segment count does not match
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Position < findings[j].Position })
+	elementErrors := make(map[int]*SegmentError) // by position
+	for _, f := range findings {
+		switch {
+		case errors.Is(f.Err, schema.ErrUnknownCondition):
+			continue
+		case errors.Is(f.Err, snip.ErrNoSchema):
+			codes.add("I6") // implementation convention not supported
+			continue
+		case f.Level == snip.Integrity:
+			codes.add("5") // one or more segments in error
+		default:
+			codes.add("I5") // implementation: one or more segments in error
+		}
+		if f.Position == 0 {
+			continue
+		}
+		p := diagnose(f.Err)
+		if p.segmentID == "" && p.loopID != "" {
+			p.segmentID = trigger(g, tx, p.loopID)
+		}
+		if p.segmentID == "" {
+			p.segmentID = segmentAt(tx, f.Position).ID
+		}
+		if p.element == 0 {
+			r.Errors = append(r.Errors, segmentError(tx, f, p, p.code))
+			continue
+		}
+		se := elementErrors[f.Position]
+		if se == nil {
+			r.Errors = append(r.Errors, segmentError(tx, f, p, "8")) // segment has data element errors
+			se = &r.Errors[len(r.Errors)-1]
+			elementErrors[f.Position] = se
+		}
+		if len(se.Elements) < 99 {
+			se.Elements = append(se.Elements, ElementError{Error: elementNote(tx, f.Position, p)})
+		}
+	}
+
+	r.Trailer.AcknowledgmentCode = Accepted
+	if len(codes) > 0 {
+		r.Trailer.AcknowledgmentCode = Rejected
+		r.Trailer.ErrorCodes = codes
+	}
+	return r
+}
+
+// A problem is a finding's error as a 999 reports it.
+type problem struct {
+	segmentID string
+	loopID    string
+	element   int    // 1-based position, or 0 for an error in the segment
+	code      string // IK304 for an error in the segment, IK403 otherwise
+}
+
+// diagnose returns the problem err describes.
+func diagnose(err error) problem {
+	var (
+		serr *schema.Error
+		eerr *x12.ElementError
+		xerr *x12.SyntaxError
+	)
+	switch {
+	case errors.As(err, &serr):
+		p := problem{segmentID: serr.SegmentID, loopID: serr.LoopID, element: serr.Element}
+		if p.element > 0 {
+			p.code = elementCode(err, serr.Rule != nil)
+		} else {
+			p.code = segmentCode(err, serr)
+		}
+		return p
+	case errors.As(err, &eerr):
+		return problem{segmentID: eerr.SegmentID, element: eerr.Element, code: elementCode(err, false)}
+	case errors.As(err, &xerr):
+		code := "2" // conditional required data element missing
+		if errors.Is(err, x12.ErrInvalidFormat) {
+			code = "10" // exclusion condition violated
+		}
+		return problem{segmentID: xerr.SegmentID, element: xerr.Element, code: code}
+	}
+	return problem{code: "8"}
+}
+
+// segmentCode returns the IK304 code of e, an error in a segment or
+// loop. The dictionary describes every segment the guides use, so an
+// unexpected segment it lacks is unrecognized.
+func segmentCode(err error, e *schema.Error) string {
+	switch {
+	case errors.Is(err, schema.ErrUnexpectedSegment):
+		if dict.Default.Segment(e.SegmentID) == nil {
+			return "1" // unrecognized segment ID
+		}
+		return "2" // unexpected segment
+	case errors.Is(err, schema.ErrMissingSegment):
+		if e.Rule != nil {
+			return "I6" // implementation dependent segment missing
+		}
+		return "3" // required segment missing
+	case errors.Is(err, schema.ErrTooManyRepeats):
+		if e.SegmentID == "" {
+			return "4" // loop occurs over maximum times
+		}
+		return "5" // segment exceeds maximum use
+	case errors.Is(err, schema.ErrNotUsed):
+		if e.Rule != nil {
+			return "I9" // implementation dependent "not used" segment present
+		}
+		return "I4" // implementation "not used" segment present
+	}
+	return "8"
+}
+
+// elementCode returns the IK403 code of err, an error in an element;
+// dependent reports whether a situational rule decided it.
+func elementCode(err error, dependent bool) string {
+	switch {
+	case errors.Is(err, x12.ErrMissingElement):
+		if dependent {
+			return "I9" // implementation dependent data element missing
+		}
+		return "1" // required data element missing
+	case errors.Is(err, schema.ErrNotUsed):
+		if dependent {
+			return "I13" // implementation dependent "not used" data element present
+		}
+		return "I10" // implementation "not used" data element present
+	case errors.Is(err, schema.ErrInvalidCode):
+		return "7" // invalid code value
+	case errors.Is(err, schema.ErrInvalidLength):
+		// CheckUsage names the bound a value falls outside of.
+		if strings.Contains(err.Error(), "minimum") {
+			return "4" // data element too short
+		}
+		return "5" // data element too long
+	case errors.Is(err, x12.ErrInvalidFormat):
+		return "6" // invalid character in data element
+	}
+	return "I12" // implementation pattern match failure
+}
+
+// segmentError returns the Error Identification loop reporting p, found
+// at f's position, with the code given.
+func segmentError(tx *x12.Transaction, f snip.Finding, p problem, code string) SegmentError {
+	se := SegmentError{Error: segments.IK3{
+		SegmentID:       p.segmentID,
+		SegmentPosition: strconv.Itoa(f.Position),
+		LoopID:          p.loopID,
+		ErrorCode:       code,
+	}}
+	if f.Claim != "" {
+		name := "CLM01"
+		if tx.Header.IDCode == "835" {
+			name = "CLP01"
+		}
+		se.BusinessUnit = &segments.CTX{Context: segments.ContextIdentification{Name: name, Reference: f.Claim}}
+	}
+	return se
+}
+
+// elementNote returns the IK4 reporting p, an error in an element of
+// the segment at position pos, with the element's reference number and,
+// unless it is missing, a copy of its value.
+func elementNote(tx *x12.Transaction, pos int, p problem) segments.IK4 {
+	ik4 := segments.IK4{ElementPosition: segments.ElementPosition{Element: strconv.Itoa(p.element)}, ErrorCode: p.code}
+	if sg := dict.Default.Segment(p.segmentID); sg != nil && p.element <= len(sg.Elements) {
+		ik4.ElementReference = sg.Elements[p.element-1].Ref
+	}
+	switch p.code {
+	case "1", "2", "I9":
+	default:
+		if seg := segmentAt(tx, pos); seg.ID == p.segmentID && p.element <= len(seg.Elements) {
+			bad := seg.Elements[p.element-1].Value
+			if len(bad) > 99 {
+				bad = bad[:99]
+			}
+			ik4.BadData = bad
+		}
+	}
+	return ik4
+}
+
+// segmentAt returns the segment of tx at the 1-based position pos,
+// counting ST as 1, or the zero Segment.
+func segmentAt(tx *x12.Transaction, pos int) x12.Segment {
+	switch {
+	case pos == 1:
+		return x12.Segment{ID: "ST"}
+	case pos >= 2 && pos <= len(tx.Segments)+1:
+		return tx.Segments[pos-2]
+	case pos == len(tx.Segments)+2 && tx.Trailer != nil:
+		return x12.Segment{ID: "SE"}
+	}
+	return x12.Segment{}
+}
+
+// trigger returns the ID of the segment that begins the loop id in the
+// guide tx, a transaction set of g, follows, as snip.SchemaFor selects
+// it, or "" if the package has no such guide or loop.
+func trigger(g *x12.FunctionGroup, tx *x12.Transaction, id string) string {
+	ts := snip.SchemaFor(hipaa.Schemas, g, tx)
+	if ts == nil {
+		return ""
+	}
+	var found string
+	var walk func(l *schema.Loop)
+	walk = func(l *schema.Loop) {
+		for _, c := range l.Children {
+			if sub, ok := c.(*schema.Loop); ok {
+				if sub.ID == id && found == "" {
+					if t := sub.Trigger(); t != nil {
+						found = t.ID
+					}
+				}
+				walk(sub)
+			}
+		}
+	}
+	walk(ts.Loop)
+	return found
+}
+
+// A codeList holds up to the five error codes an IK5 or AK9 reports,
+// without repeats.
+type codeList []string
+
+func (c *codeList) add(code string) {
+	for _, have := range *c {
+		if have == code {
+			return
+		}
+	}
+	if len(*c) < 5 {
+		*c = append(*c, code)
+	}
+}
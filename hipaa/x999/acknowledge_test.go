@@ -0,0 +1,194 @@
+package x999_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/dict"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/x999"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// interchange returns the guide's example claim in an interchange twice:
+// as transaction 0021 after edit, and unchanged as transaction 0022.
+func interchange(t *testing.T, edit func(string) string) *x12.Document {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("..", "..", "testdata", "005010x222-example-3a-claim-billing-provider-payer.edi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim := strings.TrimSpace(string(b))
+	doc, err := x12.Decode(strings.NewReader(
+		"ISA*00*          *00*          *ZZ*SUBMITTERID    *ZZ*RECEIVERID     *051015*1023*^*00501*000000905*1*T*:~" +
+			"GS*HC*SUBMITTER*RECEIVER*20051015*1023*17*X*005010X222A1~" +
+			edit(claim) + strings.ReplaceAll(claim, "0021", "0022") +
+			"GE*2*17~IEA*1*000000905~"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestAcknowledge(t *testing.T) {
+	doc := interchange(t, func(claim string) string {
+		claim = strings.Replace(claim, "PAT*19~", "", 1)              // required segment missing
+		claim = strings.Replace(claim, "LX*1~", "LX*1~ZZZ*1~", 1)     // unrecognized segment
+		claim = strings.Replace(claim, "DTP*472*D8*", "DTP*472**", 1) // required element missing
+		return strings.Replace(claim, "SE*", "SE*X", 1)               // bad segment count
+	})
+	v := &snip.Validator{Schemas: hipaa.Schemas, Segments: x12.SegmentDefs(dict.Default)}
+	ack, err := x999.Acknowledge(doc, v.Validate(doc).Findings, "1", time.Date(2005, 10, 16, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Acknowledge() error: %v", err)
+	}
+	out, err := x12.Marshal(ack, x12.WithNewlines())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ISA*00*          *00*          *ZZ*RECEIVERID     *ZZ*SUBMITTERID    *051016*0800*^*00501*000000001*0*T*:~",
+		"GS*FA*RECEIVER*SUBMITTER*20051016*0800*1*X*005010X231A1~",
+		"ST*999*0001*005010X231A1~",
+		"AK1*HC*17*005010X222A1~",
+		"AK2*837*0021*005010X222~",
+		"IK3*PAT*23*2000C*3~",
+		"IK3*ZZZ*43*2400*1~",
+		"CTX*CLM01:26407789~",
+		"IK3*DTP*45**8~",
+		"CTX*CLM01:26407789~",
+		"IK4*2*1250*1~",
+		"IK5*R*4*I5*5~",
+		"AK2*837*0022*005010X222~",
+		"IK5*A~",
+		"AK9*P*2*2*1~",
+		"SE*14*0001~",
+		"GE*1*1~",
+		"IEA*1*000000001~",
+	}
+	if diff := cmp.Diff(want, strings.Split(strings.TrimSpace(string(out)), "\n")); diff != "" {
+		t.Errorf("999 mismatch (-want +got):\n%s", diff)
+	}
+	if err := ack.Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+	m, err := x999.FromTransaction(ack.Interchange.FunctionGroups[0].Transactions[0], segments.DelimitersOf(ack))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range m.Validate(segments.DelimitersOf(ack)) {
+		t.Errorf("999 Validate() error: %v", err)
+	}
+}
+
+// A segment the dictionary describes but the guide has no place for is
+// unexpected (IK304 "2"), not unrecognized.
+func TestAcknowledgeMisplacedSegment(t *testing.T) {
+	for _, seg := range []string{"UM*HS*I~", "ADX*10*52~", "BIN*1*X~"} {
+		doc := interchange(t, func(claim string) string {
+			return strings.Replace(claim, "LX*1~", "LX*1~"+seg, 1)
+		})
+		v := &snip.Validator{Schemas: hipaa.Schemas}
+		ack, err := x999.Acknowledge(doc, v.Validate(doc).Findings, "1", time.Date(2005, 10, 16, 8, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("Acknowledge() error: %v", err)
+		}
+		m, err := x999.FromTransaction(ack.Interchange.FunctionGroups[0].Transactions[0], segments.DelimitersOf(ack))
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := seg[:strings.IndexByte(seg, '*')]
+		errs := m.Responses[0].Errors
+		if len(errs) != 1 || errs[0].Error.SegmentID != id || errs[0].Error.ErrorCode != "2" {
+			t.Errorf("%s: IK3s = %+v, want one for %s with code 2", id, errs, id)
+		}
+	}
+}
+
+func TestAcknowledgeGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(*x12.FunctionGroup)
+		findings []snip.Finding
+		want     segments.AK9
+	}{
+		{
+			name: "accepted",
+			edit: func(*x12.FunctionGroup) {},
+			want: segments.AK9{AcknowledgmentCode: "A", Included: "2", Received: "2", Accepted: "2"},
+		},
+		{
+			name:     "rejected",
+			edit:     func(*x12.FunctionGroup) {},
+			findings: []snip.Finding{{Level: snip.Balancing, Group: "17", Transaction: "0021"}, {Level: snip.Balancing, Group: "17", Transaction: "0022"}},
+			want:     segments.AK9{AcknowledgmentCode: "R", Included: "2", Received: "2", Accepted: "0"},
+		},
+		{
+			name:     "other group's findings",
+			edit:     func(*x12.FunctionGroup) {},
+			findings: []snip.Finding{{Level: snip.Balancing, Group: "18", Transaction: "0021"}},
+			want:     segments.AK9{AcknowledgmentCode: "A", Included: "2", Received: "2", Accepted: "2"},
+		},
+		{
+			name: "trailer disagrees",
+			edit: func(g *x12.FunctionGroup) { g.Trailer.TransactionSetCount, g.Trailer.ControlNumber = "3", "18" },
+			want: segments.AK9{AcknowledgmentCode: "R", Included: "3", Received: "2", Accepted: "2", ErrorCodes: []string{"4", "5"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := interchange(t, func(claim string) string { return claim }).Interchange.FunctionGroups[0]
+			tt.edit(g)
+			got := x999.AcknowledgeGroup(g, tt.findings)
+			if diff := cmp.Diff(tt.want, got.Trailer); diff != "" {
+				t.Errorf("AK9 mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAcknowledgeControlNumber(t *testing.T) {
+	doc := interchange(t, func(claim string) string { return claim })
+	for _, control := range []string{"", "1234567890", "12A"} {
+		if _, err := x999.Acknowledge(doc, nil, control, time.Now()); !errors.Is(err, x12.ErrInvalidArgument) {
+			t.Errorf("Acknowledge(%q) error = %v, want %v", control, err, x12.ErrInvalidArgument)
+		}
+	}
+}
+
+// A missing loop is reported by its trigger segment in the guide the
+// transaction set follows, found from GS08 when ST03 is empty, and by
+// the segment at its position when there is no such guide.
+func TestAcknowledgeMissingLoopVersion(t *testing.T) {
+	tests := []struct {
+		gs08 string
+		want string
+	}{
+		{"005010X222A1", "CLM"},
+		{"005010X098A1", "HI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gs08, func(t *testing.T) {
+			doc := interchange(t, func(claim string) string { return strings.Replace(claim, "*0021*005010X222~", "*0021~", 1) })
+			g := doc.Interchange.FunctionGroups[0]
+			g.Header.Version = tt.gs08
+			findings := []snip.Finding{{
+				Level: snip.Requirement, Group: "17", Transaction: "0021", Position: 30,
+				Err: &schema.Error{Position: 30, LoopID: "2300", Err: schema.ErrMissingSegment},
+			}}
+			got := x999.AcknowledgeGroup(g, findings).Response("0021").Errors
+			want := []x999.SegmentError{{Error: segments.IK3{SegmentID: tt.want, SegmentPosition: "30", LoopID: "2300", ErrorCode: "3"}}}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package x999
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// A Location is an error an acknowledgment reports, resolved to the
+// segment and element of the acknowledged interchange it points at.
+type Location struct {
+	Group       string // AK102: the functional group's control number (GS06)
+	Transaction string // AK202: the transaction set's control number (ST02)
+
+	SegmentID  string // IK301
+	Position   int    // IK302: the segment's position, counting ST as 1
+	LoopID     string // IK303
+	Element    int    // IK401-1, or 0 for an error in the segment
+	Component  int    // IK401-2, or 0
+	Repetition int    // IK401-3, or 0
+	Code       string // IK403 for an error in an element, IK304 otherwise
+	BadData    string // IK404
+
+	// Segment is the segment sent at Position, and Value the element
+	// sent at Element within it; each is nil if nothing was sent there,
+	// as for a missing segment or element, or if the segment sent there
+	// is not a SegmentID.
+	Segment *x12.Segment
+	Value   *x12.Element
+
+	// Claim is the identifier of the claim the segment falls within,
+	// CLM01 or CLP01, or "". It is read from the segments sent, or,
+	// failing that, from the error's business unit context.
+	Claim string
+}
+
+// Data returns the data sent at l: the repetition and component of
+// l.Value it points at, split with d, or the whole value, its components
+// joined as the encoder joins them, if l names neither. It returns "" if
+// l.Value is nil or has no such repetition or component.
+func (l *Location) Data(d segments.Delimiters) string {
+	if l.Value == nil {
+		return ""
+	}
+	v := l.Value.Value
+	if l.Value.Components != nil {
+		v = strings.Join(append([]string{v}, l.Value.Components...), d.Component)
+	}
+	if l.Repetition > 0 && d.Repetition != "" {
+		v = part(v, d.Repetition, l.Repetition)
+	}
+	if l.Component > 0 {
+		v = part(v, d.Component, l.Component)
+	}
+	return v
+}
+
+// part returns the 1-based nth part of s split by sep, or "".
+func part(s, sep string, n int) string {
+	parts := strings.Split(s, sep)
+	if n > len(parts) {
+		return ""
+	}
+	return parts[n-1]
+}
+
+// Resolve returns the errors t reports against doc, the interchange t
+// acknowledges, each resolved to the segment and element it points at:
+// the functional group is found by its control number (AK102), the
+// transaction set by its control number (AK202), the segment by its
+// position (IK302), and the element by its position (IK401). A segment
+// error with element errors yields a Location for each element error,
+// and one without a Location for the segment. Locations are in t's
+// order. Resolve returns an error wrapping x12.ErrInvalidArgument if doc
+// has no functional group or transaction set t acknowledges.
+func (t *Transaction) Resolve(doc *x12.Document) ([]Location, error) {
+	if doc == nil || doc.Interchange == nil {
+		return nil, fmt.Errorf("%w: x999: document has no interchange", x12.ErrInvalidArgument)
+	}
+	var g *x12.FunctionGroup
+	for _, fg := range doc.Interchange.FunctionGroups {
+		if fg != nil && fg.Header != nil && strings.TrimSpace(fg.Header.ControlNumber) == strings.TrimSpace(t.Group.GroupControlNumber) {
+			g = fg
+			break
+		}
+	}
+	if g == nil {
+		return nil, fmt.Errorf("%w: x999: no functional group %q", x12.ErrInvalidArgument, t.Group.GroupControlNumber)
+	}
+	var locs []Location
+	for _, r := range t.Responses {
+		var tx *x12.Transaction
+		for _, st := range g.Transactions {
+			if st != nil && st.Header != nil && strings.TrimSpace(st.Header.ControlNumber) == strings.TrimSpace(r.Header.ControlNumber) {
+				tx = st
+				break
+			}
+		}
+		if tx == nil {
+			return nil, fmt.Errorf("%w: x999: no transaction set %q in functional group %q", x12.ErrInvalidArgument, r.Header.ControlNumber, t.Group.GroupControlNumber)
+		}
+		if len(r.Errors) == 0 {
+			continue
+		}
+		var claims map[int]string
+		if ts := snip.SchemaFor(hipaa.Schemas, g, tx); ts != nil {
+			root, _ := ts.Parse(tx)
+			claims = snip.ClaimsByPosition(root)
+		}
+		for _, e := range r.Errors {
+			l := Location{
+				Group:       g.Header.ControlNumber,
+				Transaction: tx.Header.ControlNumber,
+				SegmentID:   e.Error.SegmentID,
+				LoopID:      e.Error.LoopID,
+				Code:        e.Error.ErrorCode,
+			}
+			l.Position, _ = strconv.Atoi(strings.TrimSpace(e.Error.SegmentPosition))
+			if seg := segmentPtr(tx, l.Position); seg != nil && seg.ID == l.SegmentID {
+				l.Segment = seg
+			}
+			l.Claim = claims[l.Position]
+			if l.Claim == "" {
+				l.Claim = e.Claim()
+			}
+			if len(e.Elements) == 0 {
+				locs = append(locs, l)
+				continue
+			}
+			for _, el := range e.Elements {
+				el := el.Error
+				l := l
+				l.Element, _ = strconv.Atoi(strings.TrimSpace(el.ElementPosition.Element))
+				l.Component, _ = strconv.Atoi(strings.TrimSpace(el.ElementPosition.Component))
+				l.Repetition, _ = strconv.Atoi(strings.TrimSpace(el.ElementPosition.Repetition))
+				l.Code, l.BadData = el.ErrorCode, el.BadData
+				if l.Segment != nil && l.Element >= 1 && l.Element <= len(l.Segment.Elements) {
+					l.Value = &l.Segment.Elements[l.Element-1]
+				}
+				locs = append(locs, l)
+			}
+		}
+	}
+	return locs, nil
+}
+
+// segmentPtr returns the segment of tx at the 1-based position pos,
+// counting ST as 1, or nil if it is not one of tx's Segments.
+func segmentPtr(tx *x12.Transaction, pos int) *x12.Segment {
+	if pos >= 2 && pos <= len(tx.Segments)+1 {
+		return &tx.Segments[pos-2]
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package x999_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/dict"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x999"
+	"github.com/tmc/x12/segments"
+	"github.com/tmc/x12/snip"
+)
+
+// The errors of a 999 generated for a claim resolve to the segments and
+// elements of the claim.
+func TestResolve(t *testing.T) {
+	doc := interchange(t, func(claim string) string {
+		claim = strings.Replace(claim, "PAT*19~", "", 1)
+		claim = strings.Replace(claim, "LX*1~", "LX*1~ZZZ*1~", 1)
+		return strings.Replace(claim, "DTP*472*D8*", "DTP*472**", 1)
+	})
+	v := &snip.Validator{Schemas: hipaa.Schemas, Segments: x12.SegmentDefs(dict.Default)}
+	out, err := x999.Acknowledge(doc, v.Validate(doc).Findings, "1", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ack, err := x999.FromTransaction(out.Interchange.FunctionGroups[0].Transactions[0], segments.DelimitersOf(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ack.Resolve(doc)
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	tx := doc.Interchange.FunctionGroups[0].Transactions[0]
+	zzz, dtp := &tx.Segments[41], &tx.Segments[43]
+	want := []x999.Location{
+		{Group: "17", Transaction: "0021", SegmentID: "PAT", Position: 23, LoopID: "2000C", Code: "3"},
+		{Group: "17", Transaction: "0021", SegmentID: "ZZZ", Position: 43, LoopID: "2400", Code: "1", Segment: zzz, Claim: "26407789"},
+		{Group: "17", Transaction: "0021", SegmentID: "DTP", Position: 45, Element: 2, Code: "1", Segment: dtp, Value: &dtp.Elements[1], Claim: "26407789"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Resolve() mismatch (-want +got):\n%s", diff)
+	}
+	if len(got) == 3 && (got[1].Segment != zzz || got[2].Value != &dtp.Elements[1]) {
+		t.Error("Resolve() returned copies, want the segment and element sent")
+	}
+}
+
+func TestResolveComponent(t *testing.T) {
+	doc := interchange(t, func(claim string) string { return claim })
+	ack, err := x999.FromTransaction(hipaatest.Decode(t, `ST*999*0001*005010X231A1~
+AK1*HC*17*005010X222A1~
+AK2*837*0022*005010X222A1~
+IK3*SV1*44**8~
+IK4*1:2*234*7*99213~
+IK5*R*I5~
+AK9*R*2*2*1~
+SE*8*0001~`), segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ack.Resolve(doc)
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	want := []x999.Location{{Group: "17", Transaction: "0022", SegmentID: "SV1", Position: 44, Element: 1, Component: 2, Code: "7", BadData: "99213", Claim: "26407789"}}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(x999.Location{}, "Segment", "Value")); diff != "" {
+		t.Errorf("Resolve() mismatch (-want +got):\n%s", diff)
+	}
+	if len(got) == 1 {
+		if data := got[0].Data(segments.DelimitersOf(doc)); data != "99213" {
+			t.Errorf("Data() = %q, want %q", data, "99213")
+		}
+	}
+
+	ack.Group.GroupControlNumber = "18"
+	if _, err := ack.Resolve(doc); !errors.Is(err, x12.ErrInvalidArgument) {
+		t.Errorf("Resolve() for another group error = %v, want %v", err, x12.ErrInvalidArgument)
+	}
+	ack.Group.GroupControlNumber, ack.Responses[0].Header.ControlNumber = "17", "0023"
+	if _, err := ack.Resolve(doc); !errors.Is(err, x12.ErrInvalidArgument) {
+		t.Errorf("Resolve() for another transaction set error = %v, want %v", err, x12.ErrInvalidArgument)
+	}
+}
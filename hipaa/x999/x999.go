@@ -0,0 +1,125 @@
+// Package x999 is a typed model of the Implementation Acknowledgment
+// for Health Care Insurance (999) transaction, implementation guide
+// 005010X231A1.
+//
+// A 999 acknowledges one functional group (AK1): each of its
+// transaction sets (AK2) is accepted or rejected (IK5), with the
+// segments in error (IK3), the claim or other business unit each falls
+// within (CTX), and the elements in error within them (IK4), and the
+// group as a whole is accepted, partially accepted, or rejected (AK9).
+// FromTransaction arranges a transaction's segments with hipaa.X231A1
+// and maps them to a Transaction; ToTransaction writes the segments back
+// in guide order, so a transaction that conforms to the guide's loop
+// structure round-trips unchanged.
+//
+// Acknowledge builds the 999 interchange answering a received one from
+// the findings of a snip.Validator, and AcknowledgeGroup the 999 of one
+// of its functional groups. Transaction.Resolve works the other way,
+// resolving each error a 999 received reports to the segment, element,
+// and claim of the interchange it acknowledges.
+package x999
+
+import (
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/hipaa/internal/model"
+	"github.com/tmc/x12/segments"
+)
+
+// Acknowledgment codes (IK501 and AK901).
+const (
+	Accepted           = "A"
+	AcceptedWithErrors = "E"
+	PartiallyAccepted  = "P" // AK901 only
+	Rejected           = "R"
+)
+
+// A Transaction is a 999 implementation acknowledgment transaction.
+type Transaction struct {
+	ControlNumber string // ST02
+	Version       string // ST03; empty writes "005010X231A1" unless read without one
+	read          bool   // made by FromTransaction
+
+	Group     segments.AK1 `x12:"AK1"`
+	Responses []Response   `x12:"2000"`
+	Trailer   segments.AK9 `x12:"AK9"`
+}
+
+// A Response is the Transaction Set Response Header loop (2000): the
+// acknowledgment of one transaction set.
+type Response struct {
+	Header  segments.AK2   `x12:"AK2"`
+	Errors  []SegmentError `x12:"2100"`
+	Trailer segments.IK5   `x12:"IK5"`
+}
+
+// A SegmentError is the Error Identification loop (2100): a segment in
+// error.
+type SegmentError struct {
+	Error        segments.IK3   `x12:"IK3"`
+	Contexts     []segments.CTX `x12:"CTX,SITUATIONAL TRIGGER"`
+	BusinessUnit *segments.CTX  `x12:"CTX"`
+	Elements     []ElementError `x12:"2110"`
+}
+
+// An ElementError is the Implementation Data Element Note loop (2110):
+// an element in error.
+type ElementError struct {
+	Error    segments.IK4   `x12:"IK4"`
+	Contexts []segments.CTX `x12:"CTX"`
+}
+
+// IsAccepted reports whether r's transaction set was accepted, with or
+// without errors.
+func (r *Response) IsAccepted() bool {
+	code := r.Trailer.AcknowledgmentCode
+	return code == Accepted || code == AcceptedWithErrors
+}
+
+// Response returns the acknowledgment of the transaction set with the
+// control number (ST02), or nil.
+func (t *Transaction) Response(transaction string) *Response {
+	for i := range t.Responses {
+		if t.Responses[i].Header.ControlNumber == transaction {
+			return &t.Responses[i]
+		}
+	}
+	return nil
+}
+
+// Claim returns the identifier of the claim e falls within, the
+// reference of its business unit context (CTX01-2), or "".
+func (e *SegmentError) Claim() string {
+	if e.BusinessUnit == nil {
+		return ""
+	}
+	return e.BusinessUnit.Context.Reference
+}
+
+// FromTransaction returns the model of tx, a 999 transaction of
+// 005010X231A1. Elements are split into components and repetitions with
+// d. It returns an error if a segment is out of place for the guide's
+// loop structure.
+func FromTransaction(tx *x12.Transaction, d segments.Delimiters) (*Transaction, error) {
+	t := &Transaction{read: true}
+	if err := model.Unmarshal("x999", hipaa.X231A1, tx, t, d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToTransaction returns t as a 999 transaction, with ST and SE segments
+// built from ControlNumber and Version.
+func (t *Transaction) ToTransaction(d segments.Delimiters) (*x12.Transaction, error) {
+	return model.Marshal("x999", hipaa.X231A1, t.ControlNumber, t.Version, t.read, t, d)
+}
+
+// Validate reports the ways t fails to conform to 005010X231A1: loop
+// structure, segment usage and repeats.
+func (t *Transaction) Validate(d segments.Delimiters) []error {
+	tx, err := t.ToTransaction(d)
+	if err != nil {
+		return []error{err}
+	}
+	return model.Validate(hipaa.X231A1, tx)
+}
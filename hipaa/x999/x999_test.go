@@ -0,0 +1,77 @@
+package x999_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12/hipaa/internal/hipaatest"
+	"github.com/tmc/x12/hipaa/x999"
+	"github.com/tmc/x12/segments"
+)
+
+// ack accepts one claim transaction and rejects another for a missing
+// element in a claim's segment.
+const ack = `ST*999*0001*005010X231A1~
+AK1*HC*17456*005010X222A1~
+AK2*837*0001*005010X222A1~
+IK5*A~
+AK2*837*0002*005010X222A1~
+IK3*CLM*22**8~
+CTX*SITUATIONAL TRIGGER*CLM*22**5:3~
+CTX*CLM01:123456789~
+IK4*5:3*1331*1~
+IK5*R*I5~
+AK9*P*2*2*1~
+SE*12*0001~`
+
+func TestRoundTrip(t *testing.T) {
+	tx := hipaatest.Decode(t, ack)
+	m, err := x999.FromTransaction(tx, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("FromTransaction() error: %v", err)
+	}
+	got, err := m.ToTransaction(segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatalf("ToTransaction() error: %v", err)
+	}
+	if diff := cmp.Diff(tx, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	for _, err := range m.Validate(segments.DefaultDelimiters) {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestResponses(t *testing.T) {
+	m, err := x999.FromTransaction(hipaatest.Decode(t, ack), segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Trailer.AcknowledgmentCode != x999.PartiallyAccepted {
+		t.Errorf("AK901 = %q, want %q", m.Trailer.AcknowledgmentCode, x999.PartiallyAccepted)
+	}
+	if r := m.Response("0001"); r == nil || !r.IsAccepted() {
+		t.Errorf("Response(0001) = %+v, want an acceptance", r)
+	}
+	rejected := m.Response("0002")
+	if rejected == nil || rejected.IsAccepted() {
+		t.Fatalf("Response(0002) = %+v, want a rejection", rejected)
+	}
+	want := []x999.SegmentError{{
+		Error:        segments.IK3{SegmentID: "CLM", SegmentPosition: "22", ErrorCode: "8"},
+		Contexts:     []segments.CTX{{Context: segments.ContextIdentification{Name: "SITUATIONAL TRIGGER"}, SegmentID: "CLM", SegmentPosition: "22", ElementPosition: segments.ElementPosition{Element: "5", Component: "3"}}},
+		BusinessUnit: &segments.CTX{Context: segments.ContextIdentification{Name: "CLM01", Reference: "123456789"}},
+		Elements: []x999.ElementError{{
+			Error: segments.IK4{ElementPosition: segments.ElementPosition{Element: "5", Component: "3"}, ElementReference: "1331", ErrorCode: "1"},
+		}},
+	}}
+	if diff := cmp.Diff(want, rejected.Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	if got := rejected.Errors[0].Claim(); got != "123456789" {
+		t.Errorf("Claim() = %q, want %q", got, "123456789")
+	}
+	if m.Response("0003") != nil {
+		t.Error("Response(0003) found a transaction set not acknowledged")
+	}
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+
+	"github.com/tmc/x12/schema"
+)
+
+// WriteGo writes Go source for package pkg declaring a variable named
+// name that holds ts as a *schema.TransactionSet literal. Loops that ts
+// shares among several parents are written once per use.
+func WriteGo(w io.Writer, pkg, name string, ts *schema.TransactionSet) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by x12schemagen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import \"github.com/tmc/x12/schema\"\n\n")
+	if ts.Name != "" {
+		fmt.Fprintf(&b, "// %s is the %s (%s) implementation guide.\n", name, ts.Name, ts.ID)
+	}
+	fmt.Fprintf(&b, "var %s = &schema.TransactionSet{\n", name)
+	field(&b, "ID", ts.ID)
+	field(&b, "Version", ts.Version)
+	field(&b, "Name", ts.Name)
+	b.WriteString("Loop: ")
+	writeLoop(&b, ts.Loop)
+	b.WriteString(",\n}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("importer: formatting %s: %w", name, err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+func writeLoop(b *bytes.Buffer, l *schema.Loop) {
+	b.WriteString("&schema.Loop{\n")
+	field(b, "ID", l.ID)
+	field(b, "Name", l.Name)
+	usage(b, l.Usage)
+	if l.Max != 0 {
+		fmt.Fprintf(b, "Max: %d,\n", l.Max)
+	}
+	writeRule(b, l.Rule)
+	b.WriteString("Children: []schema.Node{\n")
+	for _, c := range l.Children {
+		switch c := c.(type) {
+		case *schema.Loop:
+			writeLoop(b, c)
+		case *schema.Segment:
+			writeSegment(b, c)
+		}
+		b.WriteString(",\n")
+	}
+	b.WriteString("},\n}")
+}
+
+func writeSegment(b *bytes.Buffer, s *schema.Segment) {
+	b.WriteString("&schema.Segment{\n")
+	field(b, "ID", s.ID)
+	field(b, "Name", s.Name)
+	usage(b, s.Usage)
+	if s.Max != 0 {
+		fmt.Fprintf(b, "Max: %d,\n", s.Max)
+	}
+	writeRule(b, s.Rule)
+	if len(s.Elements) > 0 {
+		b.WriteString("Elements: []*schema.Element{\n")
+		for _, e := range s.Elements {
+			writeElement(b, e)
+			b.WriteString(",\n")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}")
+}
+
+func writeElement(b *bytes.Buffer, e *schema.Element) {
+	if e == nil {
+		b.WriteString("nil")
+		return
+	}
+	b.WriteString("{\n")
+	field(b, "Name", e.Name)
+	usage(b, e.Usage)
+	field(b, "Type", e.Type)
+	if e.Codes != nil {
+		b.WriteString("Codes: []string{")
+		for i, c := range e.Codes {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.Quote(c))
+		}
+		b.WriteString("},\n")
+	}
+	field(b, "CodeSet", e.CodeSet)
+	for _, f := range []struct {
+		name string
+		v    int
+	}{{"Component", e.Component}, {"MinLength", e.MinLength}, {"MaxLength", e.MaxLength}} {
+		if f.v != 0 {
+			fmt.Fprintf(b, "%s: %d,\n", f.name, f.v)
+		}
+	}
+	writeRule(b, e.Rule)
+	b.WriteString("}")
+}
+
+func writeRule(b *bytes.Buffer, r *schema.Rule) {
+	if r == nil {
+		return
+	}
+	b.WriteString("Rule: &schema.Rule{\n")
+	field(b, "Text", r.Text)
+	field(b, "Condition", r.Condition)
+	if r.Exclusive {
+		b.WriteString("Exclusive: true,\n")
+	}
+	b.WriteString("},\n")
+}
+
+// field writes a string field, omitting it if empty.
+func field(b *bytes.Buffer, name, v string) {
+	if v != "" {
+		fmt.Fprintf(b, "%s: %s,\n", name, strconv.Quote(v))
+	}
+}
+
+// usageNames are the identifiers of the usage designators.
+var usageNames = map[schema.Usage]string{
+	schema.Required:    "schema.Required",
+	schema.Situational: "schema.Situational",
+	schema.NotUsed:     "schema.NotUsed",
+}
+
+func usage(b *bytes.Buffer, u schema.Usage) {
+	if name, ok := usageNames[u]; ok {
+		fmt.Fprintf(b, "Usage: %s,\n", name)
+	}
+}
@@ -0,0 +1,369 @@
+// Package importer converts machine-readable implementation guide
+// definitions into the schema package's model.
+//
+// It reads one format: the XML transaction maps of the pyx12 project
+// (for example 837.5010.X222.A1.xml), in which nested loop, segment,
+// element, and composite elements carry each node's usage, repeat
+// count, and valid codes. X12's own XSD schemas and other guide formats
+// are not read.
+//
+// A map describes the whole transaction set, envelope included; the
+// importer keeps the part between ST and SE, splices in the contents of
+// wrapper loops (such as pyx12's HEADER and DETAIL tables), and records
+// external code lists by their X12 code source number.
+//
+// WriteGo renders an imported schema as Go source, for packages that
+// embed schemas the way package hipaa does; the x12schemagen command
+// drives it.
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+// ReadXML reads a transaction map in the pyx12 XML format. The
+// returned schema's Version is empty; the maps do not record it.
+func ReadXML(r io.Reader) (*schema.TransactionSet, error) {
+	var t mapTransaction
+	if err := xml.NewDecoder(r).Decode(&t); err != nil {
+		return nil, fmt.Errorf("importer: %w", err)
+	}
+	return t.convert()
+}
+
+// The map types mirror the pyx12 map format.
+type (
+	mapTransaction struct {
+		XID  string   `xml:"xid,attr"`
+		Name string   `xml:"name"`
+		Loop *mapLoop `xml:"loop"`
+	}
+
+	mapLoop struct {
+		XID      string
+		Type     string
+		Name     string
+		Usage    string
+		Repeat   string
+		Children []mapNode
+	}
+
+	// mapNode holds one child of a loop: a loop or a segment.
+	mapNode struct {
+		Loop    *mapLoop
+		Segment *mapSegment
+	}
+
+	mapSegment struct {
+		XID        string         `xml:"xid,attr"`
+		Name       string         `xml:"name"`
+		Usage      string         `xml:"usage"`
+		MaxUse     string         `xml:"max_use"`
+		Elements   []mapElement   `xml:"element"`
+		Composites []mapComposite `xml:"composite"`
+	}
+
+	mapComposite struct {
+		XID      string       `xml:"xid,attr"`
+		Name     string       `xml:"name"`
+		Usage    string       `xml:"usage"`
+		Seq      string       `xml:"seq"`
+		Elements []mapElement `xml:"element"`
+	}
+
+	mapElement struct {
+		XID        string    `xml:"xid,attr"`
+		Name       string    `xml:"name"`
+		Usage      string    `xml:"usage"`
+		Seq        string    `xml:"seq"`
+		DataType   string    `xml:"data_type"`
+		MinLen     string    `xml:"min_len"`
+		MaxLen     string    `xml:"max_len"`
+		ValidCodes *mapCodes `xml:"valid_codes"`
+	}
+
+	mapCodes struct {
+		External string   `xml:"external,attr"`
+		Codes    []string `xml:"code"`
+	}
+)
+
+// UnmarshalXML decodes a loop, keeping its loops and segments in
+// document order.
+func (l *mapLoop) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "xid":
+			l.XID = a.Value
+		case "type":
+			l.Type = a.Value
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			var err error
+			switch tok.Name.Local {
+			case "loop":
+				child := &mapLoop{}
+				err = d.DecodeElement(child, &tok)
+				l.Children = append(l.Children, mapNode{Loop: child})
+			case "segment":
+				child := &mapSegment{}
+				err = d.DecodeElement(child, &tok)
+				l.Children = append(l.Children, mapNode{Segment: child})
+			case "name":
+				err = d.DecodeElement(&l.Name, &tok)
+			case "usage":
+				err = d.DecodeElement(&l.Usage, &tok)
+			case "repeat":
+				err = d.DecodeElement(&l.Repeat, &tok)
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// convert converts the map to a schema.
+func (t *mapTransaction) convert() (*schema.TransactionSet, error) {
+	st := t.Loop.transactionLoop()
+	if st == nil {
+		return nil, fmt.Errorf("importer: %w: transaction %s has no ST loop", x12.ErrInvalidFormat, t.XID)
+	}
+	children, err := convertChildren(st.Children)
+	if err != nil {
+		return nil, fmt.Errorf("importer: transaction %s: %w", t.XID, err)
+	}
+	return &schema.TransactionSet{
+		ID:   t.XID,
+		Name: strings.TrimSpace(t.Name),
+		Loop: &schema.Loop{Children: children},
+	}, nil
+}
+
+// transactionLoop returns the loop, l or one nested within it, that
+// begins with the ST segment, or nil.
+func (l *mapLoop) transactionLoop() *mapLoop {
+	if l == nil {
+		return nil
+	}
+	if len(l.Children) > 0 && l.Children[0].Segment != nil && l.Children[0].Segment.XID == "ST" {
+		return l
+	}
+	for _, c := range l.Children {
+		if found := c.Loop.transactionLoop(); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// convertChildren converts a loop's contents, dropping the ST and SE
+// segments and splicing in the contents of wrapper loops.
+func convertChildren(nodes []mapNode) ([]schema.Node, error) {
+	var out []schema.Node
+	for _, n := range nodes {
+		switch {
+		case n.Loop != nil && n.Loop.Type == "wrapper":
+			children, err := convertChildren(n.Loop.Children)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, children...)
+		case n.Loop != nil:
+			l, err := n.Loop.convert()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, l)
+		case n.Segment != nil:
+			if n.Segment.XID == "ST" || n.Segment.XID == "SE" {
+				continue
+			}
+			s, err := n.Segment.convert()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (l *mapLoop) convert() (*schema.Loop, error) {
+	usage, err := parseUsage(l.Usage)
+	if err != nil {
+		return nil, fmt.Errorf("loop %s: %w", l.XID, err)
+	}
+	max, err := parseRepeat(l.Repeat)
+	if err != nil {
+		return nil, fmt.Errorf("loop %s: %w", l.XID, err)
+	}
+	children, err := convertChildren(l.Children)
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return nil, fmt.Errorf("%w: loop %s is empty", x12.ErrInvalidFormat, l.XID)
+	}
+	if _, ok := children[0].(*schema.Segment); !ok {
+		return nil, fmt.Errorf("%w: loop %s does not begin with a segment", x12.ErrInvalidFormat, l.XID)
+	}
+	return &schema.Loop{ID: l.XID, Name: strings.TrimSpace(l.Name), Usage: usage, Max: max, Children: children}, nil
+}
+
+func (s *mapSegment) convert() (*schema.Segment, error) {
+	usage, err := parseUsage(s.Usage)
+	if err != nil {
+		return nil, fmt.Errorf("segment %s: %w", s.XID, err)
+	}
+	max, err := parseRepeat(s.MaxUse)
+	if err != nil {
+		return nil, fmt.Errorf("segment %s: %w", s.XID, err)
+	}
+	sg := &schema.Segment{ID: s.XID, Name: strings.TrimSpace(s.Name), Usage: usage, Max: max}
+	for _, e := range s.Elements {
+		pos, el, err := e.convert()
+		if err != nil {
+			return nil, fmt.Errorf("segment %s: %w", s.XID, err)
+		}
+		setElement(sg, pos, el)
+	}
+	for _, c := range s.Composites {
+		pos, el, err := c.convert()
+		if err != nil {
+			return nil, fmt.Errorf("segment %s: %w", s.XID, err)
+		}
+		setElement(sg, pos, el)
+	}
+	return sg, nil
+}
+
+// setElement stores el as sg's detail for the element at pos.
+func setElement(sg *schema.Segment, pos int, el *schema.Element) {
+	for len(sg.Elements) < pos {
+		sg.Elements = append(sg.Elements, nil)
+	}
+	sg.Elements[pos-1] = el
+}
+
+func (e *mapElement) convert() (int, *schema.Element, error) {
+	pos, err := parseSeq(e.Seq)
+	if err != nil {
+		return 0, nil, fmt.Errorf("element %s: %w", e.XID, err)
+	}
+	usage, err := parseUsage(e.Usage)
+	if err != nil {
+		return 0, nil, fmt.Errorf("element %s: %w", e.XID, err)
+	}
+	el := &schema.Element{Name: strings.TrimSpace(e.Name), Usage: usage, Type: strings.TrimSpace(e.DataType)}
+	if el.MinLength, err = parseLength(e.MinLen); err != nil {
+		return 0, nil, fmt.Errorf("element %s: %w", e.XID, err)
+	}
+	if el.MaxLength, err = parseLength(e.MaxLen); err != nil {
+		return 0, nil, fmt.Errorf("element %s: %w", e.XID, err)
+	}
+	if c := e.ValidCodes; c != nil {
+		if c.External != "" {
+			el.CodeSet = c.External
+		} else {
+			el.Codes = c.Codes
+		}
+	}
+	return pos, el, nil
+}
+
+// convert converts a composite element. The composite's codes are
+// those of its first component, by which a composite qualifier is
+// matched; its code set is that of the first component drawing on one.
+func (c *mapComposite) convert() (int, *schema.Element, error) {
+	pos, err := parseSeq(c.Seq)
+	if err != nil {
+		return 0, nil, fmt.Errorf("composite %s: %w", c.XID, err)
+	}
+	usage, err := parseUsage(c.Usage)
+	if err != nil {
+		return 0, nil, fmt.Errorf("composite %s: %w", c.XID, err)
+	}
+	el := &schema.Element{Name: strings.TrimSpace(c.Name), Usage: usage}
+	for _, comp := range c.Elements {
+		seq, err := parseSeq(comp.Seq)
+		if err != nil {
+			return 0, nil, fmt.Errorf("element %s: %w", comp.XID, err)
+		}
+		codes := comp.ValidCodes
+		switch {
+		case codes == nil:
+		case codes.External != "" && el.CodeSet == "":
+			el.CodeSet, el.Component = codes.External, seq
+		case codes.External == "" && seq == 1:
+			el.Codes = codes.Codes
+		}
+	}
+	return pos, el, nil
+}
+
+// parseUsage parses a usage designator.
+func parseUsage(s string) (schema.Usage, error) {
+	var u schema.Usage
+	if err := u.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("%w: usage %q", x12.ErrInvalidFormat, s)
+	}
+	return u, nil
+}
+
+// parseRepeat parses a repeat count: a number, or ">1" for unbounded,
+// which is returned as 0. An empty count means 1.
+func parseRepeat(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "":
+		return 1, nil
+	case ">1":
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: repeat %q", x12.ErrInvalidFormat, s)
+	}
+	return n, nil
+}
+
+// parseLength parses an optional length bound.
+func parseLength(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: length %q", x12.ErrInvalidFormat, s)
+	}
+	return n, nil
+}
+
+// parseSeq parses an element's 1-based position.
+func parseSeq(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: seq %q", x12.ErrInvalidFormat, s)
+	}
+	return n, nil
+}
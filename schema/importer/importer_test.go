@@ -0,0 +1,132 @@
+package importer_test
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/schema/importer"
+)
+
+var mini = &schema.TransactionSet{
+	ID:   "837",
+	Name: "Mini Claim",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Name: "Beginning of Hierarchical Transaction", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+			{Name: "Hierarchical Structure Code", Usage: schema.Required, Type: "ID", Codes: []string{"0019"}, MinLength: 4, MaxLength: 4},
+		}},
+		&schema.Loop{ID: "1000A", Name: "Submitter Name", Usage: schema.Required, Max: 1, Children: []schema.Node{
+			&schema.Segment{ID: "NM1", Name: "Submitter Name", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+				{Name: "Entity Identifier Code", Usage: schema.Required, Codes: []string{"41"}},
+				nil,
+				nil,
+				{Name: "Name First", Usage: schema.NotUsed},
+			}},
+			&schema.Segment{ID: "PER", Name: "Submitter EDI Contact Information", Usage: schema.Required, Max: 2},
+		}},
+		&schema.Loop{ID: "2000A", Name: "Billing Provider Hierarchical Level", Usage: schema.Required, Children: []schema.Node{
+			&schema.Segment{ID: "HL", Name: "Billing Provider Hierarchical Level", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+				nil,
+				nil,
+				{Name: "Hierarchical Level Code", Usage: schema.Required, Codes: []string{"20"}},
+			}},
+			&schema.Loop{ID: "2300", Name: "Claim Information", Usage: schema.Situational, Max: 100, Children: []schema.Node{
+				&schema.Segment{ID: "CLM", Name: "Claim Information", Usage: schema.Required, Max: 1},
+				&schema.Segment{ID: "HI", Name: "Health Care Diagnosis Code", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+					{Name: "Health Care Code Information", Usage: schema.Required, Codes: []string{"ABK", "BK"}, CodeSet: "897", Component: 2},
+				}},
+			}},
+		}},
+	}},
+}
+
+func readMini(t *testing.T) *schema.TransactionSet {
+	t.Helper()
+	f, err := os.Open("testdata/mini.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	ts, err := importer.ReadXML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestReadXML(t *testing.T) {
+	if diff := cmp.Diff(mini, readMini(t)); diff != "" {
+		t.Errorf("schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestImportedSchemaParses(t *testing.T) {
+	ts := readMini(t)
+	doc, err := x12.Decode(strings.NewReader(`ST*837*1~BHT*0019~NM1*41*2*ACME~PER*IC*JOHN~HL*1**20*1~CLM*A1*10~HI*ABK:Z00~SE*8*1~`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, errs := ts.Parse(doc.Interchange.FunctionGroups[0].Transactions[0])
+	errs = append(errs, root.CheckUsage()...)
+	if len(errs) > 0 {
+		t.Errorf("Parse() and CheckUsage() = %v", errs)
+	}
+}
+
+func TestReadXMLErrors(t *testing.T) {
+	const st = `<segment xid="ST"><usage>R</usage></segment>`
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no ST loop", `<transaction xid="837"><loop xid="X"></loop></transaction>`},
+		{"bad usage", `<transaction xid="837"><loop xid="ST_LOOP">` + st + `<segment xid="BHT"><usage>Q</usage></segment></loop></transaction>`},
+		{"bad repeat", `<transaction xid="837"><loop xid="ST_LOOP">` + st + `<segment xid="BHT"><usage>R</usage><max_use>many</max_use></segment></loop></transaction>`},
+		{"bad seq", `<transaction xid="837"><loop xid="ST_LOOP">` + st + `<segment xid="BHT"><usage>R</usage><element xid="BHT01"><usage>R</usage><seq>x</seq></element></segment></loop></transaction>`},
+		{"loop without trigger", `<transaction xid="837"><loop xid="ST_LOOP">` + st + `<loop xid="1000A"><usage>R</usage></loop></loop></transaction>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := importer.ReadXML(strings.NewReader(tt.input)); !errors.Is(err, x12.ErrInvalidFormat) {
+				t.Errorf("ReadXML() = %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
+
+func TestWriteGo(t *testing.T) {
+	ts := mini.Clone()
+	ts.Version = "005010X999"
+	ts.Loop.Children[0].(*schema.Segment).Rule = &schema.Rule{Text: "Always.", Condition: "test.always", Exclusive: true}
+	var b bytes.Buffer
+	if err := importer.WriteGo(&b, "guides", "Mini", ts); err != nil {
+		t.Fatal(err)
+	}
+	src := b.String()
+	if _, err := parser.ParseFile(token.NewFileSet(), "mini_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"// Code generated by x12schemagen. DO NOT EDIT.",
+		"package guides",
+		"var Mini = &schema.TransactionSet{",
+		`Version: "005010X999",`,
+		`ID:    "2300",`,
+		`Codes:     []string{"ABK", "BK"},`,
+		`CodeSet:   "897",`,
+		"Exclusive: true,",
+		`Type:      "ID",`,
+		"nil,",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package jsonschema generates JSON Schema (draft 2020-12) documents
+// from implementation-guide schemas.
+//
+// The generated schemas describe the JSON encoding of the x12 package's
+// types, as produced by encoding/json: a Document holds an Interchange,
+// whose FunctionGroups hold Transactions, each with a Header, a flat
+// list of Segments, and a Trailer. A segment is an object with an ID
+// and a list of Elements, each an object with a Value.
+//
+// Every segment use in the guide becomes a definition under $defs that
+// fixes the segment ID, the qualifier codes that distinguish the use,
+// the required elements, and each element's code list, data type, and
+// length bounds. A transaction's Segments must each match one of these
+// definitions, and the segments and loops the guide requires at the
+// transaction set's top level must appear, within their repeat counts.
+//
+// JSON Schema cannot express the positional grammar of X12 loops, so
+// segment order, the requirements of nested loops, and situational
+// rules are not captured; schema.TransactionSet.Parse and package snip
+// check those once the JSON has been converted.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tmc/x12/schema"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// A Schema is a JSON Schema. Only the keywords the generator uses are
+// represented.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	PrefixItems []*Schema          `json:"prefixItems,omitempty"`
+	MinItems    int                `json:"minItems,omitempty"`
+	Contains    *Schema            `json:"contains,omitempty"`
+	MinContains *int               `json:"minContains,omitempty"`
+	MaxContains int                `json:"maxContains,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Const       *string            `json:"const,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	MinLength   int                `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Document returns a JSON Schema for an x12.Document whose transaction
+// sets all conform to ts.
+func Document(ts *schema.TransactionSet) *Schema {
+	s := Transaction(ts)
+	tx := &Schema{
+		Title:       s.Title,
+		Description: s.Description,
+		Type:        s.Type,
+		Properties:  s.Properties,
+		Required:    s.Required,
+		AllOf:       s.AllOf,
+	}
+	s.Defs["Transaction"] = tx
+	return &Schema{
+		Schema: Draft,
+		Title:  s.Title,
+		Type:   "object",
+		Properties: map[string]*Schema{
+			"Interchange": object(map[string]*Schema{
+				"Header":  {Type: "object"},
+				"Trailer": {Type: "object"},
+				"FunctionGroups": array(object(map[string]*Schema{
+					"Header":       {Type: "object"},
+					"Trailer":      {Type: "object"},
+					"Transactions": array(ref("Transaction")),
+				}, "Header", "Transactions")),
+			}, "FunctionGroups"),
+		},
+		Required: []string{"Interchange"},
+		Defs:     s.Defs,
+	}
+}
+
+// Transaction returns a JSON Schema for an x12.Transaction conforming to
+// ts.
+func Transaction(ts *schema.TransactionSet) *Schema {
+	g := &generator{defs: map[string]*Schema{"Element": element()}, names: make(map[*schema.Segment]string)}
+	var uses []*Schema
+	g.loop(ts.Loop, "", &uses)
+
+	header := map[string]*Schema{"IDCode": {Const: &ts.ID}}
+	if ts.Version != "" {
+		header["ImplementationConventionReference"] = &Schema{Enum: versions(ts.Version)}
+	}
+	s := &Schema{
+		Schema:      Draft,
+		Title:       title(ts),
+		Description: fmt.Sprintf("An x12.Transaction conforming to %s.", title(ts)),
+		Type:        "object",
+		Properties: map[string]*Schema{
+			"Header":   object(header, "IDCode"),
+			"Segments": array(&Schema{AnyOf: uses}),
+			"Trailer":  {Type: "object"},
+		},
+		Required: []string{"Header", "Segments"},
+		Defs:     g.defs,
+	}
+	s.AllOf = g.required(ts.Loop)
+	return s
+}
+
+// Marshal returns the indented JSON encoding of s.
+func Marshal(s *Schema) ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// A generator accumulates the definitions of a transaction set's
+// segment uses.
+type generator struct {
+	defs  map[string]*Schema
+	names map[*schema.Segment]string // definition names of visited segment uses
+}
+
+// loop adds definitions for the segment uses within l, appending
+// references to them to uses.
+func (g *generator) loop(l *schema.Loop, loopID string, uses *[]*Schema) {
+	for _, c := range l.Children {
+		switch c := c.(type) {
+		case *schema.Loop:
+			g.loop(c, c.ID, uses)
+		case *schema.Segment:
+			if _, ok := g.names[c]; ok {
+				continue // a loop shared by several parents
+			}
+			name := g.name(loopID, c)
+			g.names[c] = name
+			g.defs[name] = segment(c, loopID)
+			*uses = append(*uses, ref(name))
+		}
+	}
+}
+
+// name returns a unique definition name for the use of sg in loopID,
+// such as "2010AA.NM1.85".
+func (g *generator) name(loopID string, sg *schema.Segment) string {
+	parts := []string{sg.ID}
+	if loopID != "" {
+		parts = append([]string{loopID}, parts...)
+	}
+	if e := sg.Qualifier(); e != nil && len(e.Codes) > 0 {
+		parts = append(parts, e.Codes[0])
+	}
+	name := strings.Join(parts, ".")
+	for i := 2; g.defs[name] != nil; i++ {
+		name = fmt.Sprintf("%s.%d", strings.Join(parts, "."), i)
+	}
+	return name
+}
+
+// required returns the constraints that the required segments and
+// loops at l's top level appear, and no more often than allowed.
+func (g *generator) required(l *schema.Loop) []*Schema {
+	var all []*Schema
+	for _, c := range l.Children {
+		var sg *schema.Segment
+		var usage schema.Usage
+		max := 0
+		switch c := c.(type) {
+		case *schema.Loop:
+			sg, usage, max = c.Trigger(), c.Usage, c.Max
+		case *schema.Segment:
+			sg, usage, max = c, c.Usage, c.Max
+		}
+		if sg == nil || g.names[sg] == "" {
+			continue
+		}
+		s := &Schema{Contains: ref(g.names[sg])}
+		if max > 0 {
+			s.MaxContains = max
+		}
+		if usage != schema.Required {
+			if max == 0 {
+				continue
+			}
+			zero := 0
+			s.MinContains = &zero // an upper bound only
+		}
+		all = append(all, &Schema{Properties: map[string]*Schema{"Segments": s}})
+	}
+	return all
+}
+
+// segment returns the definition of one segment use.
+func segment(sg *schema.Segment, loopID string) *Schema {
+	desc := sg.Name
+	if loopID != "" {
+		desc = fmt.Sprintf("Loop %s: %s", loopID, sg.Name)
+	}
+	elements := &Schema{Type: "array", Items: ref("Element")}
+	required := 0
+	for i, e := range sg.Elements {
+		if e != nil && e.Usage == schema.Required {
+			required = i + 1
+		}
+	}
+	if len(sg.Elements) > 0 {
+		for _, e := range sg.Elements {
+			elements.PrefixItems = append(elements.PrefixItems, elementUse(e))
+		}
+		elements.MinItems = required
+	}
+	return &Schema{
+		Title:       sg.ID,
+		Description: desc,
+		Type:        "object",
+		Properties: map[string]*Schema{
+			"ID":       {Const: &sg.ID},
+			"Elements": elements,
+		},
+		Required: []string{"ID"},
+	}
+}
+
+// elementUse returns the schema for the guide's use of an element.
+func elementUse(e *schema.Element) *Schema {
+	if e == nil {
+		return ref("Element")
+	}
+	v := &Schema{Type: "string"}
+	switch {
+	case e.Usage == schema.NotUsed:
+		empty := ""
+		v = &Schema{Const: &empty}
+	case len(e.Codes) > 0 && e.Type == "ID":
+		v.Enum = e.Codes
+	case len(e.Codes) > 0:
+		// A composite is matched by its first component.
+		quoted := make([]string, len(e.Codes))
+		for i, c := range e.Codes {
+			quoted[i] = regexp.QuoteMeta(c)
+		}
+		v.Pattern = "^(?:" + strings.Join(quoted, "|") + ")(?:[^A-Za-z0-9].*)?$"
+	default:
+		v.Pattern = typePatterns[typeClass(e.Type)]
+	}
+	if e.Usage == schema.Required && v.MinLength == 0 {
+		v.MinLength = 1
+	}
+	if e.MinLength > v.MinLength {
+		v.MinLength = e.MinLength
+	}
+	if e.MaxLength > 0 {
+		n := e.MaxLength
+		v.MaxLength = &n
+	}
+	return &Schema{
+		Title:      e.Name,
+		Type:       "object",
+		Properties: map[string]*Schema{"Value": v},
+		Required:   []string{"Value"},
+	}
+}
+
+// typePatterns holds the value patterns of the X12 data types.
+var typePatterns = map[string]string{
+	"N":  `^-?[0-9]+$`,
+	"R":  `^-?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)$`,
+	"DT": `^(?:[0-9]{6}|[0-9]{8})$`,
+	"TM": `^[0-9]{4}(?:[0-9]{2}(?:[0-9]{1,2})?)?$`,
+}
+
+// typeClass maps the implied-decimal numeric types N0 through N9 to N.
+func typeClass(t string) string {
+	if len(t) == 2 && t[0] == 'N' && '0' <= t[1] && t[1] <= '9' {
+		return "N"
+	}
+	return t
+}
+
+// element returns the schema of an x12.Element.
+func element() *Schema {
+	return object(map[string]*Schema{
+		"Value":      {Type: "string"},
+		"Components": array(&Schema{Type: "string"}),
+	}, "Value")
+}
+
+func object(props map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: props, Required: required}
+}
+
+func array(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// title returns a human-readable name for ts.
+func title(ts *schema.TransactionSet) string {
+	parts := []string{ts.ID}
+	if ts.Version != "" {
+		parts = append(parts, ts.Version)
+	}
+	if ts.Name != "" {
+		parts = append(parts, ts.Name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// addenda matches an addenda suffix such as "A1".
+var addenda = regexp.MustCompile(`A[0-9]+$`)
+
+// versions returns the ST03 values a transaction conforming to version
+// may carry: the version itself, the version without its addenda
+// suffix, or nothing, leaving GS08 to identify the guide.
+func versions(version string) []string {
+	vs := []string{version}
+	if base := addenda.ReplaceAllString(version, ""); base != version {
+		vs = append(vs, base)
+	}
+	return append(vs, "")
+}
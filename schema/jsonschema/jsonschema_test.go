@@ -0,0 +1,238 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/schema/jsonschema"
+)
+
+// validate reports whether v, a decoded JSON value, is valid against s,
+// resolving references in root. It implements the keywords the
+// generator emits.
+func validate(t *testing.T, root, s *jsonschema.Schema, v any) bool {
+	t.Helper()
+	if s.Ref != "" {
+		def := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if def == nil {
+			t.Fatalf("unresolved reference %s", s.Ref)
+		}
+		return validate(t, root, def, v)
+	}
+	switch s.Type {
+	case "object":
+		if _, ok := v.(map[string]any); !ok {
+			return false
+		}
+	case "array":
+		if _, ok := v.([]any); !ok {
+			return false
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	if m, ok := v.(map[string]any); ok {
+		for _, name := range s.Required {
+			if _, ok := m[name]; !ok {
+				return false
+			}
+		}
+		for name, ps := range s.Properties {
+			if pv, ok := m[name]; ok && !validate(t, root, ps, pv) {
+				return false
+			}
+		}
+	}
+	if a, ok := v.([]any); ok {
+		if len(a) < s.MinItems {
+			return false
+		}
+		for i, item := range a {
+			is := s.Items
+			if i < len(s.PrefixItems) {
+				is = s.PrefixItems[i]
+			}
+			if is != nil && !validate(t, root, is, item) {
+				return false
+			}
+		}
+		if s.Contains != nil {
+			n := 0
+			for _, item := range a {
+				if validate(t, root, s.Contains, item) {
+					n++
+				}
+			}
+			min := 1
+			if s.MinContains != nil {
+				min = *s.MinContains
+			}
+			if n < min || s.MaxContains > 0 && n > s.MaxContains {
+				return false
+			}
+		}
+	}
+	if str, ok := v.(string); ok {
+		if s.Const != nil && str != *s.Const {
+			return false
+		}
+		if s.Enum != nil {
+			found := false
+			for _, e := range s.Enum {
+				found = found || e == str
+			}
+			if !found {
+				return false
+			}
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			return false
+		}
+		if len(str) < s.MinLength || s.MaxLength != nil && len(str) > *s.MaxLength {
+			return false
+		}
+	}
+	for _, sub := range s.AllOf {
+		if !validate(t, root, sub, v) {
+			return false
+		}
+	}
+	if s.AnyOf != nil {
+		any := false
+		for _, sub := range s.AnyOf {
+			if validate(t, root, sub, v) {
+				any = true
+				break
+			}
+		}
+		if !any {
+			return false
+		}
+	}
+	return true
+}
+
+// toJSON returns doc as a decoded JSON value.
+func toJSON(t *testing.T, doc *x12.Document) any {
+	t.Helper()
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func decode(t *testing.T, input string) *x12.Document {
+	t.Helper()
+	doc, err := x12.Decode(strings.NewReader(input), x12.WithRelaxedSegmentIDWhitespace())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDocumentX222A1Fixtures(t *testing.T) {
+	s := jsonschema.Document(hipaa.X222A1)
+	// Round-trip the schema through its JSON encoding, as a consumer
+	// would see it.
+	b, err := jsonschema.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = &jsonschema.Schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		t.Fatal(err)
+	}
+	paths, _ := filepath.Glob("../../testdata/005010x222*.edi")
+	if len(paths) == 0 {
+		t.Fatal("no fixtures")
+	}
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !validate(t, s, s, toJSON(t, decode(t, string(input)))) {
+			t.Errorf("%s does not validate", filepath.Base(path))
+		}
+	}
+}
+
+// smallSet has one top-level required segment, a situational segment
+// that may occur twice, and a loop whose trigger is qualified.
+var smallSet = &schema.TransactionSet{
+	ID:      "837",
+	Version: "005010X999A1",
+	Name:    "Small",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+			{Usage: schema.Required, Type: "ID", Codes: []string{"0019"}},
+			nil,
+			{Usage: schema.Required, Type: "AN", MinLength: 1, MaxLength: 5},
+			{Usage: schema.Required, Type: "DT"},
+			{Usage: schema.NotUsed},
+		}},
+		&schema.Segment{ID: "REF", Usage: schema.Situational, Max: 2},
+		&schema.Loop{ID: "2300", Usage: schema.Required, Children: []schema.Node{
+			&schema.Segment{ID: "CLM", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+				{Usage: schema.Required},
+				{Usage: schema.Required, Type: "R"},
+			}},
+			&schema.Segment{ID: "HI", Usage: schema.Situational, Max: 1, Elements: []*schema.Element{
+				{Usage: schema.Required, Codes: []string{"ABK", "BK"}},
+			}},
+		}},
+	}},
+}
+
+func TestTransaction(t *testing.T) {
+	s := jsonschema.Transaction(smallSet)
+	for _, name := range []string{"BHT.0019", "REF", "2300.CLM", "2300.HI.ABK", "Element"} {
+		if s.Defs[name] == nil {
+			t.Errorf("no definition %q", name)
+		}
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"valid", `ST*837*1*005010X999~BHT*0019**A1*20230101~REF*X~REF*Y~CLM*1*10.5~HI*ABK:Z00~SE*7*1~`, true},
+		{"valid without ST03", `ST*837*1~BHT*0019**A1*230101~CLM*1*10~SE*4*1~`, true},
+		{"wrong transaction set", `ST*835*1~BHT*0019**A1*20230101~CLM*1*10~SE*4*1~`, false},
+		{"other guide", `ST*837*1*005010X222~BHT*0019**A1*20230101~CLM*1*10~SE*4*1~`, false},
+		{"bad code", `ST*837*1~BHT*0020**A1*20230101~CLM*1*10~SE*4*1~`, false},
+		{"too long", `ST*837*1~BHT*0019**ABCDEF*20230101~CLM*1*10~SE*4*1~`, false},
+		{"bad date", `ST*837*1~BHT*0019**A1*2023~CLM*1*10~SE*4*1~`, false},
+		{"bad decimal", `ST*837*1~BHT*0019**A1*20230101~CLM*1*ten~SE*4*1~`, false},
+		{"not used element", `ST*837*1~BHT*0019**A1*20230101*X~CLM*1*10~SE*4*1~`, false},
+		{"missing element", `ST*837*1~BHT*0019**A1~CLM*1*10~SE*4*1~`, false},
+		{"missing segment", `ST*837*1~CLM*1*10~SE*3*1~`, false},
+		{"missing loop", `ST*837*1~BHT*0019**A1*20230101~SE*3*1~`, false},
+		{"too many", `ST*837*1~BHT*0019**A1*20230101~REF*X~REF*Y~REF*Z~CLM*1*10~SE*7*1~`, false},
+		{"unknown segment", `ST*837*1~BHT*0019**A1*20230101~CLM*1*10~NTE*X~SE*5*1~`, false},
+		{"unknown qualifier", `ST*837*1~BHT*0019**A1*20230101~CLM*1*10~HI*BF:Z00~SE*5*1~`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decode(t, tt.input)
+			tx := toJSON(t, doc).(map[string]any)["Interchange"].(map[string]any)["FunctionGroups"].([]any)[0].(map[string]any)["Transactions"].([]any)[0]
+			if got := validate(t, s, s, tx); got != tt.want {
+				t.Errorf("valid = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tmc/x12"
+)
+
+// An Overlay is a companion guide: one trading partner's narrowing of a
+// base implementation guide, such as shorter maximum lengths, fewer
+// allowed codes, or additional required segments.
+//
+// Overlays are usually kept in files, one per companion guide, and read
+// with ReadOverlay. The file format is the JSON encoding of Overlay,
+// with usage designators written as "R", "S", or "N":
+//
+//	{
+//		"Name": "Acme Health Plan 837P Companion Guide",
+//		"Base": "005010X222A1",
+//		"Partners": [{"Receiver": "ACMEHP"}],
+//		"Changes": [
+//			{"Loop": "2300", "Segment": "CLM", "Element": 1, "MaxLength": 20},
+//			{"Loop": "2010BB", "Segment": "REF", "Qualifier": "G2", "Add": true, "After": "N4", "Usage": "R", "Max": 1}
+//		]
+//	}
+type Overlay struct {
+	Name string
+
+	// Base is the implementation convention reference of the guide the
+	// overlay narrows, matched against TransactionSet.Version.
+	Base string
+
+	// Partners lists the interchanges the overlay applies to.
+	Partners []Partner
+
+	// Changes are applied to a copy of the base guide in order.
+	Changes []Change
+}
+
+// A Partner identifies a trading partner relationship by sender and
+// receiver IDs, which are compared with both the interchange (ISA06,
+// ISA08) and functional group (GS02, GS03) IDs, ignoring surrounding
+// spaces. An empty ID matches any.
+type Partner struct {
+	Sender   string `json:",omitempty"`
+	Receiver string `json:",omitempty"`
+}
+
+// A Change alters one segment use, or one of its elements, in the base
+// guide.
+type Change struct {
+	// Loop is the ID of the loop holding the segment; empty means the
+	// transaction set's top level.
+	Loop string `json:",omitempty"`
+
+	// Segment and Qualifier select the segment use: the one in Loop
+	// with ID Segment whose qualifying element allows the code
+	// Qualifier, or the first use of Segment if Qualifier is empty.
+	Segment   string
+	Qualifier string `json:",omitempty"`
+
+	// Add inserts a new use of Segment, qualified by Qualifier, instead
+	// of selecting an existing one. It is placed after the last use of
+	// the segment ID After, or of Segment itself if After is empty.
+	Add   bool   `json:",omitempty"`
+	After string `json:",omitempty"`
+
+	// Element is the 1-based position of the element to change, or 0
+	// to change the segment itself.
+	Element int `json:",omitempty"`
+
+	// The remaining fields replace the corresponding fields of the
+	// segment or element when set. Max applies only to segments, and
+	// Codes, MinLength, and MaxLength only to elements.
+	Usage     Usage    `json:",omitempty"`
+	Max       int      `json:",omitempty"`
+	Codes     []string `json:",omitempty"`
+	MinLength int      `json:",omitempty"`
+	MaxLength int      `json:",omitempty"`
+	Rule      *Rule    `json:",omitempty"`
+}
+
+// ReadOverlay reads an overlay in its JSON file format from r.
+func ReadOverlay(r io.Reader) (*Overlay, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var o Overlay
+	if err := dec.Decode(&o); err != nil {
+		return nil, fmt.Errorf("schema: reading overlay: %w", err)
+	}
+	return &o, nil
+}
+
+// Matches reports whether the overlay applies to an interchange with
+// the given envelope headers. An overlay without Partners applies to
+// every interchange.
+func (o *Overlay) Matches(isa *x12.ISA, gs *x12.GS) bool {
+	if len(o.Partners) == 0 {
+		return true
+	}
+	var senders, receivers []string
+	if isa != nil {
+		senders = append(senders, isa.SenderID)
+		receivers = append(receivers, isa.ReceiverID)
+	}
+	if gs != nil {
+		senders = append(senders, gs.SenderCode)
+		receivers = append(receivers, gs.ReceiverCode)
+	}
+	for _, p := range o.Partners {
+		if idMatches(p.Sender, senders) && idMatches(p.Receiver, receivers) {
+			return true
+		}
+	}
+	return false
+}
+
+// idMatches reports whether id is empty or equals one of ids.
+func idMatches(id string, ids []string) bool {
+	if id == "" {
+		return true
+	}
+	for _, v := range ids {
+		if strings.TrimSpace(v) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns a copy of base with the overlay's changes applied. base
+// is not modified. It reports an error wrapping x12.ErrInvalidArgument
+// if base is not the guide the overlay narrows or a change does not
+// fit it.
+func (o *Overlay) Apply(base *TransactionSet) (*TransactionSet, error) {
+	if o.Base != base.Version {
+		return nil, fmt.Errorf("%w: overlay %q is for %s, not %s", x12.ErrInvalidArgument, o.Name, o.Base, base.Version)
+	}
+	ts := base.Clone()
+	ts.Name = base.Name + " (" + o.Name + ")"
+	for i, c := range o.Changes {
+		if err := c.apply(ts); err != nil {
+			return nil, fmt.Errorf("%w: overlay %q change %d: %v", x12.ErrInvalidArgument, o.Name, i+1, err)
+		}
+	}
+	return ts, nil
+}
+
+// apply applies c to ts.
+func (c *Change) apply(ts *TransactionSet) error {
+	l := ts.Loop
+	if c.Loop != "" {
+		if l = l.Loop(c.Loop); l == nil {
+			return fmt.Errorf("no loop %s", c.Loop)
+		}
+	}
+	var sg *Segment
+	if c.Add {
+		sg = insertSegment(l, c.Segment, c.Qualifier, c.After)
+		if sg == nil {
+			return fmt.Errorf("loop %s has no segment %s to add %s after", c.Loop, c.After, c.Segment)
+		}
+	} else if sg = findSegment(l, c.Segment, c.Qualifier); sg == nil {
+		return fmt.Errorf("loop %s has no segment %s %s", c.Loop, c.Segment, c.Qualifier)
+	}
+	if c.Element == 0 {
+		if c.Usage != 0 {
+			sg.Usage = c.Usage
+		}
+		if c.Max != 0 {
+			sg.Max = c.Max
+		}
+		if c.Rule != nil {
+			sg.Rule = c.Rule
+		}
+		return nil
+	}
+	el := elementAt(sg, c.Element)
+	if c.Usage != 0 {
+		el.Usage = c.Usage
+	}
+	if c.Codes != nil {
+		el.Codes = c.Codes
+	}
+	if c.MinLength != 0 {
+		el.MinLength = c.MinLength
+	}
+	if c.MaxLength != 0 {
+		el.MaxLength = c.MaxLength
+	}
+	if c.Rule != nil {
+		el.Rule = c.Rule
+	}
+	return nil
+}
+
+// findSegment returns the use of segment id in l qualified by
+// qualifier, or the first use if qualifier is empty.
+func findSegment(l *Loop, id, qualifier string) *Segment {
+	for _, c := range l.Children {
+		sg, ok := c.(*Segment)
+		if !ok || sg.ID != id {
+			continue
+		}
+		if qualifier == "" {
+			return sg
+		}
+		if e := sg.Qualifier(); e != nil {
+			for _, code := range e.Codes {
+				if code == qualifier {
+					return sg
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// insertSegment adds a new use of segment id, restricted to qualifier
+// if it is not empty, after the last use of segment after (or of id, if
+// after is empty) in l. It returns nil if there is no such use.
+func insertSegment(l *Loop, id, qualifier, after string) *Segment {
+	if after == "" {
+		after = id
+	}
+	at := -1
+	for i, c := range l.Children {
+		if sg, ok := c.(*Segment); ok && sg.ID == after {
+			at = i
+		}
+	}
+	if at < 0 {
+		return nil
+	}
+	sg := &Segment{ID: id, Usage: Situational}
+	if qualifier != "" {
+		elementAt(sg, qualifierPos(id)).Codes = []string{qualifier}
+		sg.Elements[qualifierPos(id)-1].Usage = Required
+	}
+	l.Children = append(l.Children[:at+1], append([]Node{sg}, l.Children[at+1:]...)...)
+	return sg
+}
+
+// elementAt returns sg's detail for the element at the 1-based
+// position pos, creating it if necessary.
+func elementAt(sg *Segment, pos int) *Element {
+	for len(sg.Elements) < pos {
+		sg.Elements = append(sg.Elements, nil)
+	}
+	if sg.Elements[pos-1] == nil {
+		sg.Elements[pos-1] = &Element{}
+	}
+	return sg.Elements[pos-1]
+}
+
+// Clone returns a deep copy of ts. Loops, segments, and elements are
+// copied, preserving any sharing among them; rules are shared.
+func (ts *TransactionSet) Clone() *TransactionSet {
+	c := *ts
+	loops := make(map[*Loop]*Loop)
+	c.Loop = cloneLoop(ts.Loop, loops)
+	return &c
+}
+
+// cloneLoop deep-copies l, reusing the copies recorded in loops.
+func cloneLoop(l *Loop, loops map[*Loop]*Loop) *Loop {
+	if l == nil {
+		return nil
+	}
+	if c, ok := loops[l]; ok {
+		return c
+	}
+	c := *l
+	loops[l] = &c
+	c.Children = make([]Node, len(l.Children))
+	for i, n := range l.Children {
+		switch n := n.(type) {
+		case *Loop:
+			c.Children[i] = cloneLoop(n, loops)
+		case *Segment:
+			sg := *n
+			sg.Elements = make([]*Element, len(n.Elements))
+			for j, e := range n.Elements {
+				if e != nil {
+					ec := *e
+					ec.Codes = append([]string(nil), e.Codes...)
+					sg.Elements[j] = &ec
+				}
+			}
+			c.Children[i] = &sg
+		}
+	}
+	return &c
+}
@@ -0,0 +1,150 @@
+package schema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+// baseSet is a guide for overlays to narrow.
+var baseSet = &schema.TransactionSet{
+	ID:      "999",
+	Version: "005010X999",
+	Name:    "Test",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+		&schema.Loop{ID: "A", Usage: schema.Required, Children: []schema.Node{
+			&schema.Segment{ID: "NM1", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+				{Usage: schema.Required, Codes: []string{"41"}},
+				nil,
+				{Usage: schema.Required},
+			}},
+			&schema.Segment{ID: "N3", Usage: schema.Situational, Max: 1},
+			&schema.Segment{ID: "REF", Usage: schema.Situational, Max: 1, Elements: []*schema.Element{
+				{Usage: schema.Required, Codes: []string{"EI", "SY"}},
+			}},
+		}},
+	}},
+}
+
+const companion = `{
+	"Name": "Acme",
+	"Base": "005010X999",
+	"Partners": [{"Receiver": "ACME"}],
+	"Changes": [
+		{"Loop": "A", "Segment": "NM1", "Element": 3, "MaxLength": 5},
+		{"Loop": "A", "Segment": "REF", "Element": 1, "Codes": ["EI"]},
+		{"Loop": "A", "Segment": "N3", "Usage": "R"},
+		{"Loop": "A", "Segment": "REF", "Qualifier": "G2", "Add": true, "Usage": "R", "Max": 1}
+	]
+}`
+
+func TestOverlayApply(t *testing.T) {
+	o, err := schema.ReadOverlay(strings.NewReader(companion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err := o.Apply(baseSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.Name != "Test (Acme)" {
+		t.Errorf("Name = %q", ts.Name)
+	}
+
+	tests := []struct {
+		name  string
+		set   *schema.TransactionSet
+		input string
+		want  []error
+	}{
+		{
+			name:  "base accepts",
+			set:   baseSet,
+			input: `ST*999*1~BHT*1~NM1*41**SMITHSON~REF*SY*1~SE*5*1~`,
+		},
+		{
+			name:  "overlay narrows",
+			set:   ts,
+			input: `ST*999*1~BHT*1~NM1*41**SMITHSON~REF*SY*1~SE*5*1~`,
+			want:  []error{schema.ErrUnexpectedSegment, schema.ErrInvalidLength, schema.ErrMissingSegment, schema.ErrMissingSegment},
+		},
+		{
+			name:  "overlay accepts",
+			set:   ts,
+			input: `ST*999*1~BHT*1~NM1*41**SMITH~N3*1 MAIN~REF*EI*1~REF*G2*2~SE*7*1~`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, errs := tt.set.Parse(transaction(t, tt.input))
+			errs = append(errs, root.CheckUsage()...)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("errors = %v, want %v", errs, tt.want)
+			}
+			for i, err := range errs {
+				if !errors.Is(err, tt.want[i]) {
+					t.Errorf("error %d = %v, want %v", i, err, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOverlayApplyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay schema.Overlay
+	}{
+		{"wrong base", schema.Overlay{Base: "005010X222A1"}},
+		{"no loop", schema.Overlay{Base: "005010X999", Changes: []schema.Change{{Loop: "Z", Segment: "N3"}}}},
+		{"no segment", schema.Overlay{Base: "005010X999", Changes: []schema.Change{{Loop: "A", Segment: "N4"}}}},
+		{"no qualifier", schema.Overlay{Base: "005010X999", Changes: []schema.Change{{Loop: "A", Segment: "REF", Qualifier: "G2"}}}},
+		{"nothing to add after", schema.Overlay{Base: "005010X999", Changes: []schema.Change{{Loop: "A", Segment: "PER", Add: true}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.overlay.Apply(baseSet); !errors.Is(err, x12.ErrInvalidArgument) {
+				t.Errorf("Apply() = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestOverlayMatches(t *testing.T) {
+	o := &schema.Overlay{Partners: []schema.Partner{{Sender: "CLINIC", Receiver: "ACME"}, {Receiver: "BETA"}}}
+	tests := []struct {
+		isa  *x12.ISA
+		gs   *x12.GS
+		want bool
+	}{
+		{&x12.ISA{SenderID: "CLINIC         ", ReceiverID: "ACME           "}, nil, true},
+		{&x12.ISA{SenderID: "X", ReceiverID: "Y"}, &x12.GS{SenderCode: "CLINIC", ReceiverCode: "ACME"}, true},
+		{&x12.ISA{SenderID: "OTHER", ReceiverID: "ACME"}, nil, false},
+		{&x12.ISA{SenderID: "OTHER", ReceiverID: "BETA"}, nil, true},
+		{nil, nil, false},
+	}
+	for _, tt := range tests {
+		if got := o.Matches(tt.isa, tt.gs); got != tt.want {
+			t.Errorf("Matches(%+v, %+v) = %v, want %v", tt.isa, tt.gs, got, tt.want)
+		}
+	}
+	if !(&schema.Overlay{}).Matches(nil, nil) {
+		t.Error("overlay without partners does not match")
+	}
+}
+
+func TestReadOverlayErrors(t *testing.T) {
+	for _, input := range []string{
+		`{"Name": "x", "Changes": [{"Segment": "N3", "Usage": "Q"}]}`,
+		`{"Name": "x", "Unknown": true}`,
+		`{`,
+	} {
+		if _, err := schema.ReadOverlay(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadOverlay(%s) succeeded", input)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/tmc/x12"
+)
+
+// Sentinel errors wrapped by *Error values; match them with errors.Is.
+var (
+	// ErrUnexpectedSegment reports a segment that has no place in the
+	// transaction set at the point where it appears.
+	ErrUnexpectedSegment = errors.New("unexpected segment")
+	// ErrMissingSegment reports a required segment, or the trigger
+	// segment of a required loop, that is absent.
+	ErrMissingSegment = errors.New("missing segment")
+	// ErrNotUsed reports a segment or element that is present where the
+	// guide says not to send it.
+	ErrNotUsed = errors.New("not used")
+)
+
+// A LoopNode is one occurrence of a schema loop within a transaction:
+// the segments matched to the loop and the occurrences of its nested
+// loops.
+type LoopNode struct {
+	Loop   *Loop     // the schema loop; for the top level, the transaction set's Loop
+	Parent *LoopNode // nil for the top level
+
+	// Segments are the segments that belong directly to this
+	// occurrence, in transaction order. A segment that could not be
+	// placed anywhere is kept, with a nil Schema, in the loop that was
+	// open when it was read.
+	Segments []*SegmentNode
+
+	// Children are the occurrences of nested loops, in transaction
+	// order.
+	Children []*LoopNode
+}
+
+// A SegmentNode is a transaction segment matched to its schema segment.
+type SegmentNode struct {
+	Schema   *Segment // nil if the segment was unexpected
+	Segment  *x12.Segment
+	Position int // 1-based position within the transaction set, counting ST as 1
+}
+
+// ID returns the ID of the node's schema loop.
+func (n *LoopNode) ID() string { return n.Loop.ID }
+
+// Position returns the position of the loop occurrence's first
+// segment, or 1 (the ST segment) for the top level.
+func (n *LoopNode) Position() int {
+	if n.Parent == nil || len(n.Segments) == 0 {
+		return 1
+	}
+	return n.Segments[0].Position
+}
+
+// Segment returns the first segment with the given ID that belongs
+// directly to n, or nil if there is none.
+func (n *LoopNode) Segment(id string) *x12.Segment {
+	for _, s := range n.Segments {
+		if s.Segment.ID == id {
+			return s.Segment
+		}
+	}
+	return nil
+}
+
+// Value returns the value of the element named by the reference
+// designator ref, such as "SBR02", in the first segment with that ID
+// belonging directly to n. It returns "" if there is no such segment or
+// element.
+func (n *LoopNode) Value(ref string) string {
+	if len(ref) < 3 {
+		return ""
+	}
+	pos, err := strconv.Atoi(ref[len(ref)-2:])
+	if err != nil {
+		return ""
+	}
+	seg := n.Segment(ref[:len(ref)-2])
+	if seg == nil {
+		return ""
+	}
+	return value(*seg, pos)
+}
+
+// Loops returns the occurrences of the nested loop id that belong
+// directly to n.
+func (n *LoopNode) Loops(id string) []*LoopNode {
+	var loops []*LoopNode
+	for _, c := range n.Children {
+		if c.Loop.ID == id {
+			loops = append(loops, c)
+		}
+	}
+	return loops
+}
+
+// Ancestor returns the nearest loop occurrence enclosing n, n included,
+// whose loop ID is id, or nil if there is none.
+func (n *LoopNode) Ancestor(id string) *LoopNode {
+	for ; n != nil; n = n.Parent {
+		if n.Loop.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// Walk calls fn for n and each loop occurrence nested within it, depth
+// first, in transaction order.
+func (n *LoopNode) Walk(fn func(*LoopNode)) {
+	fn(n)
+	for _, c := range n.Children {
+		c.Walk(fn)
+	}
+}
+
+// Parse arranges tx's segments into loops according to ts.
+//
+// Segments are placed positionally, as the X12 standard prescribes:
+// each segment is matched against the remaining contents of the
+// innermost open loop, then of each enclosing loop in turn, and the
+// trigger segment of a nested loop opens a new occurrence of it. A
+// segment that fits nowhere is reported as an *Error wrapping
+// ErrUnexpectedSegment and kept, unmatched, in the innermost open loop.
+//
+// Parse checks only placement; the usage rules are checked separately.
+func (ts *TransactionSet) Parse(tx *x12.Transaction) (*LoopNode, []error) {
+	root := &LoopNode{Loop: ts.Loop}
+	if tx == nil {
+		return root, []error{fmt.Errorf("%w: nil transaction", x12.ErrInvalidArgument)}
+	}
+	if tx.Header != nil && tx.Header.IDCode != ts.ID {
+		return root, []error{fmt.Errorf("%w: transaction set %s parsed with schema for %s", x12.ErrInvalidArgument, tx.Header.IDCode, ts.ID)}
+	}
+	type frame struct {
+		node   *LoopNode
+		cursor int // index of the last child matched
+	}
+	stack := []*frame{{node: root}}
+	var errs []error
+	for i := range tx.Segments {
+		seg := &tx.Segments[i]
+		pos := i + 2 // ST is position 1
+		placed := false
+	search:
+		for lvl := len(stack) - 1; lvl >= 0; lvl-- {
+			f := stack[lvl]
+			start := f.cursor
+			if lvl > 0 && start == 0 {
+				// A loop's trigger begins a new occurrence, which the
+				// enclosing loop handles.
+				start = 1
+			}
+			children := f.node.Loop.Children
+			for j := start; j < len(children); j++ {
+				switch c := children[j].(type) {
+				case *Segment:
+					if !c.Matches(*seg) {
+						continue
+					}
+					f.node.Segments = append(f.node.Segments, &SegmentNode{Schema: c, Segment: seg, Position: pos})
+					f.cursor = j
+					stack = stack[:lvl+1]
+				case *Loop:
+					trigger := c.Trigger()
+					if trigger == nil || !trigger.Matches(*seg) {
+						continue
+					}
+					child := &LoopNode{Loop: c, Parent: f.node}
+					child.Segments = append(child.Segments, &SegmentNode{Schema: trigger, Segment: seg, Position: pos})
+					f.node.Children = append(f.node.Children, child)
+					f.cursor = j
+					stack = append(stack[:lvl+1], &frame{node: child})
+				}
+				placed = true
+				break search
+			}
+		}
+		if !placed {
+			top := stack[len(stack)-1].node
+			top.Segments = append(top.Segments, &SegmentNode{Segment: seg, Position: pos})
+			errs = append(errs, &Error{Position: pos, SegmentID: seg.ID, LoopID: top.Loop.ID, Err: ErrUnexpectedSegment})
+		}
+	}
+	return root, errs
+}
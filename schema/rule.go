@@ -0,0 +1,186 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tmc/x12"
+)
+
+// ErrUnknownCondition reports a rule naming a condition that has not
+// been registered.
+var ErrUnknownCondition = errors.New("unknown condition")
+
+// A Rule is a situational usage note from an implementation guide, such
+// as "Required when the patient is a different person than the
+// subscriber. If not required by this implementation guide, do not
+// send."
+//
+// When the named condition holds, the loop, segment, or element the
+// rule is attached to is required. When it does not, the node may be
+// sent at the sender's discretion, unless the rule is Exclusive, in
+// which case it must not be sent.
+type Rule struct {
+	// Text is the note as the guide words it; it is reported with
+	// violations.
+	Text string
+
+	// Condition names the registered Condition that decides the rule.
+	// A rule without one documents the guide but is not evaluated, as
+	// when the situation depends on facts outside the transaction.
+	Condition string
+
+	// Exclusive reports that the node must not be sent when the
+	// condition does not hold.
+	Exclusive bool
+}
+
+// A Condition decides whether a situational rule's situation holds.
+type Condition func(ctx *Context) bool
+
+// A Context is the part of a transaction a Condition is evaluated
+// against.
+type Context struct {
+	// Loop is the loop occurrence in which the rule's node appears, or
+	// would appear: for a rule on a loop, the enclosing occurrence; for
+	// a rule on a segment or element, the occurrence holding the
+	// segment.
+	Loop *LoopNode
+
+	// Segment is the segment holding the element, for a rule on an
+	// element; it is nil otherwise.
+	Segment *x12.Segment
+}
+
+var (
+	conditionsMu sync.RWMutex
+	conditions   = make(map[string]Condition)
+)
+
+// RegisterCondition makes a condition available to rules by name. It
+// panics if name is empty, c is nil, or the name is already registered.
+// Condition names are conventionally prefixed with the guide or
+// companion guide that defines them, as in
+// "005010X222A1.patient-not-subscriber".
+func RegisterCondition(name string, c Condition) {
+	conditionsMu.Lock()
+	defer conditionsMu.Unlock()
+	if name == "" || c == nil {
+		panic("schema: RegisterCondition with empty name or nil condition")
+	}
+	if _, dup := conditions[name]; dup {
+		panic("schema: RegisterCondition called twice for " + name)
+	}
+	conditions[name] = c
+}
+
+// Conditions returns the names of the registered conditions, sorted.
+func Conditions() []string {
+	conditionsMu.RLock()
+	defer conditionsMu.RUnlock()
+	names := make([]string, 0, len(conditions))
+	for name := range conditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupCondition(name string) Condition {
+	conditionsMu.RLock()
+	defer conditionsMu.RUnlock()
+	return conditions[name]
+}
+
+// CheckRules evaluates the situational rules of the loops, segments, and
+// elements that can appear within n and its nested loop occurrences. A
+// node that a rule requires but that is absent is reported as an *Error
+// wrapping ErrMissingSegment (for loops and segments) or
+// x12.ErrMissingElement; one that an exclusive rule forbids but that is
+// present wraps ErrNotUsed. Rules naming an unregistered condition are
+// reported with ErrUnknownCondition.
+func (n *LoopNode) CheckRules() []error {
+	var errs []error
+	n.Walk(func(n *LoopNode) {
+		errs = append(errs, n.checkRules()...)
+	})
+	return errs
+}
+
+// checkRules evaluates the rules of n's own contents.
+func (n *LoopNode) checkRules() []error {
+	var errs []error
+	for _, c := range n.Loop.Children {
+		switch c := c.(type) {
+		case *Loop:
+			if c.Rule == nil {
+				continue
+			}
+			occurs := n.Loops(c.ID)
+			e := &Error{Position: n.Position(), LoopID: c.ID, Rule: c.Rule}
+			if t := c.Trigger(); t != nil {
+				e.SegmentID = t.ID
+			}
+			if len(occurs) > 0 {
+				e.Position = occurs[0].Position()
+			}
+			if err := evaluate(c.Rule, &Context{Loop: n}, len(occurs) > 0, e, ErrMissingSegment); err != nil {
+				errs = append(errs, err)
+			}
+		case *Segment:
+			var occurs []*SegmentNode
+			for _, s := range n.Segments {
+				if s.Schema == c {
+					occurs = append(occurs, s)
+				}
+			}
+			if c.Rule != nil {
+				e := &Error{Position: n.Position(), SegmentID: c.ID, LoopID: n.Loop.ID, Rule: c.Rule}
+				if len(occurs) > 0 {
+					e.Position = occurs[0].Position
+				}
+				if err := evaluate(c.Rule, &Context{Loop: n}, len(occurs) > 0, e, ErrMissingSegment); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			for _, s := range occurs {
+				for i, el := range c.Elements {
+					if el == nil || el.Rule == nil {
+						continue
+					}
+					e := &Error{Position: s.Position, SegmentID: c.ID, LoopID: n.Loop.ID, Element: i + 1, Rule: el.Rule}
+					if err := evaluate(el.Rule, &Context{Loop: n, Segment: s.Segment}, present(*s.Segment, i+1), e, x12.ErrMissingElement); err != nil {
+						errs = append(errs, err)
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// evaluate decides r in ctx for a node whose presence is given. If the
+// rule is violated it fills in e, using missing as the error for an
+// absent required node, and returns it; otherwise it returns nil.
+func evaluate(r *Rule, ctx *Context, isPresent bool, e *Error, missing error) error {
+	if r.Condition == "" {
+		return nil
+	}
+	cond := lookupCondition(r.Condition)
+	if cond == nil {
+		e.Err = fmt.Errorf("%w %q", ErrUnknownCondition, r.Condition)
+		return e
+	}
+	holds := cond(ctx)
+	switch {
+	case holds && !isPresent:
+		e.Err = missing
+	case !holds && isPresent && r.Exclusive:
+		e.Err = ErrNotUsed
+	default:
+		return nil
+	}
+	return e
+}
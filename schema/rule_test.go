@@ -0,0 +1,162 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+func init() {
+	schema.RegisterCondition("test.has-hi", func(ctx *schema.Context) bool {
+		return ctx.Loop.Segment("HI") != nil
+	})
+	schema.RegisterCondition("test.ref-ei", func(ctx *schema.Context) bool {
+		return ctx.Segment != nil && ctx.Segment.Elements[0].Value == "EI"
+	})
+}
+
+// ruleSet is a transaction set whose situational nodes carry rules:
+// the N3 segment is required when the loop has an HI segment and must
+// not be sent otherwise, the C loop likewise, and REF03 is required
+// when REF01 is EI.
+var ruleSet = &schema.TransactionSet{
+	ID: "999",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+		&schema.Loop{ID: "A", Usage: schema.Required, Children: []schema.Node{
+			&schema.Segment{ID: "HL", Usage: schema.Required, Max: 1},
+			&schema.Segment{ID: "HI", Usage: schema.Situational, Max: 1},
+			&schema.Segment{ID: "N3", Usage: schema.Situational, Max: 1, Rule: &schema.Rule{
+				Text:      "Required when HI is sent.",
+				Condition: "test.has-hi",
+				Exclusive: true,
+			}},
+			&schema.Segment{ID: "REF", Usage: schema.Situational, Elements: []*schema.Element{nil, nil, {
+				Usage: schema.Situational,
+				Rule:  &schema.Rule{Text: "Required when REF01 is EI.", Condition: "test.ref-ei"},
+			}}},
+			&schema.Segment{ID: "DMG", Usage: schema.Situational, Rule: &schema.Rule{
+				Text: "Required when the date of birth is known.",
+			}},
+			&schema.Loop{ID: "C", Usage: schema.Situational, Rule: &schema.Rule{
+				Text:      "Required when HI is sent.",
+				Condition: "test.has-hi",
+				Exclusive: true,
+			}, Children: []schema.Node{
+				&schema.Segment{ID: "LX", Usage: schema.Required, Max: 1},
+			}},
+		}},
+	}},
+}
+
+func TestCheckRules(t *testing.T) {
+	type violation struct {
+		pos     int
+		loopID  string
+		segID   string
+		element int
+		err     error
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []violation
+	}{
+		{
+			name:  "satisfied",
+			input: `ST*999*1~BHT*1~HL*1~HI*BK:1~N3*1 MAIN~REF*EI*1*X~REF*G2*2~DMG*D8*19700101~LX*1~SE*10*1~`,
+		},
+		{
+			name:  "nothing situational",
+			input: `ST*999*1~BHT*1~HL*1~SE*4*1~`,
+		},
+		{
+			name:  "required nodes missing",
+			input: `ST*999*1~BHT*1~HL*1~HI*BK:1~REF*EI*1~SE*6*1~`,
+			want: []violation{
+				{pos: 3, loopID: "A", segID: "N3", err: schema.ErrMissingSegment},
+				{pos: 5, loopID: "A", segID: "REF", element: 3, err: x12.ErrMissingElement},
+				{pos: 3, loopID: "C", segID: "LX", err: schema.ErrMissingSegment},
+			},
+		},
+		{
+			name:  "exclusive nodes present",
+			input: `ST*999*1~BHT*1~HL*1~N3*1 MAIN~LX*1~SE*6*1~`,
+			want: []violation{
+				{pos: 4, loopID: "A", segID: "N3", err: schema.ErrNotUsed},
+				{pos: 5, loopID: "C", segID: "LX", err: schema.ErrNotUsed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, errs := ruleSet.Parse(transaction(t, tt.input))
+			if len(errs) > 0 {
+				t.Fatalf("Parse() = %v", errs)
+			}
+			errs = root.CheckRules()
+			if len(errs) != len(tt.want) {
+				t.Fatalf("CheckRules() = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, err := range errs {
+				w := tt.want[i]
+				var serr *schema.Error
+				if !errors.As(err, &serr) {
+					t.Fatalf("error %d = %v, want *schema.Error", i, err)
+				}
+				if serr.Position != w.pos || serr.LoopID != w.loopID || serr.SegmentID != w.segID || serr.Element != w.element || !errors.Is(err, w.err) {
+					t.Errorf("error %d = %v (%+v), want %+v", i, err, serr, w)
+				}
+				if serr.Rule == nil {
+					t.Errorf("error %d has no Rule", i)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRulesUnknownCondition(t *testing.T) {
+	ts := &schema.TransactionSet{
+		ID: "999",
+		Loop: &schema.Loop{Children: []schema.Node{
+			&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+			&schema.Segment{ID: "PER", Usage: schema.Situational, Rule: &schema.Rule{
+				Text:      "Required by trading partner agreement.",
+				Condition: "test.unregistered",
+			}},
+		}},
+	}
+	root, errs := ts.Parse(transaction(t, `ST*999*1~BHT*1~PER*IC~SE*4*1~`))
+	if len(errs) > 0 {
+		t.Fatalf("Parse() = %v", errs)
+	}
+	errs = root.CheckRules()
+	var serr *schema.Error
+	if len(errs) != 1 || !errors.Is(errs[0], schema.ErrUnknownCondition) || !errors.As(errs[0], &serr) || serr.SegmentID != "PER" {
+		t.Errorf("CheckRules() = %v, want unknown condition on PER", errs)
+	}
+}
+
+func TestRegisterConditionDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterCondition did not panic on a duplicate name")
+		}
+	}()
+	schema.RegisterCondition("test.has-hi", func(*schema.Context) bool { return true })
+}
+
+func TestConditions(t *testing.T) {
+	names := schema.Conditions()
+	found := false
+	for _, name := range names {
+		if name == "test.has-hi" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Conditions() = %v, want test.has-hi among them", names)
+	}
+}
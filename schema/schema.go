@@ -0,0 +1,310 @@
+// Package schema describes X12 transaction sets the way implementation
+// guides define them, and matches transactions against those
+// descriptions.
+//
+// A TransactionSet is a tree of Loops. Each loop holds, in order, the
+// Segments and nested Loops it may contain; its first child is the
+// trigger segment that begins each occurrence of the loop. Loops,
+// segments, and elements carry the guide's usage designator (Required,
+// Situational, or NotUsed), and situational ones may carry a Rule: the
+// guide's "Required when ..." note, paired with a named Condition that
+// decides it.
+//
+// TransactionSet.Parse arranges a transaction's flat segment list into
+// a tree of LoopNodes. LoopNode.CheckUsage checks that tree against the
+// guide's usage designators, repeat counts, and code lists, and
+// LoopNode.CheckRules evaluates the situational rules.
+//
+// An Overlay narrows a base guide the way a payer's companion guide
+// does, and is kept per trading partner in a JSON file read with
+// ReadOverlay. Overlay.Apply returns the narrowed copy of the base
+// guide.
+//
+// Subpackage importer reads guides from pyx12 maps, and subpackage
+// jsonschema describes a guide's transactions as a JSON Schema for
+// their JSON encoding.
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tmc/x12"
+)
+
+// Usage is an implementation guide's usage designator for a loop,
+// segment, or element.
+type Usage byte
+
+// Usage designators.
+const (
+	Required    Usage = 'R'
+	Situational Usage = 'S'
+	NotUsed     Usage = 'N'
+)
+
+func (u Usage) String() string {
+	switch u {
+	case Required:
+		return "Required"
+	case Situational:
+		return "Situational"
+	case NotUsed:
+		return "Not Used"
+	}
+	return fmt.Sprintf("Usage(%q)", byte(u))
+}
+
+// MarshalText encodes u as its one-letter designator.
+func (u Usage) MarshalText() ([]byte, error) {
+	switch u {
+	case Required, Situational, NotUsed:
+		return []byte{byte(u)}, nil
+	}
+	return nil, fmt.Errorf("%w: usage %q", x12.ErrInvalidArgument, byte(u))
+}
+
+// UnmarshalText decodes a one-letter designator: "R", "S", or "N".
+func (u *Usage) UnmarshalText(text []byte) error {
+	if len(text) == 1 {
+		switch v := Usage(text[0]); v {
+		case Required, Situational, NotUsed:
+			*u = v
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: usage %q", x12.ErrInvalidArgument, text)
+}
+
+// A TransactionSet describes one implementation of a transaction set,
+// such as the 837 Professional claim (005010X222A1).
+type TransactionSet struct {
+	ID      string // transaction set identifier code, ST01, e.g. "837"
+	Version string // implementation convention reference, ST03, e.g. "005010X222A1"
+	Name    string // e.g. "Health Care Claim: Professional"
+
+	// Loop is the transaction set's top level: the header segments and
+	// loops that follow ST, through the summary, excluding ST and SE.
+	// Its ID is empty.
+	Loop *Loop
+}
+
+// A Node is an entry in a loop's contents: a *Segment or a *Loop.
+type Node interface {
+	node()
+}
+
+// A Loop is a repeatable group of segments and nested loops.
+type Loop struct {
+	ID    string // e.g. "2010AA"
+	Name  string // e.g. "Billing Provider Name"
+	Usage Usage
+	Max   int // maximum occurrences; 0 means unbounded
+
+	// Children are the loop's segments and nested loops in the order
+	// they must appear. The first child is the trigger segment.
+	Children []Node
+
+	// Rule is the loop's situational rule, if any.
+	Rule *Rule
+}
+
+func (*Loop) node() {}
+
+// Trigger returns the segment that begins each occurrence of the loop,
+// or nil if the loop has none (as for a transaction set's top level).
+func (l *Loop) Trigger() *Segment {
+	if len(l.Children) == 0 {
+		return nil
+	}
+	s, _ := l.Children[0].(*Segment)
+	return s
+}
+
+// Loop returns the first loop, searching l's descendants depth-first,
+// whose ID is id, or nil if there is none.
+func (l *Loop) Loop(id string) *Loop {
+	for _, c := range l.Children {
+		if c, ok := c.(*Loop); ok {
+			if c.ID == id {
+				return c
+			}
+			if found := c.Loop(id); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// A Segment is a segment's use at one position within a loop.
+type Segment struct {
+	ID    string // segment ID, e.g. "NM1"
+	Name  string // the guide's name for this use, e.g. "Billing Provider Name"
+	Usage Usage
+	Max   int // maximum uses at this position; 0 means unbounded
+
+	// Elements holds the guide's detail for the segment's elements,
+	// indexed by element position - 1. It may be shorter than the
+	// segment, and entries may be nil, where the guide adds nothing to
+	// the base definition.
+	Elements []*Element
+
+	// Rule is the segment's situational rule, if any.
+	Rule *Rule
+}
+
+func (*Segment) node() {}
+
+// Element returns the guide's detail for the element at the 1-based
+// position pos, or nil if there is none.
+func (s *Segment) Element(pos int) *Element {
+	if pos < 1 || pos > len(s.Elements) {
+		return nil
+	}
+	return s.Elements[pos-1]
+}
+
+// Matches reports whether seg can occupy this position: its ID must
+// match and, if the guide restricts the segment's qualifying element to
+// a list of codes, the qualifier must be among them. The qualifying
+// element is HL03 for HL segments and the first element otherwise; a
+// composite qualifier is compared by its first component.
+func (s *Segment) Matches(seg x12.Segment) bool {
+	if seg.ID != s.ID {
+		return false
+	}
+	e := s.Qualifier()
+	if e == nil || len(e.Codes) == 0 {
+		return true
+	}
+	return e.HasCode(value(seg, qualifierPos(s.ID)))
+}
+
+// Qualifier returns the guide's detail for the segment's qualifying
+// element, HL03 for HL segments and the first element otherwise, or nil
+// if there is none.
+func (s *Segment) Qualifier() *Element {
+	return s.Element(qualifierPos(s.ID))
+}
+
+// qualifierPos returns the position of the element that distinguishes
+// uses of the segment id.
+func qualifierPos(id string) int {
+	if id == "HL" {
+		return 3
+	}
+	return 1
+}
+
+// An Element is the guide's detail for one element of a segment.
+type Element struct {
+	Name  string
+	Usage Usage
+
+	// Type is the element's X12 data type, such as "AN", "ID", "N2",
+	// "R", "DT", or "TM", or empty if the guide does not give it.
+	Type string
+
+	// Codes lists the code values the guide allows, or nil if it does
+	// not restrict them.
+	Codes []string
+
+	// MinLength and MaxLength bound the length of a simple element's
+	// value; zero means the base standard's bound applies.
+	MinLength int
+	MaxLength int
+
+	// CodeSet names the external code list the element's values are
+	// drawn from, such as "ICD-10-CM" or "CPT", or is empty. Component
+	// is the 1-based component of a composite element that holds the
+	// code, or 0 for a simple element.
+	CodeSet   string
+	Component int
+
+	// Rule is the element's situational rule, if any.
+	Rule *Rule
+}
+
+// HasCode reports whether v is among the element's codes. A composite
+// value (one whose code is followed by a component separator) is
+// compared by its first component.
+func (e *Element) HasCode(v string) bool {
+	for _, c := range e.Codes {
+		if v == c || strings.HasPrefix(v, c) && !isAlnum(v[len(c)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// An Error describes a transaction that does not conform to its
+// transaction set's schema. It wraps one of the package's sentinel
+// errors.
+type Error struct {
+	// Position is the 1-based position of the offending segment within
+	// the transaction set, counting ST as 1. For a missing segment or
+	// loop, it is the position of the first segment of the loop that
+	// should contain it.
+	Position  int
+	SegmentID string
+	LoopID    string // the loop that contains, or should contain, the segment
+	Element   int    // 1-based element position, or 0
+	Rule      *Rule  // the situational rule violated, if any
+	Err       error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("schema: ")
+	if e.LoopID != "" {
+		fmt.Fprintf(&b, "loop %s ", e.LoopID)
+	}
+	fmt.Fprintf(&b, "segment %d", e.Position)
+	if e.SegmentID != "" {
+		fmt.Fprintf(&b, " (%s)", e.SegmentID)
+	}
+	if e.Element > 0 {
+		fmt.Fprintf(&b, " element %d", e.Element)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	if e.Rule != nil && e.Rule.Text != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Rule.Text)
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// value returns the value of the element at the 1-based position pos,
+// or "" if seg has no such element.
+func value(seg x12.Segment, pos int) string {
+	if pos < 1 || pos > len(seg.Elements) {
+		return ""
+	}
+	return seg.Elements[pos-1].Value
+}
+
+// present reports whether seg carries a non-empty element at the
+// 1-based position pos.
+func present(seg x12.Segment, pos int) bool {
+	if pos < 1 || pos > len(seg.Elements) {
+		return false
+	}
+	e := seg.Elements[pos-1]
+	if e.Value != "" {
+		return true
+	}
+	for _, c := range e.Components {
+		if c != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isAlnum(b byte) bool {
+	return 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9'
+}
@@ -0,0 +1,166 @@
+package schema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+// testSet is a small transaction set: a header segment, then repeating
+// "A" loops (triggered by HL*..*A) each holding an NM1*X name loop and
+// repeating "B" loops (triggered by LX).
+var testSet = &schema.TransactionSet{
+	ID: "999",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+		&schema.Loop{ID: "A", Usage: schema.Required, Children: []schema.Node{
+			&schema.Segment{ID: "HL", Usage: schema.Required, Max: 1, Elements: []*schema.Element{nil, nil, {Codes: []string{"A"}}}},
+			&schema.Segment{ID: "REF", Usage: schema.Situational, Max: 2, Elements: []*schema.Element{{Codes: []string{"EI"}}}},
+			&schema.Segment{ID: "REF", Usage: schema.Situational, Max: 1, Elements: []*schema.Element{{Codes: []string{"G2"}}}},
+			&schema.Loop{ID: "AN", Usage: schema.Required, Max: 1, Children: []schema.Node{
+				&schema.Segment{ID: "NM1", Usage: schema.Required, Max: 1, Elements: []*schema.Element{{Codes: []string{"X"}}}},
+				&schema.Segment{ID: "N3", Usage: schema.Situational, Max: 1},
+			}},
+			&schema.Loop{ID: "B", Usage: schema.Situational, Children: []schema.Node{
+				&schema.Segment{ID: "LX", Usage: schema.Required, Max: 1},
+				&schema.Segment{ID: "HI", Usage: schema.Situational, Max: 1, Elements: []*schema.Element{{Codes: []string{"BK"}}}},
+			}},
+		}},
+	}},
+}
+
+func transaction(t *testing.T, segments string) *x12.Transaction {
+	t.Helper()
+	doc, err := x12.Decode(strings.NewReader(segments))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc.Interchange.FunctionGroups[0].Transactions[0]
+}
+
+// shape renders the loop tree as loop IDs and segment positions.
+func shape(n *schema.LoopNode) string {
+	var b strings.Builder
+	b.WriteString(n.ID() + "(")
+	for i, s := range n.Segments {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(s.Segment.ID)
+	}
+	for _, c := range n.Children {
+		b.WriteString(" " + shape(c))
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantUnexp []int // positions of unexpected segments
+	}{
+		{
+			name:  "nested and repeated loops",
+			input: `ST*999*1~BHT*1~HL*1**A~REF*EI*1~REF*EI*2~REF*G2*3~NM1*X~N3*1 MAIN~LX*1~HI*BK:123~LX*2~HL*2**A~NM1*X~SE*14*1~`,
+			want:  "(BHT A(HL REF REF REF AN(NM1 N3) B(LX HI) B(LX)) A(HL AN(NM1)))",
+		},
+		{
+			name:      "qualifier not allowed",
+			input:     `ST*999*1~BHT*1~HL*1**A~REF*ZZ*1~NM1*X~SE*6*1~`,
+			want:      "(BHT A(HL REF AN(NM1)))",
+			wantUnexp: []int{4},
+		},
+		{
+			name:      "segment out of order",
+			input:     `ST*999*1~BHT*1~HL*1**A~REF*G2*1~REF*EI*1~NM1*X~SE*7*1~`,
+			want:      "(BHT A(HL REF REF AN(NM1)))",
+			wantUnexp: []int{5},
+		},
+		{
+			name:      "unknown trigger",
+			input:     `ST*999*1~BHT*1~HL*1**Z~NM1*X~SE*5*1~`,
+			want:      "(BHT HL NM1)",
+			wantUnexp: []int{3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, errs := testSet.Parse(transaction(t, tt.input))
+			if diff := cmp.Diff(tt.want, shape(root)); diff != "" {
+				t.Errorf("Parse() tree mismatch (-want +got):\n%s", diff)
+			}
+			var got []int
+			for _, err := range errs {
+				var serr *schema.Error
+				if !errors.As(err, &serr) || !errors.Is(err, schema.ErrUnexpectedSegment) {
+					t.Fatalf("Parse() error = %v, want unexpected segment", err)
+				}
+				got = append(got, serr.Position)
+			}
+			if diff := cmp.Diff(tt.wantUnexp, got); diff != "" {
+				t.Errorf("unexpected segment positions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseWrongTransactionSet(t *testing.T) {
+	_, errs := testSet.Parse(transaction(t, `ST*837*1~BHT*1~SE*3*1~`))
+	if len(errs) != 1 || !errors.Is(errs[0], x12.ErrInvalidArgument) {
+		t.Errorf("Parse() = %v, want ErrInvalidArgument", errs)
+	}
+}
+
+func TestLoopNodeNavigation(t *testing.T) {
+	root, _ := testSet.Parse(transaction(t, `ST*999*1~BHT*1~HL*1**A~REF*EI*1~NM1*X*Y~LX*1~SE*7*1~`))
+	a := root.Loops("A")
+	if len(a) != 1 {
+		t.Fatalf("Loops(A) = %d occurrences, want 1", len(a))
+	}
+	if got := a[0].Value("REF02"); got != "1" {
+		t.Errorf(`Value("REF02") = %q, want "1"`, got)
+	}
+	if got := a[0].Value("REF09"); got != "" {
+		t.Errorf(`Value("REF09") = %q, want ""`, got)
+	}
+	if got := a[0].Position(); got != 3 {
+		t.Errorf("Position() = %d, want 3", got)
+	}
+	b := a[0].Loops("B")[0]
+	if got := b.Ancestor("A"); got != a[0] {
+		t.Errorf("Ancestor(A) = %v, want the A loop", got)
+	}
+	if got := b.Ancestor("B"); got != b {
+		t.Errorf("Ancestor(B) = %v, want itself", got)
+	}
+	if got := testSet.Loop.Loop("AN"); got == nil || got.Trigger().ID != "NM1" {
+		t.Errorf(`Loop("AN") = %v, want the AN loop`, got)
+	}
+}
+
+func TestSegmentMatchesComposite(t *testing.T) {
+	hi := &schema.Segment{ID: "HI", Elements: []*schema.Element{{Codes: []string{"BK", "ABK"}}}}
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"BK", true},
+		{"BK:4779", true},
+		{"ABK>J020", true},
+		{"BKX:4779", false},
+		{"BF:4779", false},
+	}
+	for _, tt := range tests {
+		seg := x12.Segment{ID: "HI", Elements: []x12.Element{{Value: tt.value}}}
+		if got := hi.Matches(seg); got != tt.want {
+			t.Errorf("Matches(HI*%s) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
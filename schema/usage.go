@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tmc/x12"
+)
+
+// Sentinel errors wrapped by the *Error values CheckUsage reports.
+var (
+	// ErrTooManyRepeats reports a loop or segment that occurs more
+	// often than the guide's maximum.
+	ErrTooManyRepeats = errors.New("too many repeats")
+	// ErrInvalidCode reports an element value that is not among the
+	// codes the guide allows.
+	ErrInvalidCode = errors.New("invalid code")
+	// ErrInvalidLength reports an element value shorter or longer than
+	// the guide allows.
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+// CheckUsage checks n and its nested loop occurrences against the
+// guide's usage designators and repeat counts: Required loops,
+// segments, and elements must be present, NotUsed ones absent, and no
+// loop or segment may repeat more than its maximum. Elements with a
+// code list must carry one of its codes, and elements with length
+// bounds must respect them. Violations are reported as *Error values
+// wrapping ErrMissingSegment, x12.ErrMissingElement, ErrNotUsed,
+// ErrTooManyRepeats, ErrInvalidCode, or ErrInvalidLength.
+//
+// Situational nodes are not checked; CheckRules evaluates their rules.
+func (n *LoopNode) CheckUsage() []error {
+	var errs []error
+	n.Walk(func(n *LoopNode) {
+		errs = append(errs, n.checkUsage()...)
+	})
+	return errs
+}
+
+// checkUsage checks n's own contents.
+func (n *LoopNode) checkUsage() []error {
+	var errs []error
+	for _, c := range n.Loop.Children {
+		switch c := c.(type) {
+		case *Loop:
+			occurs := n.Loops(c.ID)
+			e := &Error{Position: n.Position(), LoopID: c.ID}
+			if t := c.Trigger(); t != nil {
+				e.SegmentID = t.ID
+			}
+			switch {
+			case len(occurs) == 0 && c.Usage == Required:
+				e.Err = ErrMissingSegment
+			case len(occurs) > 0 && c.Usage == NotUsed:
+				e.Position, e.Err = occurs[0].Position(), ErrNotUsed
+			case c.Max > 0 && len(occurs) > c.Max:
+				e.Position = occurs[c.Max].Position()
+				e.Err = fmt.Errorf("%w: loop occurs %d times, maximum %d", ErrTooManyRepeats, len(occurs), c.Max)
+			default:
+				continue
+			}
+			errs = append(errs, e)
+		case *Segment:
+			var occurs []*SegmentNode
+			for _, s := range n.Segments {
+				if s.Schema == c {
+					occurs = append(occurs, s)
+				}
+			}
+			e := &Error{Position: n.Position(), SegmentID: c.ID, LoopID: n.Loop.ID}
+			switch {
+			case len(occurs) == 0 && c.Usage == Required:
+				e.Err = ErrMissingSegment
+			case len(occurs) > 0 && c.Usage == NotUsed:
+				e.Position, e.Err = occurs[0].Position, ErrNotUsed
+			case c.Max > 0 && len(occurs) > c.Max:
+				e.Position = occurs[c.Max].Position
+				e.Err = fmt.Errorf("%w: segment occurs %d times, maximum %d", ErrTooManyRepeats, len(occurs), c.Max)
+			default:
+				e = nil
+			}
+			if e != nil {
+				errs = append(errs, e)
+			}
+			for _, s := range occurs {
+				errs = append(errs, checkElements(c, s, n.Loop.ID)...)
+			}
+		}
+	}
+	return errs
+}
+
+// checkElements checks the elements of s against the guide's detail
+// for them.
+func checkElements(c *Segment, s *SegmentNode, loopID string) []error {
+	var errs []error
+	for i, el := range c.Elements {
+		if el == nil {
+			continue
+		}
+		pos := i + 1
+		e := &Error{Position: s.Position, SegmentID: c.ID, LoopID: loopID, Element: pos}
+		isPresent := present(*s.Segment, pos)
+		switch {
+		case !isPresent && el.Usage == Required:
+			e.Err = x12.ErrMissingElement
+		case isPresent && el.Usage == NotUsed:
+			e.Err = ErrNotUsed
+		case isPresent && len(el.Codes) > 0 && !el.HasCode(value(*s.Segment, pos)):
+			e.Err = fmt.Errorf("%w %q", ErrInvalidCode, value(*s.Segment, pos))
+		case isPresent && el.MinLength > 0 && len(value(*s.Segment, pos)) < el.MinLength:
+			e.Err = fmt.Errorf("%w: %d characters, minimum %d", ErrInvalidLength, len(value(*s.Segment, pos)), el.MinLength)
+		case isPresent && el.MaxLength > 0 && len(value(*s.Segment, pos)) > el.MaxLength:
+			e.Err = fmt.Errorf("%w: %d characters, maximum %d", ErrInvalidLength, len(value(*s.Segment, pos)), el.MaxLength)
+		default:
+			continue
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
@@ -0,0 +1,94 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+// usageSet exercises the usage designators and repeat counts: the A
+// loop is required and may occur twice, REF is not used, DTP may occur
+// once, and NM1 requires NM103 and restricts NM102 to 1 or 2.
+var usageSet = &schema.TransactionSet{
+	ID: "999",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+		&schema.Loop{ID: "A", Usage: schema.Required, Max: 2, Children: []schema.Node{
+			&schema.Segment{ID: "NM1", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+				nil,
+				{Usage: schema.Required, Codes: []string{"1", "2"}},
+				{Usage: schema.Required},
+				{Usage: schema.NotUsed},
+			}},
+			&schema.Segment{ID: "REF", Usage: schema.NotUsed, Max: 1},
+			&schema.Segment{ID: "DTP", Usage: schema.Situational, Max: 1},
+		}},
+	}},
+}
+
+func TestCheckUsage(t *testing.T) {
+	type violation struct {
+		pos     int
+		segID   string
+		element int
+		err     error
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []violation
+	}{
+		{
+			name:  "conforming",
+			input: `ST*999*1~BHT*1~NM1*41*1*SMITH~DTP*472~NM1*40*2*ACME~SE*6*1~`,
+		},
+		{
+			name:  "missing loop",
+			input: `ST*999*1~BHT*1~SE*3*1~`,
+			want:  []violation{{1, "NM1", 0, schema.ErrMissingSegment}},
+		},
+		{
+			name:  "too many loops",
+			input: `ST*999*1~BHT*1~NM1*41*1*A~NM1*41*1*B~NM1*41*1*C~SE*6*1~`,
+			want:  []violation{{5, "NM1", 0, schema.ErrTooManyRepeats}},
+		},
+		{
+			name:  "segments",
+			input: `ST*999*1~BHT*1~NM1*41*1*A~REF*EI*1~DTP*472~DTP*473~SE*7*1~`,
+			want: []violation{
+				{4, "REF", 0, schema.ErrNotUsed},
+				{6, "DTP", 0, schema.ErrTooManyRepeats},
+			},
+		},
+		{
+			name:  "elements",
+			input: `ST*999*1~BHT*1~NM1*41*3**X~SE*4*1~`,
+			want: []violation{
+				{3, "NM1", 2, schema.ErrInvalidCode},
+				{3, "NM1", 3, x12.ErrMissingElement},
+				{3, "NM1", 4, schema.ErrNotUsed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, errs := usageSet.Parse(transaction(t, tt.input))
+			if len(errs) > 0 {
+				t.Fatalf("Parse() = %v", errs)
+			}
+			errs = root.CheckUsage()
+			if len(errs) != len(tt.want) {
+				t.Fatalf("CheckUsage() = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, err := range errs {
+				w := tt.want[i]
+				var serr *schema.Error
+				if !errors.As(err, &serr) || serr.Position != w.pos || serr.SegmentID != w.segID || serr.Element != w.element || !errors.Is(err, w.err) {
+					t.Errorf("error %d = %v, want %+v", i, err, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package segments
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+// A member is a tagged field of a loop struct.
+type member struct {
+	index  int
+	id     string   // segment or loop ID
+	loop   bool     // id names a nested loop
+	codes  []string // qualifier codes selecting uses of the segment, or other IDs of the loop
+	kind   reflect.Kind
+	struc  reflect.Type // the segment or loop struct type
+	rawSeg bool         // struc is x12.Segment
+}
+
+var (
+	memberCache sync.Map // reflect.Type -> []member
+	segmentType = reflect.TypeOf(x12.Segment{})
+)
+
+// members returns the tagged fields of the loop struct type t.
+func members(t reflect.Type) ([]member, error) {
+	if ms, ok := memberCache.Load(t); ok {
+		return ms.([]member), nil
+	}
+	var ms []member
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("x12")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		m := member{index: i, id: parts[0], codes: parts[1:], kind: sf.Type.Kind()}
+		if m.id == "" {
+			return nil, fmt.Errorf("%w: segments: field %s has invalid tag %q", x12.ErrInvalidArgument, sf.Name, tag)
+		}
+		m.loop = m.id[0] >= '0' && m.id[0] <= '9'
+		switch m.kind {
+		case reflect.Struct:
+			m.struc = sf.Type
+		case reflect.Pointer, reflect.Slice:
+			m.struc = sf.Type.Elem()
+		}
+		if m.struc == nil || m.struc.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w: segments: field %s of type %s cannot hold %s", x12.ErrInvalidArgument, sf.Name, sf.Type, m.id)
+		}
+		m.rawSeg = m.struc == segmentType
+		ms = append(ms, m)
+	}
+	memberCache.Store(t, ms)
+	return ms, nil
+}
+
+// matches reports whether m holds seg: whether the IDs agree and, if m
+// lists qualifier codes, the segment's qualifier (HL03 for HL, the
+// first element otherwise, or the first component of a composite) is
+// one of them.
+func (m *member) matches(seg *x12.Segment) bool {
+	if m.loop || seg.ID != m.id {
+		return false
+	}
+	if len(m.codes) == 0 {
+		return true
+	}
+	pos := 1
+	if seg.ID == "HL" {
+		pos = 3
+	}
+	var q string
+	if pos <= len(seg.Elements) {
+		q = seg.Elements[pos-1].Value
+	}
+	return (&schema.Element{Codes: m.codes}).HasCode(q)
+}
+
+// holdsLoop reports whether m holds occurrences of the loop id.
+func (m *member) holdsLoop(id string) bool {
+	if !m.loop {
+		return false
+	}
+	if m.id == id {
+		return true
+	}
+	for _, c := range m.codes {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalLoop stores the segments and nested loops of the loop
+// occurrence n in the loop struct pointed to by v. Each segment goes to
+// the first field, in field order, that selects it and is not already
+// filled, and each nested loop likewise to a field tagged with its ID.
+// A segment or loop left without a field, and a segment that n's schema
+// did not place, are reported as errors wrapping x12.ErrInvalidFormat.
+func UnmarshalLoop(n *schema.LoopNode, v any, d Delimiters) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: segments: UnmarshalLoop of %T, want pointer to struct", x12.ErrInvalidArgument, v)
+	}
+	return unmarshalLoop(n, rv.Elem(), d)
+}
+
+func unmarshalLoop(n *schema.LoopNode, lv reflect.Value, d Delimiters) error {
+	ms, err := members(lv.Type())
+	if err != nil {
+		return err
+	}
+	filled := make(map[int]bool)
+	for _, sn := range n.Segments {
+		if sn.Schema == nil {
+			return fmt.Errorf("%w: segments: segment %s at position %d is not part of loop %s", x12.ErrInvalidFormat, sn.Segment.ID, sn.Position, n.ID())
+		}
+		m := findMember(ms, filled, func(m *member) bool { return m.matches(sn.Segment) })
+		if m == nil {
+			return fmt.Errorf("%w: segments: %s has no field for segment %s at position %d", x12.ErrInvalidFormat, lv.Type(), sn.Segment.ID, sn.Position)
+		}
+		dst := slot(lv, m, filled)
+		if m.rawSeg {
+			dst.Set(reflect.ValueOf(*sn.Segment))
+			continue
+		}
+		if err := Unmarshal(*sn.Segment, dst.Addr().Interface(), d); err != nil {
+			return fmt.Errorf("%w at position %d", err, sn.Position)
+		}
+	}
+	for _, c := range n.Children {
+		m := findMember(ms, filled, func(m *member) bool { return m.holdsLoop(c.ID()) })
+		if m == nil {
+			return fmt.Errorf("%w: segments: %s has no field for loop %s at position %d", x12.ErrInvalidFormat, lv.Type(), c.ID(), c.Position())
+		}
+		dst := slot(lv, m, filled)
+		if err := unmarshalLoop(c, dst, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findMember returns the first member that match selects and that can
+// take another occurrence.
+func findMember(ms []member, filled map[int]bool, match func(*member) bool) *member {
+	for i := range ms {
+		if !filled[ms[i].index] && match(&ms[i]) {
+			return &ms[i]
+		}
+	}
+	return nil
+}
+
+// slot returns the struct value of m's field in lv that the next
+// occurrence is to be stored in, marking a field that holds one
+// occurrence as filled.
+func slot(lv reflect.Value, m *member, filled map[int]bool) reflect.Value {
+	fv := lv.Field(m.index)
+	switch m.kind {
+	case reflect.Slice:
+		fv.Set(reflect.Append(fv, reflect.Zero(m.struc)))
+		return fv.Index(fv.Len() - 1)
+	case reflect.Pointer:
+		filled[m.index] = true
+		fv.Set(reflect.New(m.struc))
+		return fv.Elem()
+	}
+	filled[m.index] = true
+	return fv
+}
+
+// MarshalLoop returns the segments of the loop struct v, or of the
+// struct v points to, in field order: for each field, the segment or
+// loop it holds, if it is not the zero value, or each of the segments or
+// loops of a slice.
+func MarshalLoop(v any, d Delimiters) ([]x12.Segment, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: segments: MarshalLoop of %T, want struct", x12.ErrInvalidArgument, v)
+	}
+	return marshalLoop(nil, rv, d)
+}
+
+func marshalLoop(segs []x12.Segment, lv reflect.Value, d Delimiters) ([]x12.Segment, error) {
+	ms, err := members(lv.Type())
+	if err != nil {
+		return nil, err
+	}
+	for i := range ms {
+		m := &ms[i]
+		fv := lv.Field(m.index)
+		var items []reflect.Value
+		switch m.kind {
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				items = append(items, fv.Index(j))
+			}
+		case reflect.Pointer:
+			if !fv.IsNil() {
+				items = append(items, fv.Elem())
+			}
+		default:
+			if !fv.IsZero() {
+				items = append(items, fv)
+			}
+		}
+		for _, item := range items {
+			switch {
+			case m.loop:
+				if segs, err = marshalLoop(segs, item, d); err != nil {
+					return nil, err
+				}
+			case m.rawSeg:
+				seg := item.Interface().(x12.Segment)
+				if seg.ID != m.id {
+					return nil, fmt.Errorf("%w: segments: field %s holds segment %q, want %s", x12.ErrInvalidArgument, lv.Type().Field(m.index).Name, seg.ID, m.id)
+				}
+				segs = append(segs, seg)
+			default:
+				seg, err := Marshal(m.id, item.Interface(), d)
+				if err != nil {
+					return nil, err
+				}
+				segs = append(segs, seg)
+			}
+		}
+	}
+	return segs, nil
+}
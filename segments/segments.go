@@ -0,0 +1,423 @@
+// Package segments maps X12 segments and loops to Go structs.
+//
+// A segment struct describes one segment's elements with field tags
+// giving their 1-based positions:
+//
+//	type N4 struct {
+//		City       string `x12:"1"`
+//		State      string `x12:"2"`
+//		PostalCode string `x12:"3"`
+//	}
+//
+// An element field is a string holding the element's value, a []string
+// or struct holding the components of a composite (a struct's fields
+// are tagged with component positions), or, with the "rep" option, a
+// slice of either holding the repetitions of a repeating element. A
+// field tagged with a range of positions, such as "1-12", is a slice
+// whose items occupy successive positions; with the "group" option its
+// items are structs spanning several positions each, tagged relative to
+// the start of the item, as in the CAS segment's adjustment triples.
+//
+// A loop struct describes one occurrence of an implementation-guide
+// loop. Its fields are tagged with the segment ID, optionally followed
+// by the qualifier codes that select particular uses of the segment
+// (`x12:"REF,EI,SY"`), or with the ID of a nested loop (`x12:"2010AA"`).
+// A loop struct shared by loops a guide numbers separately, such as the
+// subscriber and dependent occurrences of the same loop, is tagged with
+// all of their IDs (`x12:"2115C,2115D"`).
+// A field is a struct for a segment or loop that occurs once, a pointer
+// for one that may be absent, or a slice for one that repeats. A field
+// of type x12.Segment holds a segment whose elements are not mapped.
+// UnmarshalLoop fills a loop struct from a loop occurrence arranged by
+// schema.TransactionSet.Parse, and MarshalLoop writes its segments back
+// in field order, which must follow the guide's order.
+//
+// Conversions are lossless: unmarshaling reports an error wrapping
+// x12.ErrInvalidFormat for any element, component, or segment the
+// structs have no field for, instead of dropping it.
+package segments
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tmc/x12"
+)
+
+// Delimiters are the separators used within elements: the component
+// element separator (ISA16) and the repetition separator (ISA11).
+type Delimiters struct {
+	Component  string
+	Repetition string // empty if the interchange does not use repetition
+}
+
+// DefaultDelimiters are the delimiters most 005010 interchanges use.
+var DefaultDelimiters = Delimiters{Component: x12.DefaultComponentSeparator, Repetition: "^"}
+
+// DelimitersOf returns the delimiters declared by doc's interchange
+// header, using DefaultDelimiters for any it does not declare. An ISA11
+// of "U", the standards identifier of versions before 4020, declares no
+// repetition separator.
+func DelimitersOf(doc *x12.Document) Delimiters {
+	d := DefaultDelimiters
+	if doc == nil || doc.Interchange == nil || doc.Interchange.Header == nil {
+		return d
+	}
+	isa := doc.Interchange.Header
+	if c := strings.TrimSpace(isa.ComponentElementSeparator); c != "" {
+		d.Component = c
+	}
+	switch r := strings.TrimSpace(isa.RepetitionSeparator); r {
+	case "":
+	case "U":
+		d.Repetition = ""
+	default:
+		d.Repetition = r
+	}
+	return d
+}
+
+// Unmarshal stores the elements of seg in the segment struct pointed to
+// by v.
+func Unmarshal(seg x12.Segment, v any, d Delimiters) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: segments: Unmarshal of %T, want pointer to struct", x12.ErrInvalidArgument, v)
+	}
+	values := make([]string, len(seg.Elements))
+	for i, e := range seg.Elements {
+		values[i] = elementValue(e, d)
+	}
+	return decodeFields(rv.Elem(), values, seg.ID, d)
+}
+
+// Marshal returns the segment with the given ID holding the elements of
+// the segment struct v, or of the struct v points to. Trailing empty
+// elements are omitted.
+func Marshal(id string, v any, d Delimiters) (x12.Segment, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return x12.Segment{}, fmt.Errorf("%w: segments: Marshal of %T, want struct", x12.ErrInvalidArgument, v)
+	}
+	values, err := encodeFields(rv, id, d)
+	if err != nil {
+		return x12.Segment{}, err
+	}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	seg := x12.Segment{ID: id, Elements: make([]x12.Element, len(values))}
+	for i, v := range values {
+		seg.Elements[i] = x12.Element{Value: v}
+	}
+	return seg, nil
+}
+
+// elementValue returns e's value as it appears in an interchange,
+// joining any components the way the encoder does.
+func elementValue(e x12.Element, d Delimiters) string {
+	if e.Components == nil {
+		return e.Value
+	}
+	return strings.Join(append([]string{e.Value}, e.Components...), d.Component)
+}
+
+// A field is a tagged struct field of a segment, composite, or group
+// struct.
+type field struct {
+	index    int
+	pos, end int // 1-based positions; end > pos for a range
+	rep      bool
+	group    bool
+	width    int // positions per item of a group range
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+// fields returns the tagged fields of the struct type t.
+func fields(t reflect.Type) ([]field, error) {
+	if fs, ok := fieldCache.Load(t); ok {
+		return fs.([]field), nil
+	}
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("x12")
+		if !ok {
+			continue
+		}
+		f, err := parseFieldTag(t.Field(i), tag)
+		if err != nil {
+			return nil, err
+		}
+		f.index = i
+		fs = append(fs, f)
+	}
+	fieldCache.Store(t, fs)
+	return fs, nil
+}
+
+func parseFieldTag(sf reflect.StructField, tag string) (field, error) {
+	bad := func() (field, error) {
+		return field{}, fmt.Errorf("%w: segments: field %s has invalid tag %q", x12.ErrInvalidArgument, sf.Name, tag)
+	}
+	opts := strings.Split(tag, ",")
+	var f field
+	lo, hi, isRange := strings.Cut(opts[0], "-")
+	var err error
+	if f.pos, err = strconv.Atoi(lo); err != nil || f.pos < 1 {
+		return bad()
+	}
+	f.end = f.pos
+	if isRange {
+		if f.end, err = strconv.Atoi(hi); err != nil || f.end < f.pos || sf.Type.Kind() != reflect.Slice {
+			return bad()
+		}
+	}
+	for _, o := range opts[1:] {
+		switch o {
+		case "rep":
+			f.rep = true
+		case "group":
+			f.group = true
+		default:
+			return bad()
+		}
+	}
+	if f.group {
+		et := sf.Type.Elem()
+		if !isRange || et.Kind() != reflect.Struct {
+			return bad()
+		}
+		gfs, err := fields(et)
+		if err != nil {
+			return field{}, err
+		}
+		for _, g := range gfs {
+			if g.end > f.width {
+				f.width = g.end
+			}
+		}
+		if f.width == 0 || (f.end-f.pos+1)%f.width != 0 {
+			return bad()
+		}
+	} else if isRange {
+		f.width = 1
+	}
+	if f.rep && (isRange || sf.Type.Kind() != reflect.Slice) {
+		return bad()
+	}
+	return f, nil
+}
+
+// decodeFields stores values, the elements of segment id from its first
+// position on, in the fields of the struct sv.
+func decodeFields(sv reflect.Value, values []string, id string, d Delimiters) error {
+	fs, err := fields(sv.Type())
+	if err != nil {
+		return err
+	}
+	covered := make([]bool, len(values))
+	for _, f := range fs {
+		fv := sv.Field(f.index)
+		if f.width == 0 {
+			if f.pos <= len(values) {
+				covered[f.pos-1] = true
+				if err := decodeElement(fv, values[f.pos-1], f.rep, id, f.pos, d); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		// A range: the items up to the last one holding a value.
+		last := 0
+		for p := f.pos; p <= f.end && p <= len(values); p++ {
+			covered[p-1] = true
+			if values[p-1] != "" {
+				last = p
+			}
+		}
+		if last == 0 {
+			fv.Set(reflect.Zero(fv.Type()))
+			continue
+		}
+		n := (last - f.pos + f.width) / f.width
+		items := reflect.MakeSlice(fv.Type(), n, n)
+		for i := 0; i < n; i++ {
+			start := f.pos + i*f.width
+			if f.group {
+				end := start + f.width - 1
+				if end > len(values) {
+					end = len(values)
+				}
+				if err := decodeFields(items.Index(i), values[start-1:end], id, d); err != nil {
+					return err
+				}
+			} else if err := decodeValue(items.Index(i), values[start-1], id, start, d); err != nil {
+				return err
+			}
+		}
+		fv.Set(items)
+	}
+	for i, c := range covered {
+		if !c && values[i] != "" {
+			return fmt.Errorf("%w: segments: %s%02d has no field in %s", x12.ErrInvalidFormat, id, i+1, sv.Type())
+		}
+	}
+	return nil
+}
+
+// decodeElement stores the value of element pos of segment id in fv.
+func decodeElement(fv reflect.Value, value string, rep bool, id string, pos int, d Delimiters) error {
+	if !rep {
+		return decodeValue(fv, value, id, pos, d)
+	}
+	if value == "" {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	parts := []string{value}
+	if d.Repetition != "" {
+		parts = strings.Split(value, d.Repetition)
+	}
+	items := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+	for i, p := range parts {
+		if err := decodeValue(items.Index(i), p, id, pos, d); err != nil {
+			return err
+		}
+	}
+	fv.Set(items)
+	return nil
+}
+
+// decodeValue stores a simple or composite element value in fv.
+func decodeValue(fv reflect.Value, value, id string, pos int, d Delimiters) error {
+	switch {
+	case fv.Kind() == reflect.String:
+		fv.SetString(value)
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		if value == "" {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+		fv.Set(reflect.ValueOf(strings.Split(value, d.Component)).Convert(fv.Type()))
+	case fv.Kind() == reflect.Struct:
+		var comps []string
+		if value != "" {
+			comps = strings.Split(value, d.Component)
+		}
+		fs, err := fields(fv.Type())
+		if err != nil {
+			return err
+		}
+		covered := make([]bool, len(comps))
+		for _, f := range fs {
+			if f.width != 0 || f.rep || fv.Field(f.index).Kind() != reflect.String {
+				return fmt.Errorf("%w: segments: composite %s has a field that is not a component", x12.ErrInvalidArgument, fv.Type())
+			}
+			if f.pos <= len(comps) {
+				covered[f.pos-1] = true
+				fv.Field(f.index).SetString(comps[f.pos-1])
+			} else {
+				fv.Field(f.index).SetString("")
+			}
+		}
+		for i, c := range covered {
+			if !c && comps[i] != "" {
+				return fmt.Errorf("%w: segments: %s%02d-%d has no field in %s", x12.ErrInvalidFormat, id, pos, i+1, fv.Type())
+			}
+		}
+	default:
+		return fmt.Errorf("%w: segments: cannot store %s%02d in %s", x12.ErrInvalidArgument, id, pos, fv.Type())
+	}
+	return nil
+}
+
+// encodeFields returns the element values of the struct sv.
+func encodeFields(sv reflect.Value, id string, d Delimiters) ([]string, error) {
+	fs, err := fields(sv.Type())
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	set := func(pos int, v string) {
+		for len(values) < pos {
+			values = append(values, "")
+		}
+		values[pos-1] = v
+	}
+	for _, f := range fs {
+		fv := sv.Field(f.index)
+		if f.width == 0 {
+			v, err := encodeElement(fv, f.rep, id, f.pos, d)
+			if err != nil {
+				return nil, err
+			}
+			set(f.pos, v)
+			continue
+		}
+		if max := (f.end - f.pos + 1) / f.width; fv.Len() > max {
+			return nil, fmt.Errorf("%w: segments: %s%02d holds %d items, at most %d fit", x12.ErrInvalidArgument, id, f.pos, fv.Len(), max)
+		}
+		for i := 0; i < fv.Len(); i++ {
+			start := f.pos + i*f.width
+			if f.group {
+				group, err := encodeFields(fv.Index(i), id, d)
+				if err != nil {
+					return nil, err
+				}
+				for j, v := range group {
+					set(start+j, v)
+				}
+				continue
+			}
+			v, err := encodeValue(fv.Index(i), id, start, d)
+			if err != nil {
+				return nil, err
+			}
+			set(start, v)
+		}
+	}
+	return values, nil
+}
+
+// encodeElement returns the value of element pos held in fv.
+func encodeElement(fv reflect.Value, rep bool, id string, pos int, d Delimiters) (string, error) {
+	if !rep {
+		return encodeValue(fv, id, pos, d)
+	}
+	if fv.Len() > 1 && d.Repetition == "" {
+		return "", fmt.Errorf("%w: segments: %s%02d repeats but there is no repetition separator", x12.ErrInvalidArgument, id, pos)
+	}
+	parts := make([]string, fv.Len())
+	for i := range parts {
+		v, err := encodeValue(fv.Index(i), id, pos, d)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = v
+	}
+	return strings.Join(parts, d.Repetition), nil
+}
+
+// encodeValue returns the simple or composite element value held in fv.
+func encodeValue(fv reflect.Value, id string, pos int, d Delimiters) (string, error) {
+	switch {
+	case fv.Kind() == reflect.String:
+		return fv.String(), nil
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		return strings.Join(fv.Convert(reflect.TypeOf([]string(nil))).Interface().([]string), d.Component), nil
+	case fv.Kind() == reflect.Struct:
+		comps, err := encodeFields(fv, id, d)
+		if err != nil {
+			return "", err
+		}
+		for len(comps) > 0 && comps[len(comps)-1] == "" {
+			comps = comps[:len(comps)-1]
+		}
+		return strings.Join(comps, d.Component), nil
+	}
+	return "", fmt.Errorf("%w: segments: cannot encode %s%02d from %s", x12.ErrInvalidArgument, id, pos, fv.Type())
+}
@@ -0,0 +1,233 @@
+package segments_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/segments"
+)
+
+// parseSegment splits a segment written with the default delimiters.
+func parseSegment(s string) x12.Segment {
+	f := strings.Split(s, "*")
+	seg := x12.Segment{ID: f[0]}
+	for _, v := range f[1:] {
+		seg.Elements = append(seg.Elements, x12.Element{Value: v})
+	}
+	return seg
+}
+
+func formatSegment(seg x12.Segment) string {
+	s := seg.ID
+	for _, e := range seg.Elements {
+		s += "*" + e.Value
+	}
+	return s
+}
+
+func TestSegmentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		v    any
+		want any
+	}{
+		{
+			name: "composites",
+			in:   "CLM*26463774*100***11:B:1*Y*A*Y*I",
+			v:    &segments.CLM{},
+			want: &segments.CLM{ClaimID: "26463774", TotalCharge: "100", Location: segments.ServiceLocation{FacilityCode: "11", FacilityCodeQualifier: "B", FrequencyCode: "1"}, ProviderSignature: "Y", AssignmentParticipation: "A", BenefitsAssignment: "Y", ReleaseOfInformation: "I"},
+		},
+		{
+			name: "component list",
+			in:   "SV1*HC:99213:25*100*UN*1***1:2:3",
+			v:    &segments.SV1{},
+			want: &segments.SV1{Procedure: segments.ProcedureIdentifier{Qualifier: "HC", Code: "99213", Modifier1: "25"}, Charge: "100", Unit: "UN", Quantity: "1", DiagnosisPointers: []string{"1", "2", "3"}},
+		},
+		{
+			name: "range",
+			in:   "HI*ABK:J020*ABF:Z1159",
+			v:    &segments.HI{},
+			want: &segments.HI{Codes: []segments.HealthCareCode{{Qualifier: "ABK", Code: "J020"}, {Qualifier: "ABF", Code: "Z1159"}}},
+		},
+		{
+			name: "groups with a gap",
+			in:   "CAS*CO*45*10.5*****22*3*1",
+			v:    &segments.CAS{},
+			want: &segments.CAS{GroupCode: "CO", Adjustments: []segments.Adjustment{{ReasonCode: "45", Amount: "10.5"}, {}, {ReasonCode: "22", Amount: "3", Quantity: "1"}}},
+		},
+		{
+			name: "groups of composites",
+			in:   "PLB*1234567890*20191231*WO:CLAIM1*25*L6*-1.5",
+			v:    &segments.PLB{},
+			want: &segments.PLB{ProviderID: "1234567890", FiscalDate: "20191231", Adjustments: []segments.ProviderAdjustment{
+				{Identifier: segments.AdjustmentIdentifier{ReasonCode: "WO", ReferenceID: "CLAIM1"}, Amount: "25"},
+				{Identifier: segments.AdjustmentIdentifier{ReasonCode: "L6"}, Amount: "-1.5"},
+			}},
+		},
+		{
+			name: "repetition",
+			in:   "DMG*D8*19430501*F**RET:2135-2^RET:2106-3",
+			v:    &segments.DMG{},
+			want: &segments.DMG{FormatQualifier: "D8", BirthDate: "19430501", Gender: "F", RaceOrEthnicity: []string{"RET:2135-2", "RET:2106-3"}},
+		},
+		{
+			name: "short segment",
+			in:   "NM1*87*2",
+			v:    &segments.NM1{},
+			want: &segments.NM1{EntityIdentifierCode: "87", EntityTypeQualifier: "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seg := parseSegment(tt.in)
+			if err := segments.Unmarshal(seg, tt.v, segments.DefaultDelimiters); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, tt.v); diff != "" {
+				t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+			}
+			got, err := segments.Marshal(seg.ID, tt.v, segments.DefaultDelimiters)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			if s := formatSegment(got); s != tt.in {
+				t.Errorf("Marshal() = %q, want %q", s, tt.in)
+			}
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		v    any
+		want error
+	}{
+		{"extra element", "N3*1 MAIN ST*APT 2*X", &segments.N3{}, x12.ErrInvalidFormat},
+		{"extra component", "CLM*1*100***11:B:1:9", &segments.CLM{}, x12.ErrInvalidFormat},
+		{"invalid tag", "LX*1", &struct {
+			A []string `x12:"1-2,group"`
+		}{}, x12.ErrInvalidArgument},
+		{"not a pointer", "LX*1", segments.LX{}, x12.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := segments.Unmarshal(parseSegment(tt.in), tt.v, segments.DefaultDelimiters)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Unmarshal() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Repetitions need a separator to be written.
+	dmg := segments.DMG{RaceOrEthnicity: []string{"A", "B"}}
+	if _, err := segments.Marshal("DMG", dmg, segments.Delimiters{Component: ":"}); !errors.Is(err, x12.ErrInvalidArgument) {
+		t.Errorf("Marshal() = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestDelimitersOf(t *testing.T) {
+	doc := &x12.Document{Interchange: &x12.Interchange{Header: &x12.ISA{ComponentElementSeparator: ">", RepetitionSeparator: "U"}}}
+	if got, want := segments.DelimitersOf(doc), (segments.Delimiters{Component: ">"}); got != want {
+		t.Errorf("DelimitersOf() = %+v, want %+v", got, want)
+	}
+	if got := segments.DelimitersOf(&x12.Document{}); got != segments.DefaultDelimiters {
+		t.Errorf("DelimitersOf(empty) = %+v, want defaults", got)
+	}
+}
+
+var testSet = &schema.TransactionSet{
+	ID: "999",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+		&schema.Loop{ID: "1000", Usage: schema.Required, Max: 0, Children: []schema.Node{
+			&schema.Segment{ID: "NM1", Usage: schema.Required, Max: 1},
+			&schema.Segment{ID: "REF", Usage: schema.Situational, Max: 5},
+			&schema.Segment{ID: "PER", Usage: schema.Situational, Max: 1},
+		}},
+	}},
+}
+
+type testLoop struct {
+	BHT    segments.BHT `x12:"BHT"`
+	Names  []testName   `x12:"1000"`
+	Unused string
+}
+
+type testName struct {
+	Name    segments.NM1   `x12:"NM1"`
+	TaxID   *segments.REF  `x12:"REF,EI"`
+	Others  []segments.REF `x12:"REF"`
+	Contact *x12.Segment   `x12:"PER"`
+}
+
+func TestLoopRoundTrip(t *testing.T) {
+	in := []string{"BHT*0019*00*1", "NM1*41*2*A", "REF*EI*123", "REF*SY*456", "PER*IC*JO", "NM1*40*2*B"}
+	tx := &x12.Transaction{Header: &x12.ST{IDCode: "999"}}
+	for _, s := range in {
+		tx.Segments = append(tx.Segments, parseSegment(s))
+	}
+	root, errs := testSet.Parse(tx)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	var v testLoop
+	if err := segments.UnmarshalLoop(root, &v, segments.DefaultDelimiters); err != nil {
+		t.Fatal(err)
+	}
+	per := parseSegment("PER*IC*JO")
+	want := testLoop{
+		BHT: segments.BHT{StructureCode: "0019", PurposeCode: "00", ReferenceID: "1"},
+		Names: []testName{
+			{Name: segments.NM1{EntityIdentifierCode: "41", EntityTypeQualifier: "2", LastName: "A"}, TaxID: &segments.REF{Qualifier: "EI", ID: "123"}, Others: []segments.REF{{Qualifier: "SY", ID: "456"}}, Contact: &per},
+			{Name: segments.NM1{EntityIdentifierCode: "40", EntityTypeQualifier: "2", LastName: "B"}},
+		},
+	}
+	if diff := cmp.Diff(want, v); diff != "" {
+		t.Errorf("UnmarshalLoop() mismatch (-want +got):\n%s", diff)
+	}
+	segs, err := segments.MarshalLoop(v, segments.DefaultDelimiters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, seg := range segs {
+		got = append(got, formatSegment(seg))
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("MarshalLoop() mismatch (-want +got):\n%s", diff)
+	}
+
+	// A second tax ID goes to the next field taking REF segments, and
+	// is an error without one.
+	tx.Segments = append(tx.Segments[:3:3], append([]x12.Segment{parseSegment("REF*EI*789")}, tx.Segments[3:]...)...)
+	root, _ = testSet.Parse(tx)
+	if err := segments.UnmarshalLoop(root, &testLoop{}, segments.DefaultDelimiters); err != nil {
+		t.Errorf("UnmarshalLoop() = %v", err)
+	}
+	type strict struct {
+		BHT   segments.BHT `x12:"BHT"`
+		Names []struct {
+			Name  segments.NM1  `x12:"NM1"`
+			TaxID *segments.REF `x12:"REF,EI"`
+		} `x12:"1000"`
+	}
+	if err := segments.UnmarshalLoop(root, &strict{}, segments.DefaultDelimiters); !errors.Is(err, x12.ErrInvalidFormat) {
+		t.Errorf("UnmarshalLoop() = %v, want ErrInvalidFormat", err)
+	}
+
+	// A loop field may hold loops of several IDs.
+	var shared struct {
+		BHT   segments.BHT `x12:"BHT"`
+		Names []testName   `x12:"0900,1000"`
+	}
+	if err := segments.UnmarshalLoop(root, &shared, segments.DefaultDelimiters); err != nil || len(shared.Names) != 2 {
+		t.Errorf("UnmarshalLoop() = %v with %d names, want 2", err, len(shared.Names))
+	}
+}
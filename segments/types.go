@@ -0,0 +1,1049 @@
+package segments
+
+// This file defines the segments shared by the health care transaction
+// sets. Field names follow the 005010 element names; positions the
+// implementation guides mark not used are mapped too, so that
+// conversions stay lossless.
+
+// AAA is the Request Validation segment: why a request was rejected and
+// what the requester should do about it.
+type AAA struct {
+	ValidRequest    string `x12:"1"`
+	AgencyQualifier string `x12:"2"`
+	RejectReason    string `x12:"3"`
+	FollowUpAction  string `x12:"4"`
+}
+
+// ADX is the Adjustment segment of a remittance: an amount withheld from
+// or added to a payment, and why.
+type ADX struct {
+	Amount           string `x12:"1"`
+	AdjustmentReason string `x12:"2"`
+	Qualifier        string `x12:"3"`
+	ID               string `x12:"4"`
+}
+
+// AK1 is the Functional Group Response Header segment of an
+// acknowledgment: the functional group acknowledged.
+type AK1 struct {
+	FunctionalIDCode   string `x12:"1"`
+	GroupControlNumber string `x12:"2"`
+	Version            string `x12:"3"`
+}
+
+// AK2 is the Transaction Set Response Header segment of an
+// acknowledgment: the transaction set acknowledged.
+type AK2 struct {
+	TransactionSetID string `x12:"1"`
+	ControlNumber    string `x12:"2"`
+	Version          string `x12:"3"`
+}
+
+// AK3 is the Data Segment Note segment of a 997 acknowledgment: a
+// segment in error, its position in the transaction set, counting ST as
+// 1, its loop, and the error.
+type AK3 struct {
+	SegmentID       string `x12:"1"`
+	SegmentPosition string `x12:"2"`
+	LoopID          string `x12:"3"`
+	ErrorCode       string `x12:"4"`
+}
+
+// AK4 is the Data Element Note segment of a 997 acknowledgment: an
+// element in error, the error, and a copy of the bad data.
+type AK4 struct {
+	ElementPosition  ElementPosition `x12:"1"`
+	ElementReference string          `x12:"2"`
+	ErrorCode        string          `x12:"3"`
+	BadData          string          `x12:"4"`
+}
+
+// AK5 is the Transaction Set Response Trailer segment of a 997
+// acknowledgment: whether the transaction set was accepted ("A"),
+// accepted with errors ("E"), or rejected ("R"), and up to five
+// transaction set error codes.
+type AK5 struct {
+	AcknowledgmentCode string   `x12:"1"`
+	ErrorCodes         []string `x12:"2-6"`
+}
+
+// AK9 is the Functional Group Response Trailer segment of an
+// acknowledgment: whether the group was accepted ("A"), accepted with
+// errors ("E"), partially accepted ("P"), or rejected ("R"), the numbers
+// of its transaction sets, and up to five group error codes.
+type AK9 struct {
+	AcknowledgmentCode string   `x12:"1"`
+	Included           string   `x12:"2"`
+	Received           string   `x12:"3"`
+	Accepted           string   `x12:"4"`
+	ErrorCodes         []string `x12:"5-9"`
+}
+
+// AMT is the Monetary Amount Information segment.
+type AMT struct {
+	Qualifier   string `x12:"1"`
+	Amount      string `x12:"2"`
+	CreditDebit string `x12:"3"`
+}
+
+// BGN is the Beginning Segment of a transaction set without a BHT, such
+// as a benefit enrollment: the transaction's purpose, reference, and
+// creation date and time.
+type BGN struct {
+	PurposeCode     string `x12:"1"`
+	ReferenceID     string `x12:"2"`
+	Date            string `x12:"3"`
+	Time            string `x12:"4"`
+	TimeCode        string `x12:"5"`
+	ReferenceID2    string `x12:"6"`
+	TransactionType string `x12:"7"`
+	ActionCode      string `x12:"8"`
+	SecurityLevel   string `x12:"9"`
+}
+
+// BHT is the Beginning of Hierarchical Transaction segment.
+type BHT struct {
+	StructureCode   string `x12:"1"`
+	PurposeCode     string `x12:"2"`
+	ReferenceID     string `x12:"3"`
+	Date            string `x12:"4"`
+	Time            string `x12:"5"`
+	TransactionType string `x12:"6"`
+}
+
+// BIN is the Binary Data segment: Length (BIN01) bytes of Data (BIN02),
+// such as an attached document, kept verbatim.
+type BIN struct {
+	Length string `x12:"1"`
+	Data   string `x12:"2"`
+}
+
+// BPR is the Financial Information segment: the total amount of a
+// payment and how it is made.
+type BPR struct {
+	HandlingCode               string `x12:"1"`
+	Amount                     string `x12:"2"`
+	CreditDebit                string `x12:"3"`
+	PaymentMethod              string `x12:"4"`
+	PaymentFormat              string `x12:"5"`
+	SenderDFIQualifier         string `x12:"6"`
+	SenderDFIID                string `x12:"7"`
+	SenderAccountQualifier     string `x12:"8"`
+	SenderAccountNumber        string `x12:"9"`
+	OriginatorID               string `x12:"10"`
+	OriginatorSupplementalCode string `x12:"11"`
+	ReceiverDFIQualifier       string `x12:"12"`
+	ReceiverDFIID              string `x12:"13"`
+	ReceiverAccountQualifier   string `x12:"14"`
+	ReceiverAccountNumber      string `x12:"15"`
+	Date                       string `x12:"16"`
+	BusinessFunctionCode       string `x12:"17"`
+	DFIQualifier18             string `x12:"18"`
+	DFIID19                    string `x12:"19"`
+	AccountQualifier20         string `x12:"20"`
+	AccountNumber21            string `x12:"21"`
+}
+
+// CAS is the Claims Adjustment segment: up to six adjustments within one
+// claim adjustment group.
+type CAS struct {
+	GroupCode   string       `x12:"1"`
+	Adjustments []Adjustment `x12:"2-19,group"`
+}
+
+// An Adjustment is one reason, amount, and quantity triple of a CAS
+// segment.
+type Adjustment struct {
+	ReasonCode string `x12:"1"`
+	Amount     string `x12:"2"`
+	Quantity   string `x12:"3"`
+}
+
+// CLM is the Health Claim segment.
+type CLM struct {
+	ClaimID                   string          `x12:"1"`
+	TotalCharge               string          `x12:"2"`
+	ClaimFilingIndicator      string          `x12:"3"`
+	NonInstitutionalClaimType string          `x12:"4"`
+	Location                  ServiceLocation `x12:"5"`
+	ProviderSignature         string          `x12:"6"`
+	AssignmentParticipation   string          `x12:"7"`
+	BenefitsAssignment        string          `x12:"8"`
+	ReleaseOfInformation      string          `x12:"9"`
+	PatientSignatureSource    string          `x12:"10"`
+	RelatedCauses             RelatedCauses   `x12:"11"`
+	SpecialProgram            string          `x12:"12"`
+	YesNo13                   string          `x12:"13"`
+	LevelOfService            string          `x12:"14"`
+	YesNo15                   string          `x12:"15"`
+	ProviderAgreement         string          `x12:"16"`
+	ClaimStatus               string          `x12:"17"`
+	YesNo18                   string          `x12:"18"`
+	SubmissionReason          string          `x12:"19"`
+	DelayReason               string          `x12:"20"`
+}
+
+// ServiceLocation is the Health Care Service Location Information
+// composite (C023).
+type ServiceLocation struct {
+	FacilityCode          string `x12:"1"`
+	FacilityCodeQualifier string `x12:"2"`
+	FrequencyCode         string `x12:"3"`
+}
+
+// RelatedCauses is the Related Causes Information composite (C024).
+type RelatedCauses struct {
+	Code1   string `x12:"1"`
+	Code2   string `x12:"2"`
+	Code3   string `x12:"3"`
+	State   string `x12:"4"`
+	Country string `x12:"5"`
+}
+
+// CL1 is the Claim Codes segment of an institutional claim.
+type CL1 struct {
+	AdmissionType                string `x12:"1"`
+	AdmissionSource              string `x12:"2"`
+	PatientStatus                string `x12:"3"`
+	NursingHomeResidentialStatus string `x12:"4"`
+}
+
+// CLP is the Claim Level Data segment of a payment/advice: a claim's
+// charge, payment, and status as the payer adjudicated it.
+type CLP struct {
+	ClaimID               string `x12:"1"`
+	StatusCode            string `x12:"2"`
+	TotalCharge           string `x12:"3"`
+	Payment               string `x12:"4"`
+	PatientResponsibility string `x12:"5"`
+	ClaimFilingIndicator  string `x12:"6"`
+	PayerClaimControl     string `x12:"7"`
+	FacilityCode          string `x12:"8"`
+	FrequencyCode         string `x12:"9"`
+	PatientStatus         string `x12:"10"`
+	DRGCode               string `x12:"11"`
+	DRGWeight             string `x12:"12"`
+	DischargeFraction     string `x12:"13"`
+	YesNo14               string `x12:"14"`
+}
+
+// CN1 is the Contract Information segment.
+type CN1 struct {
+	TypeCode      string `x12:"1"`
+	Amount        string `x12:"2"`
+	Percent       string `x12:"3"`
+	Code          string `x12:"4"`
+	TermsDiscount string `x12:"5"`
+	VersionID     string `x12:"6"`
+}
+
+// CR1 is the Ambulance Certification segment: a patient's weight and
+// the ambulance transport's code, reason, and distance.
+type CR1 struct {
+	WeightUnit       string `x12:"1"`
+	Weight           string `x12:"2"`
+	TransportCode    string `x12:"3"`
+	TransportReason  string `x12:"4"`
+	DistanceUnit     string `x12:"5"`
+	Distance         string `x12:"6"`
+	Address1         string `x12:"7"`
+	Address2         string `x12:"8"`
+	RoundTripPurpose string `x12:"9"`
+	StretcherPurpose string `x12:"10"`
+}
+
+// CRC is the Conditions Indicator segment.
+type CRC struct {
+	CategoryCode string   `x12:"1"`
+	Response     string   `x12:"2"`
+	Conditions   []string `x12:"3-7"`
+}
+
+// CTP is the Pricing Information segment.
+type CTP struct {
+	ClassOfTrade          string   `x12:"1"`
+	PriceIdentifier       string   `x12:"2"`
+	UnitPrice             string   `x12:"3"`
+	Quantity              string   `x12:"4"`
+	Unit                  []string `x12:"5"`
+	PriceMultiplier       string   `x12:"6"`
+	Multiplier            string   `x12:"7"`
+	Amount                string   `x12:"8"`
+	BasisOfUnitPrice      string   `x12:"9"`
+	ConditionValue        string   `x12:"10"`
+	MultiplePriceQuantity string   `x12:"11"`
+}
+
+// CTX is the Context segment of an implementation acknowledgment: the
+// business unit, such as a claim ("CLM01"), or the situational trigger
+// segment an error is reported in the context of.
+type CTX struct {
+	Context          ContextIdentification `x12:"1"`
+	SegmentID        string                `x12:"2"`
+	SegmentPosition  string                `x12:"3"`
+	LoopID           string                `x12:"4"`
+	ElementPosition  ElementPosition       `x12:"5"`
+	ElementReference ElementReference      `x12:"6"`
+}
+
+// ContextIdentification is the Context Identification composite (C998):
+// a context name, such as "CLM01", and its value.
+type ContextIdentification struct {
+	Name      string `x12:"1"`
+	Reference string `x12:"2"`
+}
+
+// ElementReference is the Reference in Segment composite (C999): the
+// data element reference numbers of an element and a component.
+type ElementReference struct {
+	Element   string `x12:"1"`
+	Component string `x12:"2"`
+}
+
+// DMG is the Demographic Information segment.
+type DMG struct {
+	FormatQualifier     string   `x12:"1"`
+	BirthDate           string   `x12:"2"`
+	Gender              string   `x12:"3"`
+	MaritalStatus       string   `x12:"4"`
+	RaceOrEthnicity     []string `x12:"5,rep"`
+	CitizenshipStatus   string   `x12:"6"`
+	Country             string   `x12:"7"`
+	BasisOfVerification string   `x12:"8"`
+	Quantity            string   `x12:"9"`
+	CodeListQualifier   string   `x12:"10"`
+	IndustryCode        string   `x12:"11"`
+}
+
+// DN1 is the Orthodontic Information segment.
+type DN1 struct {
+	TotalMonths     string `x12:"1"`
+	RemainingMonths string `x12:"2"`
+	YesNo3          string `x12:"3"`
+	Description     string `x12:"4"`
+}
+
+// DN2 is the Tooth Summary segment: the status of one tooth.
+type DN2 struct {
+	ToothNumber       string `x12:"1"`
+	StatusCode        string `x12:"2"`
+	Quantity          string `x12:"3"`
+	DateFormat        string `x12:"4"`
+	Date              string `x12:"5"`
+	CodeListQualifier string `x12:"6"`
+}
+
+// DTP is the Date or Time or Period segment.
+type DTP struct {
+	Qualifier       string `x12:"1"`
+	FormatQualifier string `x12:"2"`
+	Period          string `x12:"3"`
+}
+
+// DTM is the Date/Time Reference segment.
+type DTM struct {
+	Qualifier       string `x12:"1"`
+	Date            string `x12:"2"`
+	Time            string `x12:"3"`
+	TimeCode        string `x12:"4"`
+	FormatQualifier string `x12:"5"`
+	Period          string `x12:"6"`
+}
+
+// EB is the Eligibility or Benefit Information segment. ServiceTypes
+// holds the repetitions of EB03.
+type EB struct {
+	InfoCode              string              `x12:"1"`
+	CoverageLevel         string              `x12:"2"`
+	ServiceTypes          []string            `x12:"3,rep"`
+	InsuranceType         string              `x12:"4"`
+	PlanCoverage          string              `x12:"5"`
+	TimePeriod            string              `x12:"6"`
+	Amount                string              `x12:"7"`
+	Percent               string              `x12:"8"`
+	QuantityQualifier     string              `x12:"9"`
+	Quantity              string              `x12:"10"`
+	AuthorizationRequired string              `x12:"11"`
+	InPlanNetwork         string              `x12:"12"`
+	Procedure             ProcedureIdentifier `x12:"13"`
+	DiagnosisPointers     []string            `x12:"14"`
+}
+
+// ENT is the Entity segment of a remittance: the organization or
+// individual the remittance detail that follows is for.
+type ENT struct {
+	AssignedNumber        string `x12:"1"`
+	EntityIdentifierCode  string `x12:"2"`
+	IDQualifier           string `x12:"3"`
+	ID                    string `x12:"4"`
+	EntityIdentifierCode2 string `x12:"5"`
+	IDQualifier2          string `x12:"6"`
+	ID2                   string `x12:"7"`
+	Qualifier             string `x12:"8"`
+	ReferenceID           string `x12:"9"`
+}
+
+// EQ is the Eligibility or Benefit Inquiry segment. ServiceTypes holds
+// the repetitions of EQ01.
+type EQ struct {
+	ServiceTypes      []string            `x12:"1,rep"`
+	Procedure         ProcedureIdentifier `x12:"2"`
+	CoverageLevel     string              `x12:"3"`
+	InsuranceType     string              `x12:"4"`
+	DiagnosisPointers []string            `x12:"5"`
+}
+
+// HCP is the Health Care Pricing segment, used for repricing.
+type HCP struct {
+	PricingMethodology  string `x12:"1"`
+	RepricedAllowed     string `x12:"2"`
+	SavingAmount        string `x12:"3"`
+	RepricingOrgID      string `x12:"4"`
+	PerDiemRate         string `x12:"5"`
+	ApprovedDRGCode     string `x12:"6"`
+	ApprovedDRGAmount   string `x12:"7"`
+	ApprovedRevenueCode string `x12:"8"`
+	ProductIDQualifier  string `x12:"9"`
+	ProcedureCode       string `x12:"10"`
+	Unit                string `x12:"11"`
+	Quantity            string `x12:"12"`
+	RejectReason        string `x12:"13"`
+	PolicyCompliance    string `x12:"14"`
+	ExceptionCode       string `x12:"15"`
+}
+
+// HCR is the Health Care Services Review segment of a services review
+// response: the reviewer's decision (HCR01), the certification number
+// it issued, and the reason for it.
+type HCR struct {
+	ActionCode            string `x12:"1"`
+	CertificationNumber   string `x12:"2"`
+	ReasonCode            string `x12:"3"`
+	SecondSurgicalOpinion string `x12:"4"`
+}
+
+// HD is the Health Coverage segment of a benefit enrollment: a line of
+// insurance, such as "HLT" for health, the plan, and the coverage level,
+// such as "FAM" for family, with its maintenance type (HD01).
+type HD struct {
+	MaintenanceType      string `x12:"1"`
+	MaintenanceReason    string `x12:"2"`
+	InsuranceLine        string `x12:"3"`
+	PlanCoverage         string `x12:"4"`
+	CoverageLevel        string `x12:"5"`
+	Count6               string `x12:"6"`
+	Count7               string `x12:"7"`
+	UnderwritingDecision string `x12:"8"`
+	YesNo9               string `x12:"9"`
+	DrugHouse            string `x12:"10"`
+	YesNo11              string `x12:"11"`
+}
+
+// HI is the Health Care Information Codes segment: up to twelve codes,
+// such as diagnoses, of the types their qualifiers give.
+type HI struct {
+	Codes []HealthCareCode `x12:"1-12"`
+}
+
+// HealthCareCode is the Health Care Code Information composite (C022).
+type HealthCareCode struct {
+	Qualifier          string `x12:"1"`
+	Code               string `x12:"2"`
+	DateFormat         string `x12:"3"`
+	Date               string `x12:"4"`
+	Amount             string `x12:"5"`
+	Quantity           string `x12:"6"`
+	VersionID          string `x12:"7"`
+	CodeRangeEnd       string `x12:"8"`
+	PresentOnAdmission string `x12:"9"`
+}
+
+// HL is the Hierarchical Level segment.
+type HL struct {
+	ID        string `x12:"1"`
+	ParentID  string `x12:"2"`
+	LevelCode string `x12:"3"`
+	ChildCode string `x12:"4"`
+}
+
+// HSD is the Health Care Services Delivery segment: how many services,
+// over what period, and on what pattern.
+type HSD struct {
+	QuantityQualifier string `x12:"1"`
+	Quantity          string `x12:"2"`
+	Unit              string `x12:"3"`
+	SampleModulus     string `x12:"4"`
+	TimePeriod        string `x12:"5"`
+	Periods           string `x12:"6"`
+	DeliveryPattern   string `x12:"7"`
+	DeliveryTime      string `x12:"8"`
+}
+
+// III is the Information segment: an industry code, such as a place of
+// service, or a free-form message.
+type III struct {
+	CodeListQualifier string   `x12:"1"`
+	Code              string   `x12:"2"`
+	CategoryCode      string   `x12:"3"`
+	Text              string   `x12:"4"`
+	Quantity          string   `x12:"5"`
+	Unit              []string `x12:"6"`
+	Layers            []string `x12:"7-9"`
+}
+
+// IK3 is the Implementation Data Segment Note segment of a 999
+// acknowledgment: a segment in error, its position in the transaction
+// set, counting ST as 1, its loop, and the error.
+type IK3 struct {
+	SegmentID       string `x12:"1"`
+	SegmentPosition string `x12:"2"`
+	LoopID          string `x12:"3"`
+	ErrorCode       string `x12:"4"`
+}
+
+// IK4 is the Implementation Data Element Note segment of a 999
+// acknowledgment: an element in error, the error, and a copy of the bad
+// data.
+type IK4 struct {
+	ElementPosition  ElementPosition `x12:"1"`
+	ElementReference string          `x12:"2"`
+	ErrorCode        string          `x12:"3"`
+	BadData          string          `x12:"4"`
+}
+
+// IK5 is the Implementation Transaction Set Response Trailer segment of
+// a 999 acknowledgment: whether the transaction set was accepted ("A"),
+// accepted with errors ("E"), or rejected ("R"), and up to five
+// transaction set error codes.
+type IK5 struct {
+	AcknowledgmentCode string   `x12:"1"`
+	ErrorCodes         []string `x12:"2-6"`
+}
+
+// INS is the Member Level Detail segment.
+type INS struct {
+	SubscriberIndicator string   `x12:"1"`
+	RelationshipCode    string   `x12:"2"`
+	MaintenanceType     string   `x12:"3"`
+	MaintenanceReason   string   `x12:"4"`
+	BenefitStatus       string   `x12:"5"`
+	MedicareStatus      []string `x12:"6"`
+	COBRAEvent          string   `x12:"7"`
+	EmploymentStatus    string   `x12:"8"`
+	StudentStatus       string   `x12:"9"`
+	Handicapped         string   `x12:"10"`
+	DateFormat          string   `x12:"11"`
+	DateOfDeath         string   `x12:"12"`
+	Confidentiality     string   `x12:"13"`
+	City                string   `x12:"14"`
+	State               string   `x12:"15"`
+	Country             string   `x12:"16"`
+	BirthSequence       string   `x12:"17"`
+}
+
+// K3 is the File Information segment.
+type K3 struct {
+	Information  string   `x12:"1"`
+	RecordFormat string   `x12:"2"`
+	Unit         []string `x12:"3"`
+}
+
+// LE is the Loop Trailer segment, which closes a loop opened by LS.
+type LE struct {
+	LoopID string `x12:"1"`
+}
+
+// LIN is the Item Identification segment, as the health care guides use
+// it to identify a drug.
+type LIN struct {
+	AssignedID         string `x12:"1"`
+	ProductIDQualifier string `x12:"2"`
+	ProductID          string `x12:"3"`
+}
+
+// LQ is the Industry Code Identification segment, as the payment/advice
+// uses it for remark codes.
+type LQ struct {
+	CodeListQualifier string `x12:"1"`
+	Code              string `x12:"2"`
+}
+
+// LS is the Loop Header segment, which opens a bounded loop.
+type LS struct {
+	LoopID string `x12:"1"`
+}
+
+// LX is the Transaction Set Line Number segment.
+type LX struct {
+	Number string `x12:"1"`
+}
+
+// MOA is the Outpatient Adjudication Information segment.
+type MOA struct {
+	ReimbursementRate  string   `x12:"1"`
+	HCPCSPayableAmount string   `x12:"2"`
+	RemarkCodes        []string `x12:"3-7"`
+	ESRDPaymentAmount  string   `x12:"8"`
+	NonpayableAmount   string   `x12:"9"`
+}
+
+// MPI is the Military Personnel Information segment.
+type MPI struct {
+	InformationStatus  string `x12:"1"`
+	EmploymentStatus   string `x12:"2"`
+	ServiceAffiliation string `x12:"3"`
+	Description        string `x12:"4"`
+	Rank               string `x12:"5"`
+	DateFormat         string `x12:"6"`
+	Period             string `x12:"7"`
+}
+
+// MSG is the Message Text segment.
+type MSG struct {
+	Text            string `x12:"1"`
+	CarriageControl string `x12:"2"`
+	Number          string `x12:"3"`
+}
+
+// N1 is the Party Identification segment.
+type N1 struct {
+	EntityIdentifierCode  string `x12:"1"`
+	Name                  string `x12:"2"`
+	IDQualifier           string `x12:"3"`
+	ID                    string `x12:"4"`
+	EntityRelationship    string `x12:"5"`
+	EntityIdentifierCode2 string `x12:"6"`
+}
+
+// N3 is the Party Location segment.
+type N3 struct {
+	Address1 string `x12:"1"`
+	Address2 string `x12:"2"`
+}
+
+// N4 is the Geographic Location segment.
+type N4 struct {
+	City               string `x12:"1"`
+	State              string `x12:"2"`
+	PostalCode         string `x12:"3"`
+	Country            string `x12:"4"`
+	LocationQualifier  string `x12:"5"`
+	LocationID         string `x12:"6"`
+	CountrySubdivision string `x12:"7"`
+}
+
+// NM1 is the Individual or Organizational Name segment. LastName holds
+// an organization's name.
+type NM1 struct {
+	EntityIdentifierCode  string `x12:"1"`
+	EntityTypeQualifier   string `x12:"2"`
+	LastName              string `x12:"3"`
+	FirstName             string `x12:"4"`
+	MiddleName            string `x12:"5"`
+	Prefix                string `x12:"6"`
+	Suffix                string `x12:"7"`
+	IDQualifier           string `x12:"8"`
+	ID                    string `x12:"9"`
+	EntityRelationship    string `x12:"10"`
+	EntityIdentifierCode2 string `x12:"11"`
+	LastName2             string `x12:"12"`
+}
+
+// NTE is the Note/Special Instruction segment.
+type NTE struct {
+	ReferenceCode string `x12:"1"`
+	Description   string `x12:"2"`
+}
+
+// OTI is the Original Transaction Identification segment of an
+// application advice: a transaction set it reports on, and whether it
+// was accepted ("TA"), accepted with errors ("TE"), or rejected ("TR").
+type OTI struct {
+	AcknowledgmentCode       string `x12:"1"`
+	Qualifier                string `x12:"2"`
+	ReferenceID              string `x12:"3"`
+	SenderCode               string `x12:"4"`
+	ReceiverCode             string `x12:"5"`
+	Date                     string `x12:"6"`
+	Time                     string `x12:"7"`
+	GroupControlNumber       string `x12:"8"`
+	TransactionControlNumber string `x12:"9"`
+	TransactionSetID         string `x12:"10"`
+	Version                  string `x12:"11"`
+	PurposeCode              string `x12:"12"`
+	TransactionType          string `x12:"13"`
+	ApplicationType          string `x12:"14"`
+	ActionCode               string `x12:"15"`
+	HandlingCode             string `x12:"16"`
+	StatusReason             string `x12:"17"`
+}
+
+// PAT is the Patient Information segment.
+type PAT struct {
+	RelationshipCode   string `x12:"1"`
+	LocationCode       string `x12:"2"`
+	EmploymentStatus   string `x12:"3"`
+	StudentStatus      string `x12:"4"`
+	DateFormat         string `x12:"5"`
+	DateOfDeath        string `x12:"6"`
+	Unit               string `x12:"7"`
+	Weight             string `x12:"8"`
+	PregnancyIndicator string `x12:"9"`
+}
+
+// PER is the Administrative Communications Contact segment.
+type PER struct {
+	FunctionCode     string          `x12:"1"`
+	Name             string          `x12:"2"`
+	Communications   []Communication `x12:"3-8,group"`
+	InquiryReference string          `x12:"9"`
+}
+
+// A Communication is one qualifier and number pair of a PER segment,
+// such as "TE" and a telephone number.
+type Communication struct {
+	Qualifier string `x12:"1"`
+	Number    string `x12:"2"`
+}
+
+// PLB is the Provider Level Adjustment segment: up to six adjustments
+// to a provider's payment not tied to a claim.
+type PLB struct {
+	ProviderID  string               `x12:"1"`
+	FiscalDate  string               `x12:"2"`
+	Adjustments []ProviderAdjustment `x12:"3-14,group"`
+}
+
+// A ProviderAdjustment is one identifier and amount pair of a PLB
+// segment. A positive amount reduces the payment.
+type ProviderAdjustment struct {
+	Identifier AdjustmentIdentifier `x12:"1"`
+	Amount     string               `x12:"2"`
+}
+
+// AdjustmentIdentifier is the Adjustment Identifier composite (C042):
+// the reason for a provider adjustment and the reference it applies to.
+type AdjustmentIdentifier struct {
+	ReasonCode  string `x12:"1"`
+	ReferenceID string `x12:"2"`
+}
+
+// PRV is the Provider Information segment.
+type PRV struct {
+	ProviderCode       string   `x12:"1"`
+	ReferenceQualifier string   `x12:"2"`
+	ReferenceID        string   `x12:"3"`
+	State              string   `x12:"4"`
+	Specialty          []string `x12:"5"`
+	OrganizationCode   string   `x12:"6"`
+}
+
+// PWK is the Paperwork segment.
+type PWK struct {
+	ReportType      string   `x12:"1"`
+	Transmission    string   `x12:"2"`
+	Copies          string   `x12:"3"`
+	EntityCode      string   `x12:"4"`
+	IDQualifier     string   `x12:"5"`
+	ID              string   `x12:"6"`
+	Description     string   `x12:"7"`
+	Actions         []string `x12:"8"`
+	RequestCategory string   `x12:"9"`
+}
+
+// QTY is the Quantity Information segment.
+type QTY struct {
+	Qualifier string   `x12:"1"`
+	Quantity  string   `x12:"2"`
+	Unit      []string `x12:"3"`
+	FreeForm  string   `x12:"4"`
+}
+
+// REF is the Reference Information segment.
+type REF struct {
+	Qualifier   string              `x12:"1"`
+	ID          string              `x12:"2"`
+	Description string              `x12:"3"`
+	Reference   ReferenceIdentifier `x12:"4"`
+}
+
+// ReferenceIdentifier is the Reference Identifier composite (C040).
+type ReferenceIdentifier struct {
+	Qualifier1 string `x12:"1"`
+	ID1        string `x12:"2"`
+	Qualifier2 string `x12:"3"`
+	ID2        string `x12:"4"`
+	Qualifier3 string `x12:"5"`
+	ID3        string `x12:"6"`
+}
+
+// RMR is the Remittance Advice Accounts Receivable Open Item Reference
+// segment: an amount remitted for one invoice, policy, or account.
+type RMR struct {
+	Qualifier         string `x12:"1"`
+	ID                string `x12:"2"`
+	PaymentActionCode string `x12:"3"`
+	Amount            string `x12:"4"`
+	InvoiceAmount     string `x12:"5"`
+	DiscountAmount    string `x12:"6"`
+	AdjustmentReason  string `x12:"7"`
+	Amount8           string `x12:"8"`
+}
+
+// RED is the Related Data segment: data related to an error an
+// application advice reports.
+type RED struct {
+	Description       string `x12:"1"`
+	CodeListQualifier string `x12:"2"`
+	Code              string `x12:"3"`
+}
+
+// SBR is the Subscriber Information segment.
+type SBR struct {
+	PayerResponsibility    string `x12:"1"`
+	RelationshipCode       string `x12:"2"`
+	GroupNumber            string `x12:"3"`
+	GroupName              string `x12:"4"`
+	InsuranceType          string `x12:"5"`
+	CoordinationOfBenefits string `x12:"6"`
+	ConditionResponse      string `x12:"7"`
+	EmploymentStatus       string `x12:"8"`
+	ClaimFilingIndicator   string `x12:"9"`
+}
+
+// STC is the Status Information segment of a claim status response.
+// Status holds the primary status; Status2 and Status3 hold any
+// further statuses reported with it.
+type STC struct {
+	Status           ClaimStatus `x12:"1"`
+	Date             string      `x12:"2"`
+	ActionCode       string      `x12:"3"`
+	TotalCharge      string      `x12:"4"`
+	Payment          string      `x12:"5"`
+	AdjudicationDate string      `x12:"6"`
+	PaymentMethod    string      `x12:"7"`
+	CheckDate        string      `x12:"8"`
+	CheckNumber      string      `x12:"9"`
+	Status2          ClaimStatus `x12:"10"`
+	Status3          ClaimStatus `x12:"11"`
+	Message          string      `x12:"12"`
+}
+
+// Statuses returns the statuses s reports, in order, skipping empty
+// ones.
+func (s STC) Statuses() []ClaimStatus {
+	var out []ClaimStatus
+	for _, c := range []ClaimStatus{s.Status, s.Status2, s.Status3} {
+		if c != (ClaimStatus{}) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ClaimStatus is the Health Care Claim Status composite (C043): a
+// category code, such as "F2" for finalized/denial, a status code
+// qualifying it, and the entity the status applies to.
+type ClaimStatus struct {
+	Category          string `x12:"1"`
+	Status            string `x12:"2"`
+	EntityCode        string `x12:"3"`
+	CodeListQualifier string `x12:"4"`
+}
+
+// SV1 is the Professional Service segment.
+type SV1 struct {
+	Procedure         ProcedureIdentifier `x12:"1"`
+	Charge            string              `x12:"2"`
+	Unit              string              `x12:"3"`
+	Quantity          string              `x12:"4"`
+	PlaceOfService    string              `x12:"5"`
+	ServiceType       string              `x12:"6"`
+	DiagnosisPointers []string            `x12:"7"`
+	Amount8           string              `x12:"8"`
+	Emergency         string              `x12:"9"`
+	YesNo10           string              `x12:"10"`
+	EPSDT             string              `x12:"11"`
+	FamilyPlanning    string              `x12:"12"`
+	ReviewCode        string              `x12:"13"`
+	NationalOrLocal   string              `x12:"14"`
+	CopayStatus       string              `x12:"15"`
+	ShortageArea      string              `x12:"16"`
+	ReferenceID       string              `x12:"17"`
+	PostalCode        string              `x12:"18"`
+	Amount19          string              `x12:"19"`
+	LevelOfCare       string              `x12:"20"`
+	ProviderAgreement string              `x12:"21"`
+}
+
+// ProcedureIdentifier is the Composite Medical Procedure Identifier
+// (C003).
+type ProcedureIdentifier struct {
+	Qualifier   string `x12:"1"`
+	Code        string `x12:"2"`
+	Modifier1   string `x12:"3"`
+	Modifier2   string `x12:"4"`
+	Modifier3   string `x12:"5"`
+	Modifier4   string `x12:"6"`
+	Description string `x12:"7"`
+	CodeEnd     string `x12:"8"`
+}
+
+// SV2 is the Institutional Service Line segment.
+type SV2 struct {
+	RevenueCode                  string              `x12:"1"`
+	Procedure                    ProcedureIdentifier `x12:"2"`
+	Charge                       string              `x12:"3"`
+	Unit                         string              `x12:"4"`
+	Quantity                     string              `x12:"5"`
+	UnitRate                     string              `x12:"6"`
+	NonCoveredCharge             string              `x12:"7"`
+	NursingHomeResidentialStatus string              `x12:"8"`
+	LevelOfCare                  string              `x12:"9"`
+	ProviderAgreement            string              `x12:"10"`
+	ClaimStatus                  string              `x12:"11"`
+}
+
+// SV3 is the Dental Service segment.
+type SV3 struct {
+	Procedure         ProcedureIdentifier `x12:"1"`
+	Charge            string              `x12:"2"`
+	PlaceOfService    string              `x12:"3"`
+	OralCavity        OralCavity          `x12:"4"`
+	ProsthesisCode    string              `x12:"5"`
+	Quantity          string              `x12:"6"`
+	Description       string              `x12:"7"`
+	CopayStatus       string              `x12:"8"`
+	ProviderAgreement string              `x12:"9"`
+	YesNo10           string              `x12:"10"`
+	DiagnosisPointers []string            `x12:"11"`
+}
+
+// OralCavity is the Oral Cavity Designation composite (C006): up to five
+// areas of the mouth, such as "01" for the upper right quadrant.
+type OralCavity struct {
+	Area1 string `x12:"1"`
+	Area2 string `x12:"2"`
+	Area3 string `x12:"3"`
+	Area4 string `x12:"4"`
+	Area5 string `x12:"5"`
+}
+
+// Areas returns the designated areas, in order.
+func (o OralCavity) Areas() []string {
+	return nonEmpty(o.Area1, o.Area2, o.Area3, o.Area4, o.Area5)
+}
+
+// SVC is the Service Payment Information segment of a payment/advice.
+// OriginalProcedure holds the procedure submitted when the payer
+// adjudicated a different one.
+type SVC struct {
+	Procedure         ProcedureIdentifier `x12:"1"`
+	Charge            string              `x12:"2"`
+	Payment           string              `x12:"3"`
+	RevenueCode       string              `x12:"4"`
+	UnitsPaid         string              `x12:"5"`
+	OriginalProcedure ProcedureIdentifier `x12:"6"`
+	OriginalUnits     string              `x12:"7"`
+}
+
+// SVD is the Line Adjudication Information segment.
+type SVD struct {
+	PayerID     string              `x12:"1"`
+	PaidAmount  string              `x12:"2"`
+	Procedure   ProcedureIdentifier `x12:"3"`
+	ProductID   string              `x12:"4"`
+	PaidUnits   string              `x12:"5"`
+	BundledLine string              `x12:"6"`
+}
+
+// TED is the Technical Error Description segment: an error found in a
+// transaction, and the segment and element where it was found.
+type TED struct {
+	ErrorCode        string          `x12:"1"`
+	Message          string          `x12:"2"`
+	SegmentID        string          `x12:"3"`
+	SegmentPosition  string          `x12:"4"`
+	ElementPosition  ElementPosition `x12:"5"`
+	ElementReference string          `x12:"6"`
+	BadData          string          `x12:"7"`
+	NewContent       string          `x12:"8"`
+}
+
+// ElementPosition is the Position in Segment composite (C030).
+type ElementPosition struct {
+	Element    string `x12:"1"`
+	Component  string `x12:"2"`
+	Repetition string `x12:"3"`
+}
+
+// TOO is the Tooth Identification segment.
+type TOO struct {
+	CodeListQualifier string       `x12:"1"`
+	ToothNumber       string       `x12:"2"`
+	Surface           ToothSurface `x12:"3"`
+}
+
+// ToothSurface is the Tooth Surface composite (C005): up to five
+// surfaces of a tooth, such as "M" for mesial or "O" for occlusal.
+type ToothSurface struct {
+	Surface1 string `x12:"1"`
+	Surface2 string `x12:"2"`
+	Surface3 string `x12:"3"`
+	Surface4 string `x12:"4"`
+	Surface5 string `x12:"5"`
+}
+
+// Surfaces returns the tooth's surfaces, in order.
+func (t ToothSurface) Surfaces() []string {
+	return nonEmpty(t.Surface1, t.Surface2, t.Surface3, t.Surface4, t.Surface5)
+}
+
+// TRN is the Trace segment.
+type TRN struct {
+	TypeCode     string `x12:"1"`
+	ReferenceID  string `x12:"2"`
+	OriginatorID string `x12:"3"`
+	ReferenceID2 string `x12:"4"`
+}
+
+// UM is the Health Care Services Review Information segment: what a
+// services review asks for, such as "AR" for an admission review or "HS"
+// for health services, and the service it concerns.
+type UM struct {
+	RequestCategory      string          `x12:"1"`
+	CertificationType    string          `x12:"2"`
+	ServiceType          string          `x12:"3"`
+	Location             ServiceLocation `x12:"4"`
+	RelatedCauses        RelatedCauses   `x12:"5"`
+	LevelOfService       string          `x12:"6"`
+	HealthCondition      string          `x12:"7"`
+	Prognosis            string          `x12:"8"`
+	ReleaseOfInformation string          `x12:"9"`
+	DelayReason          string          `x12:"10"`
+}
+
+func nonEmpty(vs ...string) []string {
+	var out []string
+	for _, v := range vs {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// A Party is a name loop of the health care guides: an NM1 segment
+// naming a submitter, provider, payer, or person, followed by the
+// segments that describe it. Each guide's name loops use a subset of
+// these segments, in this order.
+type Party struct {
+	Name         NM1   `x12:"NM1"`
+	Specialty    *PRV  `x12:"PRV"`
+	Address      *N3   `x12:"N3"`
+	City         *N4   `x12:"N4"`
+	Demographics *DMG  `x12:"DMG"`
+	Dates        []DTP `x12:"DTP"`
+	References   []REF `x12:"REF"`
+	Contacts     []PER `x12:"PER"`
+}
@@ -0,0 +1,178 @@
+// Package snip validates X12 documents in the seven levels, or types,
+// defined by the WEDI Strategic National Implementation Process (SNIP)
+// for HIPAA transactions:
+//
+//  1. Integrity: the envelope and each segment's X12 syntax.
+//  2. Requirement: the implementation guide's loops, segments, usage,
+//     repeat counts, and code lists.
+//  3. Balancing: totals that must agree, such as a claim's charge and
+//     the sum of its service lines.
+//  4. Situation: the guide's situational rules.
+//  5. External code sets, such as ICD-10-CM or CPT.
+//  6. Product types or types of service, such as ambulance or
+//     chiropractic claims.
+//  7. Trading-partner specific requirements, as in companion guides.
+//
+// A Validator runs the levels against a Document and returns a Report
+// whose Findings can be grouped by level, by transaction set, and by
+// claim. Level 1 builds on Document.Validate and SegmentDef.Check;
+// levels 2, 4, and 5 use the schema package's transaction-set schemas;
+// balancing, product-type, and trading-partner requirements are
+// supplied as Checks.
+package snip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Level is a SNIP validation level.
+type Level int
+
+// SNIP levels.
+const (
+	Integrity       Level = 1 + iota // level 1: X12 syntax integrity
+	Requirement                      // level 2: implementation guide requirements
+	Balancing                        // level 3: balancing
+	Situation                        // level 4: situational rules
+	ExternalCodeSet                  // level 5: external code sets
+	ProductType                      // level 6: product types or types of service
+	TradingPartner                   // level 7: trading-partner specific requirements
+)
+
+func (l Level) String() string {
+	switch l {
+	case Integrity:
+		return "Integrity"
+	case Requirement:
+		return "Requirement"
+	case Balancing:
+		return "Balancing"
+	case Situation:
+		return "Situation"
+	case ExternalCodeSet:
+		return "External Code Set"
+	case ProductType:
+		return "Product Type"
+	case TradingPartner:
+		return "Trading Partner"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// Sentinel errors for findings the package reports itself.
+var (
+	// ErrNoSchema reports a transaction set for which the Validator
+	// has no schema, so levels 2, 4, and 5 could not be checked.
+	ErrNoSchema = errors.New("no schema for transaction set")
+	// ErrOutOfBalance reports totals that do not agree. Balancing
+	// Checks wrap it.
+	ErrOutOfBalance = errors.New("out of balance")
+)
+
+// A Finding is one validation failure.
+type Finding struct {
+	Level Level
+
+	// Group and Transaction are the control numbers (GS06 and ST02) of
+	// the functional group and transaction set the finding belongs to.
+	// Both are empty for findings about the envelope as a whole.
+	Group       string
+	Transaction string
+
+	// Claim is the identifier of the claim the finding falls within
+	// (CLM01 or CLP01), or empty.
+	Claim string
+
+	// Position is the 1-based position within the transaction set of
+	// the segment the finding concerns, counting ST as 1, or 0.
+	Position int
+
+	Err error
+}
+
+func (f Finding) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "level %d (%v)", int(f.Level), f.Level)
+	if f.Group != "" {
+		fmt.Fprintf(&b, " group %s", f.Group)
+	}
+	if f.Transaction != "" {
+		fmt.Fprintf(&b, " transaction %s", f.Transaction)
+	}
+	if f.Claim != "" {
+		fmt.Fprintf(&b, " claim %s", f.Claim)
+	}
+	fmt.Fprintf(&b, ": %v", f.Err)
+	return b.String()
+}
+
+// A TransactionRef identifies a transaction set within an interchange
+// by the control numbers of its functional group and itself.
+type TransactionRef struct {
+	Group       string // GS06
+	Transaction string // ST02
+}
+
+// A ClaimRef identifies a claim within a transaction set.
+type ClaimRef struct {
+	TransactionRef
+	Claim string // CLM01 or CLP01
+}
+
+// A Report holds the findings of a validation run.
+type Report struct {
+	// Findings are ordered by transaction set, in document order, and
+	// within a transaction set by level. Findings about the envelope
+	// come first.
+	Findings []Finding
+}
+
+// OK reports whether the report has no findings.
+func (r *Report) OK() bool { return len(r.Findings) == 0 }
+
+// Level returns the findings at level l.
+func (r *Report) Level(l Level) []Finding {
+	var fs []Finding
+	for _, f := range r.Findings {
+		if f.Level == l {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
+// ByLevel groups the findings by level.
+func (r *Report) ByLevel() map[Level][]Finding {
+	m := make(map[Level][]Finding)
+	for _, f := range r.Findings {
+		m[f.Level] = append(m[f.Level], f)
+	}
+	return m
+}
+
+// ByTransaction groups the findings by transaction set. Findings about
+// the envelope are keyed by the zero TransactionRef.
+func (r *Report) ByTransaction() map[TransactionRef][]Finding {
+	m := make(map[TransactionRef][]Finding)
+	for _, f := range r.Findings {
+		k := TransactionRef{Group: f.Group, Transaction: f.Transaction}
+		m[k] = append(m[k], f)
+	}
+	return m
+}
+
+// ByClaim groups the findings that fall within a claim by claim.
+// Findings outside any claim are omitted.
+func (r *Report) ByClaim() map[ClaimRef][]Finding {
+	m := make(map[ClaimRef][]Finding)
+	for _, f := range r.Findings {
+		if f.Claim == "" {
+			continue
+		}
+		k := ClaimRef{TransactionRef{Group: f.Group, Transaction: f.Transaction}, f.Claim}
+		m[k] = append(m[k], f)
+	}
+	return m
+}
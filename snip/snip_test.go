@@ -0,0 +1,276 @@
+package snip_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/hipaa"
+	"github.com/tmc/x12/schema"
+	"github.com/tmc/x12/snip"
+)
+
+// claimSet is a cut-down claim transaction: claims (CLM) with a
+// diagnosis (HI) drawn from the "ICD" code set and service lines (LX,
+// SV1) whose charge, SV102, is required.
+var claimSet = &schema.TransactionSet{
+	ID:      "837",
+	Version: "005010X999A1",
+	Loop: &schema.Loop{Children: []schema.Node{
+		&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+		&schema.Loop{ID: "2300", Usage: schema.Required, Children: []schema.Node{
+			&schema.Segment{ID: "CLM", Usage: schema.Required, Max: 1},
+			&schema.Segment{ID: "HI", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+				{Usage: schema.Required, Codes: []string{"ABK"}, CodeSet: "ICD", Component: 2},
+			}},
+			&schema.Loop{ID: "2400", Usage: schema.Required, Children: []schema.Node{
+				&schema.Segment{ID: "LX", Usage: schema.Required, Max: 1},
+				&schema.Segment{ID: "SV1", Usage: schema.Required, Max: 1, Elements: []*schema.Element{
+					nil, {Usage: schema.Required},
+				}},
+			}},
+		}},
+	}},
+}
+
+const claims = `ISA*00*          *00*          *ZZ*SUBMITTER      *ZZ*RECEIVER       *230101*1200*^*00501*000000001*0*P*:~
+GS*HC*SUBMITTER*RECEIVER*20230101*1200*1*X*005010X999~
+ST*837*0001*005010X999~
+BHT*0019~
+CLM*A1*100~
+HI*ABK:Z00~
+LX*1~
+SV1*HC:99213*60~
+LX*2~
+SV1*HC:99214~
+CLM*B2*50~
+HI*ABK:BAD~
+LX*1~
+SV1*HC:99211*50~
+SE*13*0001~
+ST*837*0002*005010X111~
+BHT*0019*00~
+SE*3*0002~
+GE*2*1~
+IEA*1*000000002~`
+
+func TestValidate(t *testing.T) {
+	doc, err := x12.Decode(strings.NewReader(claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &snip.Validator{
+		Schemas: []*schema.TransactionSet{claimSet},
+		Segments: map[string]*x12.SegmentDef{
+			"BHT": {ID: "BHT", Elements: []x12.ElementDef{{Requirement: x12.Mandatory}, {Requirement: x12.Mandatory}}},
+		},
+		CodeSets: map[string]snip.CodeSet{
+			"ICD": snip.CodeSetFunc(func(code string) bool { return code == "Z00" }),
+		},
+		Checks: []snip.Check{
+			{Name: "one-line claims", Level: snip.ProductType, TransactionSet: "837", Func: func(t *snip.Target) []error {
+				if t.Root == nil {
+					return nil
+				}
+				var errs []error
+				for _, claim := range t.Root.Loops("2300") {
+					if lines := claim.Loops("2400"); len(lines) > 1 {
+						errs = append(errs, &schema.Error{Position: lines[1].Position(), SegmentID: "LX", Err: errors.New("too many lines")})
+					}
+				}
+				return errs
+			}},
+			{Name: "sender", Level: snip.TradingPartner, Func: func(t *snip.Target) []error {
+				if id := strings.TrimSpace(t.Interchange.Header.SenderID); id != "PARTNER" {
+					return []error{fmt.Errorf("unknown sender %s", id)}
+				}
+				return nil
+			}},
+			{Name: "remittances", Level: snip.Balancing, TransactionSet: "835", Func: func(t *snip.Target) []error {
+				return []error{errors.New("not reached")}
+			}},
+		},
+	}
+	report := v.Validate(doc)
+
+	type row struct {
+		Level       snip.Level
+		Transaction string
+		Claim       string
+		Position    int
+		Err         error
+	}
+	want := []row{
+		{snip.Integrity, "", "", 0, x12.ErrInvalidFormat},
+		{snip.Integrity, "0001", "", 2, x12.ErrMissingElement},
+		{snip.Requirement, "0001", "A1", 8, x12.ErrMissingElement},
+		{snip.ExternalCodeSet, "0001", "B2", 10, schema.ErrInvalidCode},
+		{snip.ProductType, "0001", "A1", 7, nil},
+		{snip.TradingPartner, "0001", "", 0, nil},
+		{snip.Requirement, "0002", "", 0, snip.ErrNoSchema},
+		{snip.TradingPartner, "0002", "", 0, nil},
+	}
+	var got []row
+	for i, f := range report.Findings {
+		r := row{f.Level, f.Transaction, f.Claim, f.Position, nil}
+		if i < len(want) && want[i].Err != nil && errors.Is(f.Err, want[i].Err) {
+			r.Err = want[i].Err
+		}
+		got = append(got, r)
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b error) bool { return a == b })); diff != "" {
+		t.Errorf("Findings mismatch (-want +got):\n%s", diff)
+		for _, f := range report.Findings {
+			t.Log(f)
+		}
+	}
+
+	byClaim := report.ByClaim()
+	if n := len(byClaim[snip.ClaimRef{TransactionRef: snip.TransactionRef{Group: "1", Transaction: "0001"}, Claim: "A1"}]); n != 2 {
+		t.Errorf("ByClaim() has %d findings for claim A1, want 2", n)
+	}
+	if n := len(report.ByTransaction()[snip.TransactionRef{}]); n != 1 {
+		t.Errorf("ByTransaction() has %d envelope findings, want 1", n)
+	}
+	if n := len(report.ByLevel()[snip.TradingPartner]); n != 2 {
+		t.Errorf("ByLevel() has %d trading partner findings, want 2", n)
+	}
+	if report.OK() {
+		t.Error("OK() = true, want false")
+	}
+}
+
+func TestValidateClean(t *testing.T) {
+	doc, err := x12.Decode(strings.NewReader(`ST*837*0001*005010X999A1~BHT*0019~CLM*A1*100~HI*ABK:Z00~LX*1~SV1*HC:99213*100~SE*7*0001~`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &snip.Validator{Schemas: []*schema.TransactionSet{claimSet}}
+	if report := v.Validate(doc); !report.OK() {
+		t.Errorf("Validate() = %v, want no findings", report.Findings)
+	}
+}
+
+func TestValidateSharedVersion(t *testing.T) {
+	// lineSet shares claimSet's ID and version but wants LX at the top
+	// level, as a request and response of one guide may.
+	lineSet := &schema.TransactionSet{
+		ID:      "837",
+		Version: "005010X999A1",
+		Loop: &schema.Loop{Children: []schema.Node{
+			&schema.Segment{ID: "BHT", Usage: schema.Required, Max: 1},
+			&schema.Segment{ID: "LX", Usage: schema.Required, Max: 1},
+		}},
+	}
+	v := &snip.Validator{Schemas: []*schema.TransactionSet{lineSet, claimSet}}
+	for _, in := range []string{
+		`ST*837*0001*005010X999A1~BHT*0019~CLM*A1*100~HI*ABK:Z00~LX*1~SV1*HC:99213*100~SE*7*0001~`,
+		`ST*837*0001*005010X999A1~BHT*0019~LX*1~SE*4*0001~`,
+	} {
+		doc, err := x12.Decode(strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report := v.Validate(doc); !report.OK() {
+			t.Errorf("Validate(%q) = %v, want no findings", in, report.Findings)
+		}
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	if got := snip.ExternalCodeSet.String(); got != "External Code Set" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := snip.Level(9).String(); got != "Level(9)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestValidateOverlay(t *testing.T) {
+	const clean = `ISA*00*          *00*          *ZZ*SUBMITTER      *ZZ*%-15s*230101*1200*^*00501*000000001*0*P*:~
+GS*HC*SUBMITTER*%[1]s*20230101*1200*1*X*005010X999A1~
+ST*837*0001~
+BHT*0019~
+CLM*A1*100~
+HI*ABK:Z00~
+LX*1~
+SV1*HC:99213*100~
+SE*7*0001~
+GE*1*1~
+IEA*1*000000001~`
+	v := &snip.Validator{
+		Schemas: []*schema.TransactionSet{claimSet},
+		Overlays: []*schema.Overlay{
+			{Name: "short claim IDs", Base: "005010X999A1", Partners: []schema.Partner{{Receiver: "ACME"}}, Changes: []schema.Change{
+				{Loop: "2300", Segment: "CLM", Element: 1, MaxLength: 1},
+			}},
+			{Name: "broken", Base: "005010X999A1", Partners: []schema.Partner{{Receiver: "BETA"}}, Changes: []schema.Change{
+				{Loop: "2500", Segment: "CLM"},
+			}},
+		},
+	}
+	tests := []struct {
+		receiver string
+		level    snip.Level
+		err      error
+	}{
+		{"OTHER", 0, nil},
+		{"ACME", snip.Requirement, schema.ErrInvalidLength},
+		{"BETA", snip.TradingPartner, x12.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.receiver, func(t *testing.T) {
+			doc, err := x12.Decode(strings.NewReader(fmt.Sprintf(clean, tt.receiver)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			fs := v.Validate(doc).Findings
+			if tt.err == nil {
+				if len(fs) > 0 {
+					t.Errorf("Validate() = %v, want no findings", fs)
+				}
+				return
+			}
+			if len(fs) != 1 || fs[0].Level != tt.level || !errors.Is(fs[0].Err, tt.err) {
+				t.Errorf("Validate() = %v, want one level %d finding wrapping %v", fs, tt.level, tt.err)
+			}
+		})
+	}
+}
+
+// A 278 request and response share their guide's version, so an overlay
+// for that version applies to each guide separately.
+func TestValidateOverlaySharedVersion(t *testing.T) {
+	var txs []string
+	for i, name := range []string{"005010x217-example-1a-referral-request-review.edi", "005010x217-example-1b-response-request-review.edi"} {
+		b, err := os.ReadFile(filepath.Join("..", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, strings.ReplaceAll(strings.TrimSpace(string(b)), "0001", fmt.Sprintf("%04d", i+1)))
+	}
+	doc, err := x12.Decode(strings.NewReader(
+		"ISA*00*          *00*          *ZZ*SUBMITTER      *ZZ*ACME           *050502*1101*^*00501*000000001*0*P*:~" +
+			"GS*HI*SUBMITTER*ACME*20050502*1101*1*X*005010X217~" +
+			strings.Join(txs, "") +
+			"GE*2*1~IEA*1*000000001~"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &snip.Validator{
+		Schemas: hipaa.Schemas,
+		Overlays: []*schema.Overlay{
+			{Name: "long reference IDs", Base: "005010X217", Partners: []schema.Partner{{Receiver: "ACME"}}, Changes: []schema.Change{
+				{Segment: "BHT", Element: 3, MaxLength: 50},
+			}},
+		},
+	}
+	for _, f := range v.Validate(doc).Findings {
+		t.Errorf("Validate() finding: %v", f)
+	}
+}
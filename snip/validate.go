@@ -0,0 +1,356 @@
+package snip
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tmc/x12"
+	"github.com/tmc/x12/schema"
+)
+
+// A CodeSet is an external code list, such as ICD-10-CM diagnosis
+// codes.
+type CodeSet interface {
+	Contains(code string) bool
+}
+
+// CodeSetFunc adapts a function to the CodeSet interface.
+type CodeSetFunc func(code string) bool
+
+// Contains returns f(code).
+func (f CodeSetFunc) Contains(code string) bool { return f(code) }
+
+// A Target is the transaction set a Check is run against, with its
+// surroundings.
+type Target struct {
+	Interchange *x12.Interchange
+	Group       *x12.FunctionGroup
+	Transaction *x12.Transaction
+
+	// Schema is the transaction set schema the transaction was parsed
+	// with and Root the resulting loop tree; both are nil if the
+	// Validator has no schema for the transaction set.
+	Schema *schema.TransactionSet
+	Root   *schema.LoopNode
+}
+
+// A Check is a validation supplied by the caller, typically for levels
+// 3, 6, and 7, whose requirements are specific to a guide, a type of
+// service, or a trading partner.
+type Check struct {
+	Name  string
+	Level Level
+
+	// TransactionSet restricts the check to transaction sets with this
+	// identifier (ST01), such as "837". Empty means all.
+	TransactionSet string
+
+	// Func returns the check's failures. Errors that are *schema.Error
+	// values are attributed to the segment at their Position.
+	Func func(t *Target) []error
+}
+
+// A Validator validates documents level by level. Levels whose inputs
+// are not configured are skipped: without Schemas levels 2, 4, and 5
+// are not checked, and levels 3, 6, and 7 consist only of Checks.
+type Validator struct {
+	// Schemas are the transaction set schemas available for levels 2,
+	// 4, and 5. A transaction set is matched to the schema with its
+	// identifier (ST01) and implementation convention reference (ST03,
+	// or GS08 if ST03 is empty). A reference without an addenda suffix
+	// matches a schema with one, so "005010X222" matches a schema for
+	// "005010X222A1". Where several schemas match, as the 278 request
+	// and response do, the transaction set is validated against the one
+	// it fits best.
+	Schemas []*schema.TransactionSet
+
+	// Overlays are companion guides. A transaction set is validated
+	// against its schema with the first overlay applied whose Base is
+	// the schema's Version and whose Partners match the interchange's
+	// and functional group's sender and receiver IDs. An overlay that
+	// does not fit its base guide is reported at level 7.
+	Overlays []*schema.Overlay
+
+	// Segments holds the base definitions, keyed by segment ID, that
+	// level 1 checks each segment against.
+	Segments map[string]*x12.SegmentDef
+
+	// CodeSets holds the external code lists, keyed by the names schema
+	// elements give in their CodeSet fields, that level 5 checks values
+	// against. Elements naming a code set not present here are not
+	// checked.
+	CodeSets map[string]CodeSet
+
+	// Checks are run against each transaction set they apply to.
+	Checks []Check
+}
+
+// Validate validates doc and reports its findings.
+func (v *Validator) Validate(doc *x12.Document) *Report {
+	r := &Report{}
+	applied := make(map[overlayUse]*schema.TransactionSet)
+	if err := doc.Validate(); err != nil {
+		r.Findings = append(r.Findings, Finding{Level: Integrity, Err: err})
+	}
+	if doc == nil || doc.Interchange == nil {
+		return r
+	}
+	for _, g := range doc.Interchange.FunctionGroups {
+		if g == nil || g.Header == nil {
+			continue
+		}
+		for _, tx := range g.Transactions {
+			if tx == nil || tx.Header == nil {
+				continue
+			}
+			r.Findings = append(r.Findings, v.validateTransaction(doc, g, tx, applied)...)
+		}
+	}
+	return r
+}
+
+// An overlayUse is an overlay applied to a base schema. Several schemas
+// may share a version, as a guide's request and response do, so an
+// overlay matching that version is applied to each separately.
+type overlayUse struct {
+	overlay *schema.Overlay
+	base    *schema.TransactionSet
+}
+
+// validateTransaction validates one transaction set. applied caches
+// the schemas produced by applying overlays during this run.
+func (v *Validator) validateTransaction(doc *x12.Document, g *x12.FunctionGroup, tx *x12.Transaction, applied map[overlayUse]*schema.TransactionSet) []Finding {
+	t := &Target{Interchange: doc.Interchange, Group: g, Transaction: tx, Schema: SchemaFor(v.Schemas, g, tx)}
+	var errs []leveled
+	add := func(level Level, list []error) {
+		for _, err := range list {
+			errs = append(errs, leveled{level, err})
+		}
+	}
+
+	if t.Schema != nil {
+		if o := v.overlayFor(t.Schema, doc.Interchange.Header, g.Header); o != nil {
+			use := overlayUse{o, t.Schema}
+			if applied[use] == nil {
+				ts, err := o.Apply(t.Schema)
+				if err != nil {
+					add(TradingPartner, []error{err})
+					ts = t.Schema
+				}
+				applied[use] = ts
+			}
+			t.Schema = applied[use]
+		}
+	}
+
+	// Level 1.
+	for i, seg := range tx.Segments {
+		if def := v.Segments[seg.ID]; def != nil {
+			for _, err := range def.Check(seg) {
+				errs = append(errs, leveled{Integrity, &positioned{i + 2, err}})
+			}
+		}
+	}
+
+	// Levels 2, 4, and 5.
+	if t.Schema != nil {
+		root, parseErrs := t.Schema.Parse(tx)
+		t.Root = root
+		add(Requirement, parseErrs)
+		add(Requirement, root.CheckUsage())
+		add(Situation, root.CheckRules())
+		add(ExternalCodeSet, v.checkCodeSets(root, separator(doc)))
+	} else if len(v.Schemas) > 0 {
+		add(Requirement, []error{fmt.Errorf("%w: %s %s", ErrNoSchema, tx.Header.IDCode, version(g, tx))})
+	}
+
+	for _, c := range v.Checks {
+		if c.TransactionSet == "" || c.TransactionSet == tx.Header.IDCode {
+			add(c.Level, c.Func(t))
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].level < errs[j].level })
+	claims := ClaimsByPosition(t.Root)
+	findings := make([]Finding, 0, len(errs))
+	for _, e := range errs {
+		f := Finding{Level: e.level, Group: g.Header.ControlNumber, Transaction: tx.Header.ControlNumber, Err: e.err}
+		var perr *positioned
+		var serr *schema.Error
+		switch {
+		case errors.As(e.err, &perr):
+			f.Position, f.Err = perr.pos, perr.err
+		case errors.As(e.err, &serr):
+			f.Position = serr.Position
+		}
+		f.Claim = claims[f.Position]
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// leveled is an error found at a given level.
+type leveled struct {
+	level Level
+	err   error
+}
+
+// positioned attaches a segment position to an error that lacks one.
+type positioned struct {
+	pos int
+	err error
+}
+
+func (e *positioned) Error() string { return e.err.Error() }
+func (e *positioned) Unwrap() error { return e.err }
+
+// SchemaFor returns the schema among schemas for tx, a transaction set
+// of the functional group g, or nil: one with tx's ID whose version is
+// the implementation convention reference tx claims (ST03, or failing
+// that GS08), or that reference followed by an addenda, so that
+// "005010X222" selects "005010X222A1". Of several matching schemas it
+// returns the first that tx fits with the fewest parse and usage
+// errors. Validator.Validate selects schemas with it.
+func SchemaFor(schemas []*schema.TransactionSet, g *x12.FunctionGroup, tx *x12.Transaction) *schema.TransactionSet {
+	ver := version(g, tx)
+	var matches []*schema.TransactionSet
+	for _, ts := range schemas {
+		if ts.ID != tx.Header.IDCode {
+			continue
+		}
+		if ts.Version == ver || strings.HasPrefix(ts.Version, ver) && ver != "" && ts.Version[len(ver)] == 'A' {
+			matches = append(matches, ts)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil
+	case 1:
+		return matches[0]
+	}
+	var best *schema.TransactionSet
+	bestErrs := 0
+	for _, ts := range matches {
+		root, errs := ts.Parse(tx)
+		n := len(errs) + len(root.CheckUsage())
+		if best == nil || n < bestErrs {
+			best, bestErrs = ts, n
+		}
+	}
+	return best
+}
+
+// overlayFor returns the overlay to apply to base for an interchange
+// with the given headers, or nil.
+func (v *Validator) overlayFor(base *schema.TransactionSet, isa *x12.ISA, gs *x12.GS) *schema.Overlay {
+	for _, o := range v.Overlays {
+		if o.Base == base.Version && o.Matches(isa, gs) {
+			return o
+		}
+	}
+	return nil
+}
+
+// version returns the implementation convention reference tx claims:
+// ST03, or failing that GS08.
+func version(g *x12.FunctionGroup, tx *x12.Transaction) string {
+	if tx.Header.ImplementationConventionReference != "" || g == nil || g.Header == nil {
+		return tx.Header.ImplementationConventionReference
+	}
+	return g.Header.Version
+}
+
+// separator returns doc's component element separator.
+func separator(doc *x12.Document) string {
+	if h := doc.Interchange.Header; h != nil && h.ComponentElementSeparator != "" {
+		return h.ComponentElementSeparator
+	}
+	return x12.DefaultComponentSeparator
+}
+
+// checkCodeSets checks the elements of root's segments whose schema
+// names a configured code set.
+func (v *Validator) checkCodeSets(root *schema.LoopNode, sep string) []error {
+	if len(v.CodeSets) == 0 {
+		return nil
+	}
+	var errs []error
+	root.Walk(func(n *schema.LoopNode) {
+		for _, s := range n.Segments {
+			if s.Schema == nil {
+				continue
+			}
+			for i, el := range s.Schema.Elements {
+				if el == nil || el.CodeSet == "" || i >= len(s.Segment.Elements) {
+					continue
+				}
+				set := v.CodeSets[el.CodeSet]
+				if set == nil {
+					continue
+				}
+				code := component(s.Segment.Elements[i], el.Component, sep)
+				if code == "" || set.Contains(code) {
+					continue
+				}
+				errs = append(errs, &schema.Error{
+					Position:  s.Position,
+					SegmentID: s.Segment.ID,
+					LoopID:    n.ID(),
+					Element:   i + 1,
+					Err:       fmt.Errorf("%w %q: not in %s", schema.ErrInvalidCode, code, el.CodeSet),
+				})
+			}
+		}
+	})
+	return errs
+}
+
+// component returns the 1-based component pos of e, or e's whole value
+// if pos is 0.
+func component(e x12.Element, pos int, sep string) string {
+	if pos == 0 {
+		return e.Value
+	}
+	parts := e.Components
+	if parts == nil {
+		parts = strings.Split(e.Value, sep)
+	}
+	if pos > len(parts) {
+		return ""
+	}
+	return parts[pos-1]
+}
+
+// claimSegments are the segments whose loops hold a single claim, with
+// the claim's identifier in their first element.
+var claimSegments = map[string]bool{"CLM": true, "CLP": true}
+
+// ClaimsByPosition maps the position of each segment of root, a
+// transaction set arranged by its schema, that falls within a claim loop
+// (one triggered by a CLM or CLP) to the claim's identifier (CLM01 or
+// CLP01). Finding.Claim is read from it.
+func ClaimsByPosition(root *schema.LoopNode) map[int]string {
+	claims := make(map[int]string)
+	if root == nil {
+		return claims
+	}
+	var mark func(n *schema.LoopNode, id string)
+	mark = func(n *schema.LoopNode, id string) {
+		for _, s := range n.Segments {
+			claims[s.Position] = id
+		}
+		for _, c := range n.Children {
+			mark(c, id)
+		}
+	}
+	root.Walk(func(n *schema.LoopNode) {
+		if t := n.Loop.Trigger(); t != nil && claimSegments[t.ID] && len(n.Segments) > 0 {
+			if seg := n.Segments[0].Segment; len(seg.Elements) > 0 {
+				mark(n, seg.Elements[0].Value)
+			}
+		}
+	})
+	return claims
+}
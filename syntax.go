@@ -0,0 +1,251 @@
+package x12
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A SegmentDef defines a segment as published in an X12 standard: its
+// element positions, in order, and the syntax notes relating them.
+type SegmentDef struct {
+	ID       string       // segment ID, e.g. "PER"
+	Name     string       // e.g. "Administrative Communications Contact"
+	Elements []ElementDef // indexed by element position - 1
+	Syntax   []SyntaxNote
+}
+
+// An ElementDef defines one element position of a segment.
+type ElementDef struct {
+	Name        string
+	Ref         string // data element reference number, e.g. "1028", if known
+	Requirement Requirement
+}
+
+// Requirement is an element's requirement designator within its
+// segment.
+type Requirement byte
+
+// Requirement designators.
+const (
+	Optional   Requirement = 'O'
+	Mandatory  Requirement = 'M'
+	Relational Requirement = 'X' // governed by the segment's syntax notes
+)
+
+// Check reports the ways seg fails to conform to def: mandatory
+// elements that are missing, as *ElementError values, and violated
+// syntax notes, as *SyntaxError values. It returns nil if seg conforms.
+func (def *SegmentDef) Check(seg Segment) []error {
+	if seg.ID != def.ID {
+		return []error{fmt.Errorf("%w: segment %s checked against definition of %s", ErrInvalidArgument, seg.ID, def.ID)}
+	}
+	var errs []error
+	for i, e := range def.Elements {
+		if e.Requirement == Mandatory && !elementPresent(seg, i+1) {
+			errs = append(errs, &ElementError{SegmentID: seg.ID, Element: i + 1, Err: ErrMissingElement})
+		}
+	}
+	for _, note := range def.Syntax {
+		if err := note.Check(seg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// An ElementError describes an element that does not conform to its
+// segment's definition. It wraps one of the package's sentinel errors.
+type ElementError struct {
+	SegmentID string
+	Element   int // 1-based position of the element
+	Err       error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("x12: %s: %v", refDesignator(e.SegmentID, e.Element), e.Err)
+}
+
+func (e *ElementError) Unwrap() error { return e.Err }
+
+// SyntaxType is the kind of relational condition a syntax note
+// expresses, named by the letter that begins the note.
+type SyntaxType byte
+
+// Syntax note types.
+const (
+	// SyntaxPaired: if any of the elements is present, all are
+	// required.
+	SyntaxPaired SyntaxType = 'P'
+	// SyntaxRequired: at least one of the elements is required.
+	SyntaxRequired SyntaxType = 'R'
+	// SyntaxExclusion: no more than one of the elements may be
+	// present.
+	SyntaxExclusion SyntaxType = 'E'
+	// SyntaxConditional: if the first element is present, all of the
+	// others are required.
+	SyntaxConditional SyntaxType = 'C'
+	// SyntaxListConditional: if the first element is present, at least
+	// one of the others is required.
+	SyntaxListConditional SyntaxType = 'L'
+)
+
+// A SyntaxNote is a relational condition between elements of a
+// segment, such as P0304: if either PER03 or PER04 is present, both
+// are required.
+type SyntaxNote struct {
+	Type     SyntaxType
+	Elements []int // 1-based element positions, in the order the note lists them
+}
+
+// ParseSyntaxNote parses a syntax note in its published form: the type
+// letter followed by two-digit element positions, as in "P0304" or
+// "L102030".
+func ParseSyntaxNote(s string) (SyntaxNote, error) {
+	if len(s) < 5 || len(s)%2 != 1 {
+		return SyntaxNote{}, fmt.Errorf("%w: syntax note %q", ErrInvalidArgument, s)
+	}
+	n := SyntaxNote{Type: SyntaxType(s[0])}
+	switch n.Type {
+	case SyntaxPaired, SyntaxRequired, SyntaxExclusion, SyntaxConditional, SyntaxListConditional:
+	default:
+		return SyntaxNote{}, fmt.Errorf("%w: syntax note %q: unknown type %q", ErrInvalidArgument, s, s[0])
+	}
+	for i := 1; i < len(s); i += 2 {
+		pos, err := strconv.Atoi(s[i : i+2])
+		if err != nil || pos < 1 {
+			return SyntaxNote{}, fmt.Errorf("%w: syntax note %q: invalid element position %q", ErrInvalidArgument, s, s[i:i+2])
+		}
+		n.Elements = append(n.Elements, pos)
+	}
+	return n, nil
+}
+
+// MustParseSyntaxNote is like ParseSyntaxNote but panics if s is not a
+// valid syntax note. It simplifies declaring segment definitions.
+func MustParseSyntaxNote(s string) SyntaxNote {
+	n, err := ParseSyntaxNote(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// String returns the note in its published form, e.g. "P0304".
+func (n SyntaxNote) String() string {
+	var b strings.Builder
+	b.WriteByte(byte(n.Type))
+	for _, pos := range n.Elements {
+		fmt.Fprintf(&b, "%02d", pos)
+	}
+	return b.String()
+}
+
+// Check reports whether seg satisfies the note. It returns nil or a
+// *SyntaxError.
+func (n SyntaxNote) Check(seg Segment) error {
+	if len(n.Elements) == 0 {
+		return nil
+	}
+	var present, missing []int
+	for _, pos := range n.Elements {
+		if elementPresent(seg, pos) {
+			present = append(present, pos)
+		} else {
+			missing = append(missing, pos)
+		}
+	}
+	first := elementPresent(seg, n.Elements[0])
+	violation := 0
+	switch n.Type {
+	case SyntaxPaired:
+		if len(present) > 0 && len(missing) > 0 {
+			violation = missing[0]
+		}
+	case SyntaxRequired:
+		if len(present) == 0 {
+			violation = n.Elements[0]
+		}
+	case SyntaxExclusion:
+		if len(present) > 1 {
+			violation = present[1]
+		}
+	case SyntaxConditional:
+		if first && len(missing) > 0 {
+			violation = missing[0]
+		}
+	case SyntaxListConditional:
+		if first && len(present) == 1 && len(n.Elements) > 1 {
+			violation = n.Elements[1]
+		}
+	}
+	if violation == 0 {
+		return nil
+	}
+	return &SyntaxError{SegmentID: seg.ID, Note: n, Element: violation}
+}
+
+// A SyntaxError describes a segment that violates one of its syntax
+// notes. It wraps ErrMissingElement when the note requires an element
+// that is absent, and ErrInvalidFormat for an exclusion, where an
+// element is present that must not be.
+type SyntaxError struct {
+	SegmentID string
+	Note      SyntaxNote
+	// Element is the 1-based position the violation is reported
+	// against: the first required element that is missing, or, for an
+	// exclusion, the second element present.
+	Element int
+}
+
+func (e *SyntaxError) Error() string {
+	refs := make([]string, len(e.Note.Elements))
+	for i, pos := range e.Note.Elements {
+		refs[i] = refDesignator(e.SegmentID, pos)
+	}
+	var rule string
+	switch e.Note.Type {
+	case SyntaxPaired:
+		rule = fmt.Sprintf("if any of %s is present, all must be", strings.Join(refs, ", "))
+	case SyntaxRequired:
+		rule = fmt.Sprintf("at least one of %s is required", strings.Join(refs, ", "))
+	case SyntaxExclusion:
+		rule = fmt.Sprintf("only one of %s may be present", strings.Join(refs, ", "))
+	case SyntaxConditional:
+		rule = fmt.Sprintf("if %s is present, %s must be too", refs[0], strings.Join(refs[1:], ", "))
+	case SyntaxListConditional:
+		rule = fmt.Sprintf("if %s is present, at least one of %s is required", refs[0], strings.Join(refs[1:], ", "))
+	}
+	return fmt.Sprintf("x12: %s: syntax note %v: %s", refDesignator(e.SegmentID, e.Element), e.Note, rule)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	if e.Note.Type == SyntaxExclusion {
+		return ErrInvalidFormat
+	}
+	return ErrMissingElement
+}
+
+// elementPresent reports whether seg carries a non-empty value at the
+// 1-based element position pos.
+func elementPresent(seg Segment, pos int) bool {
+	if pos < 1 || pos > len(seg.Elements) {
+		return false
+	}
+	e := seg.Elements[pos-1]
+	if e.Value != "" {
+		return true
+	}
+	for _, c := range e.Components {
+		if c != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// refDesignator returns the X12 reference designator for an element,
+// the segment ID followed by the two-digit position, e.g. "NM109".
+func refDesignator(segmentID string, pos int) string {
+	return fmt.Sprintf("%s%02d", segmentID, pos)
+}
@@ -0,0 +1,174 @@
+package x12_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+)
+
+// perDef is the 005010 PER segment: PER03/PER04, PER05/PER06, and
+// PER07/PER08 are each paired.
+var perDef = &x12.SegmentDef{
+	ID:   "PER",
+	Name: "Administrative Communications Contact",
+	Elements: []x12.ElementDef{
+		{Name: "Contact Function Code", Requirement: x12.Mandatory},
+		{Name: "Name", Requirement: x12.Optional},
+		{Name: "Communication Number Qualifier", Requirement: x12.Relational},
+		{Name: "Communication Number", Requirement: x12.Relational},
+		{Name: "Communication Number Qualifier", Requirement: x12.Relational},
+		{Name: "Communication Number", Requirement: x12.Relational},
+		{Name: "Communication Number Qualifier", Requirement: x12.Relational},
+		{Name: "Communication Number", Requirement: x12.Relational},
+		{Name: "Contact Inquiry Reference", Requirement: x12.Optional},
+	},
+	Syntax: []x12.SyntaxNote{
+		x12.MustParseSyntaxNote("P0304"),
+		x12.MustParseSyntaxNote("P0506"),
+		x12.MustParseSyntaxNote("P0708"),
+	},
+}
+
+func segment(id string, values ...string) x12.Segment {
+	seg := x12.Segment{ID: id}
+	for _, v := range values {
+		seg.Elements = append(seg.Elements, x12.Element{Value: v})
+	}
+	return seg
+}
+
+func TestParseSyntaxNote(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    x12.SyntaxNote
+		wantErr bool
+	}{
+		{in: "P0304", want: x12.SyntaxNote{Type: x12.SyntaxPaired, Elements: []int{3, 4}}},
+		{in: "R0203", want: x12.SyntaxNote{Type: x12.SyntaxRequired, Elements: []int{2, 3}}},
+		{in: "C0405", want: x12.SyntaxNote{Type: x12.SyntaxConditional, Elements: []int{4, 5}}},
+		{in: "E0102", want: x12.SyntaxNote{Type: x12.SyntaxExclusion, Elements: []int{1, 2}}},
+		{in: "L102030", want: x12.SyntaxNote{Type: x12.SyntaxListConditional, Elements: []int{10, 20, 30}}},
+		{in: "P03", wantErr: true},
+		{in: "P030", wantErr: true},
+		{in: "Z0304", wantErr: true},
+		{in: "P03x4", wantErr: true},
+		{in: "P0003", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := x12.ParseSyntaxNote(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, x12.ErrInvalidArgument) {
+					t.Fatalf("ParseSyntaxNote() error = %v, want ErrInvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseSyntaxNote() mismatch (-want +got):\n%s", diff)
+			}
+			if got.String() != tt.in {
+				t.Errorf("String() = %q, want %q", got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestSyntaxNoteCheck(t *testing.T) {
+	tests := []struct {
+		note        string
+		values      []string
+		wantElement int // 0 if the note is satisfied
+	}{
+		{"P0304", []string{"IC", "JERRY", "TE", "3055552222"}, 0},
+		{"P0304", []string{"IC", "JERRY"}, 0},
+		{"P0304", []string{"IC", "JERRY", "TE"}, 4},
+		{"P0304", []string{"IC", "JERRY", "", "3055552222"}, 3},
+		{"R0203", []string{"A", "", "C"}, 0},
+		{"R0203", []string{"A"}, 2},
+		{"E0102", []string{"A"}, 0},
+		{"E0102", []string{"A", "B"}, 2},
+		{"E010203", []string{"", "B", "C"}, 3},
+		{"C0405", []string{"", "", "", "", "E"}, 0},
+		{"C0405", []string{"", "", "", "D", "E"}, 0},
+		{"C0405", []string{"", "", "", "D"}, 5},
+		{"C040506", []string{"", "", "", "D", "E"}, 6},
+		{"L010203", []string{"A", "", "C"}, 0},
+		{"L010203", []string{"", "B"}, 0},
+		{"L010203", []string{"A"}, 2},
+	}
+	for _, tt := range tests {
+		seg := segment("TST", tt.values...)
+		err := x12.MustParseSyntaxNote(tt.note).Check(seg)
+		if tt.wantElement == 0 {
+			if err != nil {
+				t.Errorf("%s.Check(%v) = %v, want nil", tt.note, tt.values, err)
+			}
+			continue
+		}
+		var serr *x12.SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("%s.Check(%v) = %v, want *SyntaxError", tt.note, tt.values, err)
+			continue
+		}
+		if serr.Element != tt.wantElement {
+			t.Errorf("%s.Check(%v) element = %d, want %d", tt.note, tt.values, serr.Element, tt.wantElement)
+		}
+		wantSentinel := x12.ErrMissingElement
+		if tt.note[0] == 'E' {
+			wantSentinel = x12.ErrInvalidFormat
+		}
+		if !errors.Is(err, wantSentinel) {
+			t.Errorf("%s.Check(%v) = %v, want wrapping %v", tt.note, tt.values, err, wantSentinel)
+		}
+	}
+}
+
+func TestSegmentDefCheck(t *testing.T) {
+	if errs := perDef.Check(segment("PER", "IC", "JERRY", "TE", "3055552222")); errs != nil {
+		t.Errorf("Check() = %v, want nil", errs)
+	}
+
+	// A telephone qualifier without the number it qualifies.
+	errs := perDef.Check(segment("PER", "IC", "JERRY", "TE"))
+	if len(errs) != 1 {
+		t.Fatalf("Check() = %v, want one error", errs)
+	}
+	const want = "x12: PER04: syntax note P0304: if any of PER03, PER04 is present, all must be"
+	if got := errs[0].Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	errs = perDef.Check(segment("PER", "", "JERRY", "", "", "EM"))
+	if len(errs) != 2 {
+		t.Fatalf("Check() = %v, want two errors", errs)
+	}
+	var eerr *x12.ElementError
+	if !errors.As(errs[0], &eerr) || eerr.Element != 1 || !errors.Is(eerr, x12.ErrMissingElement) {
+		t.Errorf("errs[0] = %v, want missing PER01", errs[0])
+	}
+	if got, want := errs[0].Error(), "x12: PER01: missing element"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	var serr *x12.SyntaxError
+	if !errors.As(errs[1], &serr) || serr.Element != 6 {
+		t.Errorf("errs[1] = %v, want PER06 syntax error", errs[1])
+	}
+
+	if errs := perDef.Check(segment("NM1", "41")); len(errs) != 1 || !errors.Is(errs[0], x12.ErrInvalidArgument) {
+		t.Errorf("Check() of mismatched segment = %v, want ErrInvalidArgument", errs)
+	}
+}
+
+func TestSyntaxNoteCheckComponents(t *testing.T) {
+	// An element whose Value is empty but whose Components are not is
+	// present.
+	seg := x12.Segment{ID: "TST", Elements: []x12.Element{{}, {Components: []string{"X"}}}}
+	if err := x12.MustParseSyntaxNote("R0102").Check(seg); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+}
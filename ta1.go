@@ -0,0 +1,154 @@
+package x12
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Interchange acknowledgment codes (TA104).
+const (
+	InterchangeAccepted           = "A"
+	InterchangeAcceptedWithErrors = "E"
+	InterchangeRejected           = "R"
+)
+
+// ErrUnknownReceiver reports an interchange addressed to a receiver other
+// than the ones expected. CheckReceiver wraps it.
+var ErrUnknownReceiver = errors.New("unknown interchange receiver")
+
+// CheckReceiver returns an *EnvelopeError wrapping ErrUnknownReceiver
+// unless isa's receiver ID (ISA08, without its padding) is one of ids.
+func (isa *ISA) CheckReceiver(ids ...string) error {
+	id := strings.TrimSpace(isa.ReceiverID)
+	for _, want := range ids {
+		if id == strings.TrimSpace(want) {
+			return nil
+		}
+	}
+	return envelopeErrorf("ISA", 8, "%w: ISA08 %q", ErrUnknownReceiver, isa.ReceiverID)
+}
+
+// interchangeNotes maps the elements of the ISA and IEA to the TA105
+// note codes reporting an invalid value, with element 0 for a segment
+// that is missing or repeated.
+var interchangeNotes = map[string]map[int]string{
+	"ISA": {
+		0:  "022", // invalid control structure
+		1:  "010", // invalid authorization information qualifier value
+		2:  "011", // invalid authorization information value
+		3:  "012", // invalid security information qualifier value
+		4:  "013", // invalid security information value
+		5:  "005", // invalid interchange ID qualifier for sender
+		6:  "006", // invalid interchange sender ID
+		7:  "007", // invalid interchange ID qualifier for receiver
+		8:  "008", // invalid interchange receiver ID
+		9:  "014", // invalid interchange date value
+		10: "015", // invalid interchange time value
+		11: "016", // invalid interchange standards identifier value
+		12: "017", // invalid interchange version ID value
+		13: "018", // invalid interchange control number value
+		14: "019", // invalid acknowledgment requested value
+		15: "020", // invalid test indicator value
+		16: "027", // invalid component element separator
+	},
+	"IEA": {
+		0: "023", // improper (premature) end-of-file
+		1: "021", // invalid number of included groups value
+		2: "001", // interchange control numbers do not match
+	},
+}
+
+// NewTA1 returns the TA1 acknowledging the interchange with header isa,
+// given err, the error Decoder.Decode or Document.Validate returned for
+// it, or nil.
+//
+// An error in the ISA or IEA, or one that kept Decode from reading the
+// interchange, rejects it, with the note code (TA105) the error's
+// segment and element call for: "008" for an invalid receiver ID, or
+// "009" for one CheckReceiver reports unknown, "014" for an invalid
+// date, "001" for an IEA02 that disagrees with ISA13, "024" for invalid
+// content, and so on. An error in a GS or GE accepts the interchange with
+// errors (note "024"), and the interchange is otherwise accepted (note
+// "000"): errors in its transaction sets are reported by an
+// implementation or functional acknowledgment. NewTA1 always returns a
+// rejection, but an acceptance only if isa requests one (ISA14 is "1"),
+// and nil otherwise. It returns nil if isa is nil, as it is when Decode
+// fails before reading an ISA: there is no control number to
+// acknowledge.
+func NewTA1(isa *ISA, err error) *TA1 {
+	if isa == nil {
+		return nil
+	}
+	ta1 := &TA1{
+		ControlNumber:      isa.ControlNumber,
+		Date:               isa.Date,
+		Time:               isa.Time,
+		AcknowledgmentCode: InterchangeAccepted,
+		NoteCode:           "000",
+	}
+	var (
+		eerr *EnvelopeError
+		perr *ParseError
+	)
+	switch {
+	case err == nil:
+	case errors.As(err, &eerr):
+		switch eerr.SegmentID {
+		case "ISA", "IEA":
+			ta1.AcknowledgmentCode, ta1.NoteCode = InterchangeRejected, interchangeNote(eerr.SegmentID, eerr.Element, err)
+		case "GS", "GE":
+			ta1.AcknowledgmentCode, ta1.NoteCode = InterchangeAcceptedWithErrors, "024"
+		}
+	case errors.As(err, &perr):
+		ta1.AcknowledgmentCode, ta1.NoteCode = InterchangeRejected, interchangeNote(perr.SegmentID, perr.Element, err)
+		if perr.Element == 0 && (perr.SegmentID == "ISA" || perr.SegmentID == "IEA") {
+			ta1.NoteCode = "022" // a repeated ISA or IEA: invalid control structure
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		ta1.AcknowledgmentCode, ta1.NoteCode = InterchangeRejected, "023"
+	default:
+		ta1.AcknowledgmentCode, ta1.NoteCode = InterchangeRejected, "024"
+	}
+	if ta1.AcknowledgmentCode != InterchangeRejected && strings.TrimSpace(isa.AcknowledgmentRequested) != "1" {
+		return nil
+	}
+	return ta1
+}
+
+// interchangeNote returns the TA105 note code of err, an error in the
+// element of segment id.
+func interchangeNote(id string, element int, err error) string {
+	if id == "ISA" && element == 8 && errors.Is(err, ErrUnknownReceiver) {
+		return "009" // unknown interchange receiver ID
+	}
+	if code, ok := interchangeNotes[id][element]; ok {
+		return code
+	}
+	return "024" // invalid interchange content
+}
+
+// AcknowledgeInterchange returns an interchange carrying the TA1 that
+// NewTA1 returns for the interchange with header isa and err, or nil if
+// no TA1 is due. The interchange, which has no functional groups, is
+// addressed and numbered as isa.Reply makes it.
+func AcknowledgeInterchange(isa *ISA, err error, control string, now time.Time) (*Document, error) {
+	if isa == nil {
+		return nil, fmt.Errorf("%w: no interchange header to acknowledge", ErrInvalidArgument)
+	}
+	ta1 := NewTA1(isa, err)
+	if ta1 == nil {
+		return nil, nil
+	}
+	reply, rerr := isa.Reply(control, now)
+	if rerr != nil {
+		return nil, rerr
+	}
+	return &Document{Interchange: &Interchange{
+		Header:          reply,
+		Acknowledgments: []*TA1{ta1},
+		Trailer:         &IEA{FunctionalGroupCount: "0", ControlNumber: reply.ControlNumber},
+	}}, nil
+}
@@ -0,0 +1,158 @@
+package x12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/x12"
+)
+
+func TestNewTA1(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested bool
+		mutate    func(*x12.Document)
+		check     func(*x12.Document) error
+		want      *x12.TA1
+	}{
+		{name: "accepted, not requested", mutate: func(*x12.Document) {}},
+		{name: "accepted", requested: true, mutate: func(*x12.Document) {}, want: &x12.TA1{AcknowledgmentCode: "A", NoteCode: "000"}},
+		{name: "invalid date", mutate: func(d *x12.Document) { d.Interchange.Header.Date = "041232" }, want: &x12.TA1{AcknowledgmentCode: "R", NoteCode: "014"}},
+		{name: "invalid receiver ID", mutate: func(d *x12.Document) { d.Interchange.Header.ReceiverID = "1234567890123456" }, want: &x12.TA1{AcknowledgmentCode: "R", NoteCode: "008"}},
+		{
+			name:   "unknown receiver ID",
+			mutate: func(*x12.Document) {},
+			check:  func(d *x12.Document) error { return d.Interchange.Header.CheckReceiver("987654321") },
+			want:   &x12.TA1{AcknowledgmentCode: "R", NoteCode: "009"},
+		},
+		{name: "control number mismatch", mutate: func(d *x12.Document) { d.Interchange.Trailer.ControlNumber = "000095072" }, want: &x12.TA1{AcknowledgmentCode: "R", NoteCode: "001"}},
+		{name: "group count", mutate: func(d *x12.Document) { d.Interchange.Trailer.FunctionalGroupCount = "2" }, want: &x12.TA1{AcknowledgmentCode: "R", NoteCode: "021"}},
+		{name: "invalid GS, not requested", mutate: func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Date = "20041232" }},
+		{name: "invalid GS", requested: true, mutate: func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Date = "20041232" }, want: &x12.TA1{AcknowledgmentCode: "E", NoteCode: "024"}},
+		{
+			name:      "transaction set error",
+			requested: true,
+			mutate:    func(d *x12.Document) { d.Interchange.FunctionGroups[0].Transactions[0].Trailer.SegmentCount = "99" },
+			want:      &x12.TA1{AcknowledgmentCode: "A", NoteCode: "000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := x12.Decode(strings.NewReader(exampleEDI))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.requested {
+				doc.Interchange.Header.AcknowledgmentRequested = "1"
+			}
+			tt.mutate(doc)
+			check := (*x12.Document).Validate
+			if tt.check != nil {
+				check = tt.check
+			}
+			if tt.want != nil {
+				tt.want.ControlNumber, tt.want.Date, tt.want.Time = "000095071", doc.Interchange.Header.Date, "0805"
+			}
+			if diff := cmp.Diff(tt.want, x12.NewTA1(doc.Interchange.Header, check(doc))); diff != "" {
+				t.Errorf("NewTA1() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTA1DecodeError(t *testing.T) {
+	const isa = `ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       *230101*1200*^*00501*000000001*0*P*:~`
+	tests := []struct {
+		name string
+		body string
+		note string
+	}{
+		{"segment outside transaction", `GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010~NM1*41*2*ACME~`, "024"},
+		{"repeated IEA", `IEA*0*000000001~IEA*0*000000001~`, "022"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := x12.NewDecoder(strings.NewReader(isa + tt.body))
+			_, err := dec.Decode()
+			if err == nil {
+				t.Fatal("Decode() succeeded, want an error")
+			}
+			if dec.Header() == nil {
+				t.Fatal("Header() = nil after the ISA was read")
+			}
+			want := &x12.TA1{ControlNumber: "000000001", Date: "230101", Time: "1200", AcknowledgmentCode: "R", NoteCode: tt.note}
+			if diff := cmp.Diff(want, x12.NewTA1(dec.Header(), err)); diff != "" {
+				t.Errorf("NewTA1() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	dec := x12.NewDecoder(strings.NewReader(`ST*837*0001~NM1*41*2*ACME~SE*3*0001~ST*837*0002~SE*2*0002~`))
+	_, err := dec.Decode()
+	if err == nil {
+		t.Fatal("Decode() succeeded, want an error")
+	}
+	if isa := dec.Header(); isa != nil && isa.SenderID != "" {
+		t.Errorf("Header() = %+v for input without an ISA", isa)
+	}
+	if ta1 := x12.NewTA1(nil, err); ta1 != nil {
+		t.Errorf("NewTA1(nil, err) = %+v, want nil", ta1)
+	}
+}
+
+func TestAcknowledgeInterchange(t *testing.T) {
+	doc, err := x12.Decode(strings.NewReader(exampleEDI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	isa := doc.Interchange.Header
+	isa.Date = "041232"
+	ack, err := x12.AcknowledgeInterchange(isa, doc.Validate(), "42", time.Date(2004, 12, 17, 9, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("AcknowledgeInterchange() error: %v", err)
+	}
+	out, err := x12.Marshal(ack, x12.WithNewlines())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `ISA*00*          *00*          *ZZ*123456789      *08*9254110060     *041217*0930*U*00501*000000042*0*P*>~
+TA1*000095071*041232*0805*R*014~
+IEA*0*000000042~
+`
+	if diff := cmp.Diff(want, string(out)); diff != "" {
+		t.Errorf("TA1 interchange mismatch (-want +got):\n%s", diff)
+	}
+	got, err := x12.Decode(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+	if diff := cmp.Diff(ack.Interchange.Acknowledgments, got.Interchange.Acknowledgments); diff != "" {
+		t.Errorf("decoded TA1 mismatch (-want +got):\n%s", diff)
+	}
+
+	isa.Date = "041216"
+	if ack, err := x12.AcknowledgeInterchange(isa, nil, "42", time.Now()); ack != nil || err != nil {
+		t.Errorf("AcknowledgeInterchange() = %v, %v for an accepted interchange not requesting a TA1, want nil, nil", ack, err)
+	}
+	if _, err := x12.AcknowledgeInterchange(isa, errors.New("bad"), "", time.Now()); !errors.Is(err, x12.ErrInvalidArgument) {
+		t.Errorf("AcknowledgeInterchange() with no control number error = %v, want %v", err, x12.ErrInvalidArgument)
+	}
+}
+
+func TestValidateEnvelopeError(t *testing.T) {
+	doc, err := x12.Decode(strings.NewReader(exampleEDI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Interchange.FunctionGroups[0].Header.Time = "0860"
+	var eerr *x12.EnvelopeError
+	if err := doc.Validate(); !errors.As(err, &eerr) || eerr.SegmentID != "GS" || eerr.Element != 5 {
+		t.Errorf("Validate() = %v, want an *EnvelopeError at GS05", err)
+	}
+}
@@ -20,7 +20,12 @@ type Document struct {
 
 // Interchange is the envelope for an X12 interchange.
 type Interchange struct {
-	Header         *ISA
+	Header *ISA
+
+	// Acknowledgments are the interchange acknowledgments (TA1) the
+	// interchange carries ahead of its functional groups.
+	Acknowledgments []*TA1 `json:",omitempty"`
+
 	FunctionGroups []*FunctionGroup
 	Trailer        *IEA
 }
@@ -52,6 +57,16 @@ type ISA struct {
 	ComponentElementSeparator string // ISA16
 }
 
+// TA1 is the Interchange Acknowledgment: whether an interchange was
+// accepted, accepted with errors, or rejected, and why.
+type TA1 struct {
+	ControlNumber      string // TA101, the ISA13 acknowledged
+	Date               string // TA102, its ISA09
+	Time               string // TA103, its ISA10
+	AcknowledgmentCode string // TA104: "A", "E", or "R"
+	NoteCode           string // TA105, e.g. "000" for no error
+}
+
 // IEA is the Interchange Control Trailer.
 type IEA struct {
 	FunctionalGroupCount string // IEA01
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -418,6 +419,141 @@ func TestValidateCounts(t *testing.T) {
 	}
 }
 
+func TestValidateEnvelopeValues(t *testing.T) {
+	decode := func(t *testing.T) *x12.Document {
+		t.Helper()
+		doc, err := x12.Decode(strings.NewReader(exampleEDI))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+	tests := []struct {
+		name   string
+		mutate func(*x12.Document)
+	}{
+		{"ISA01 code", func(d *x12.Document) { d.Interchange.Header.AuthorizationInfoQualifier = "99" }},
+		{"ISA02 too long", func(d *x12.Document) { d.Interchange.Header.AuthorizationInformation = "ABCDEFGHIJK" }},
+		{"ISA03 code", func(d *x12.Document) { d.Interchange.Header.SecurityInfoQualifier = "02" }},
+		{"ISA05 code", func(d *x12.Document) { d.Interchange.Header.SenderIDQualifier = "XX" }},
+		{"ISA06 too long", func(d *x12.Document) { d.Interchange.Header.SenderID = "1234567890123456" }},
+		{"ISA06 wrong qualifier", func(d *x12.Document) {
+			d.Interchange.Header.SenderIDQualifier = "01"
+			d.Interchange.Header.SenderID = "123456789012   "
+		}},
+		{"ISA07 missing", func(d *x12.Document) { d.Interchange.Header.ReceiverIDQualifier = "" }},
+		{"ISA09 invalid date", func(d *x12.Document) { d.Interchange.Header.Date = "991399" }},
+		{"ISA09 February 30", func(d *x12.Document) { d.Interchange.Header.Date = "230230" }},
+		{"ISA10 invalid time", func(d *x12.Document) { d.Interchange.Header.Time = "2460" }},
+		{"ISA11 element separator", func(d *x12.Document) { d.Interchange.Header.RepetitionSeparator = "*" }},
+		{"ISA12 not numeric", func(d *x12.Document) { d.Interchange.Header.Version = "0050A" }},
+		{"ISA13 not numeric", func(d *x12.Document) {
+			d.Interchange.Header.ControlNumber = "00009507A"
+			d.Interchange.Trailer.ControlNumber = "00009507A"
+		}},
+		{"ISA13 short", func(d *x12.Document) {
+			d.Interchange.Header.ControlNumber = "95071"
+			d.Interchange.Trailer.ControlNumber = "95071"
+		}},
+		{"ISA14 code", func(d *x12.Document) { d.Interchange.Header.AcknowledgmentRequested = "2" }},
+		{"ISA15 code", func(d *x12.Document) { d.Interchange.Header.UsageIndicator = "X" }},
+		{"ISA16 segment terminator", func(d *x12.Document) { d.Interchange.Header.ComponentElementSeparator = "~" }},
+		{"ISA16 repetition separator", func(d *x12.Document) {
+			d.Interchange.Header.RepetitionSeparator = "^"
+			d.Interchange.Header.ComponentElementSeparator = "^"
+		}},
+		{"ISA16 missing", func(d *x12.Document) { d.Interchange.Header.ComponentElementSeparator = "" }},
+		{"GS01 too long", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.FunctionalIDCode = "AGX" }},
+		{"GS02 too short", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.SenderCode = "A" }},
+		{"GS04 invalid date", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Date = "20041232" }},
+		{"GS04 two-digit year", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Date = "041216" }},
+		{"GS05 invalid time", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Time = "0860" }},
+		{"GS06 not numeric", func(d *x12.Document) {
+			d.Interchange.FunctionGroups[0].Header.ControlNumber = "A1"
+			d.Interchange.FunctionGroups[0].Trailer.ControlNumber = "A1"
+		}},
+		{"GS07 code", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.ResponsibleAgencyCode = "Z" }},
+		{"GS08 too long", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Version = "005010X222A1X" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decode(t)
+			if err := doc.Validate(); err != nil {
+				t.Fatalf("Validate() before mutation = %v", err)
+			}
+			tt.mutate(doc)
+			if err := doc.Validate(); !errors.Is(err, x12.ErrInvalidFormat) {
+				t.Errorf("Validate() = %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+
+	valid := []struct {
+		name   string
+		mutate func(*x12.Document)
+	}{
+		{"ISA11 repetition separator", func(d *x12.Document) { d.Interchange.Header.RepetitionSeparator = "^" }},
+		{"ISA06 DUNS", func(d *x12.Document) {
+			d.Interchange.Header.SenderIDQualifier = "01"
+			d.Interchange.Header.SenderID = "123456789      "
+		}},
+		{"GS05 decimal seconds", func(d *x12.Document) { d.Interchange.FunctionGroups[0].Header.Time = "08051299" }},
+		{"ISA09 leap day", func(d *x12.Document) { d.Interchange.Header.Date = "240229" }},
+	}
+	for _, tt := range valid {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decode(t)
+			tt.mutate(doc)
+			if err := doc.Validate(); err != nil {
+				t.Errorf("Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateGroupConsistency(t *testing.T) {
+	const isa = `ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       *230101*1200*^*00501*000000001*0*P*:~`
+	tests := []struct {
+		name    string
+		group   string
+		wantErr bool
+	}{
+		{"837 in HC", `GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010X222A1~ST*837*0001*005010X222A1~BHT*0019~SE*3*0001~GE*1*1~`, false},
+		{"GS08 version only", `GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010~ST*837*0001*005010X222A1~BHT*0019~SE*3*0001~GE*1*1~`, false},
+		{"ST03 without addenda", `GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010X222A1~ST*837*0001*005010X222~BHT*0019~SE*3*0001~GE*1*1~`, false},
+		{"no ST03", `GS*HP*SENDER*RECEIVER*20230101*1200*1*X*005010X221A1~ST*835*0001~BPR*I~SE*3*0001~GE*1*1~`, false},
+		{"unknown transaction set", `GS*ZZ*SENDER*RECEIVER*20230101*1200*1*X*005010~ST*123*0001~BHT*0019~SE*3*0001~GE*1*1~`, false},
+		{"999 in FA", `GS*FA*SENDER*RECEIVER*20230101*1200*1*X*005010X231A1~ST*999*0001*005010X231A1~AK1*HC*1*005010X222A1~SE*3*0001~GE*1*1~`, false},
+		{"837 in HP", `GS*HP*SENDER*RECEIVER*20230101*1200*1*X*005010X222A1~ST*837*0001*005010X222A1~BHT*0019~SE*3*0001~GE*1*1~`, true},
+		{"271 in HS", `GS*HS*SENDER*RECEIVER*20230101*1200*1*X*005010X279A1~ST*271*0001*005010X279A1~BHT*0022~SE*3*0001~GE*1*1~`, true},
+		{"ST03 disagrees", `GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010X222A1~ST*837*0001*005010X223A2~BHT*0019~SE*3*0001~GE*1*1~`, true},
+		{"mixed transaction sets", `GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010~ST*837*0001~BHT*0019~SE*3*0001~ST*835*0002~BPR*I~SE*3*0002~GE*2*1~`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := x12.Decode(strings.NewReader(isa + tt.group + `IEA*1*000000001~`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = doc.Validate()
+			if tt.wantErr && !errors.Is(err, x12.ErrInvalidFormat) {
+				t.Errorf("Validate() = %v, want ErrInvalidFormat", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestFunctionalIDCode(t *testing.T) {
+	for id, want := range map[string]string{"837": "HC", "835": "HP", "270": "HS", "271": "HB", "277": "HN", "999": "FA", "123": ""} {
+		if got := x12.FunctionalIDCode(id); got != want {
+			t.Errorf("FunctionalIDCode(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
 func TestDecodeDiscoversDelimiters(t *testing.T) {
 	// A canonical fixed-width ISA using | as the element separator,
 	// > as the component separator (ISA16), and newline as the
@@ -645,6 +781,29 @@ func TestDecodeLeadingTerminator(t *testing.T) {
 	}
 }
 
+func TestDecodeBinarySegment(t *testing.T) {
+	// BIN02 is read by the length in BIN01, so delimiters and line
+	// breaks within it are data.
+	const data = "%PDF*1~\r\n"
+	input := "ST*275*0001~BIN*" + strconv.Itoa(len(data)) + "*" + data + "~SE*3*0001~"
+	doc, err := x12.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	tx := doc.Interchange.FunctionGroups[0].Transactions[0]
+	want := []x12.Segment{{ID: "BIN", Elements: []x12.Element{{Value: strconv.Itoa(len(data))}, {Value: data}}}}
+	if diff := cmp.Diff(want, tx.Segments); diff != "" {
+		t.Errorf("segments mismatch (-want +got):\n%s", diff)
+	}
+	out, err := x12.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Errorf("Marshal() = %q, want %q", out, input)
+	}
+}
+
 func TestDecodeLargeSegment(t *testing.T) {
 	// Segments over bufio.Scanner's 64KB default must still decode; ones
 	// over the default 1MB bound must fail with a wrapped, identifiable
@@ -829,7 +988,7 @@ func TestDecodeVariableISA(t *testing.T) {
 	// A well-formed but non-canonical ISA (variable-width fields, no
 	// padding) must decode under default options and round-trip.
 	const input = `ISA*00**00**ZZ*SENDER*ZZ*RECEIVER*230101*1200*^*00501*000000001*0*P*:~` +
-		`GS*HC*S*R*20230101*1200*1*X*005010~` +
+		`GS*HC*SENDER*RECEIVER*20230101*1200*1*X*005010~` +
 		`ST*837*0001~NM1*41*2*ACME~SE*3*0001~` +
 		`GE*1*1~IEA*1*000000001~`
 	doc, err := x12.Decode(strings.NewReader(input))